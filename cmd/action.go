@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/nektos/act/pkg/model"
+	"github.com/nektos/act/pkg/runner"
+)
+
+func newActionCommand(ctx context.Context, input *Input) *cobra.Command {
+	actionCmd := &cobra.Command{
+		Use:   "action <path|owner/repo@ref|docker://image> [flags]",
+		Short: "Run a single action without a workflow and print its outputs, state and step results as JSON",
+		Args:  cobra.ExactArgs(1),
+		RunE:  newActionRunCommand(ctx, input),
+	}
+
+	actionCmd.Flags().StringVar(&input.runsOn, "runs-on", "ubuntu-latest", "platform of the synthetic job running the action, resolved with --platform")
+	return actionCmd
+}
+
+func newActionRunCommand(ctx context.Context, input *Input) func(*cobra.Command, []string) error {
+	return func(_ *cobra.Command, args []string) error {
+		if input.jsonLogger {
+			log.SetFormatter(&log.JSONFormatter{})
+		}
+
+		uses, err := actionUses(input.Workdir(), args[0])
+		if err != nil {
+			return err
+		}
+
+		log.Debugf("Loading action inputs from %s", input.Inputfile())
+		with := parseEnvs(input.inputs)
+		_ = readEnvs(input.Inputfile(), with)
+
+		plan, err := runner.NewActionPlan(uses, with, input.runsOn)
+		if err != nil {
+			return err
+		}
+
+		actionMocks, err := input.newActionMocks()
+		if err != nil {
+			return err
+		}
+		exports, err := input.newJobExports(plan)
+		if err != nil {
+			return err
+		}
+
+		setupContainerEngine(input)
+
+		envs, secrets, vars := loadRunEnvironment(ctx, input)
+		config := newRunnerConfig(input, "workflow_dispatch", envs, map[string]string{}, secrets, vars, map[string]map[string]bool{}, actionMocks)
+		config.Exports = exports
+
+		result := &runner.ActionResult{}
+		executor, err := runner.NewActionExecutor(config, plan, result)
+		if err != nil {
+			return err
+		}
+		err = executeWithServers(ctx, input, config, executor, false)
+
+		out, jsonErr := json.MarshalIndent(result, "", "  ")
+		if jsonErr != nil {
+			return jsonErr
+		}
+		fmt.Println(string(out))
+		return err
+	}
+}
+
+// actionUses converts the action argument to the uses value of a step,
+// local actions have to be located inside the working directory
+func actionUses(workdir string, action string) (string, error) {
+	if strings.HasPrefix(action, "docker://") {
+		return action, nil
+	}
+
+	actionPath := action
+	if !filepath.IsAbs(actionPath) {
+		actionPath = filepath.Join(workdir, actionPath)
+	}
+	if _, err := os.Stat(actionPath); err == nil {
+		rel, err := filepath.Rel(workdir, actionPath)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return "", fmt.Errorf("action '%s' must be located inside the working directory '%s'", action, workdir)
+		}
+		if rel == "." {
+			return "./", nil
+		}
+		return "./" + filepath.ToSlash(rel), nil
+	}
+
+	step := &model.Step{Uses: action}
+	if step.Type() == model.StepTypeUsesActionRemote && strings.Contains(action, "@") {
+		return action, nil
+	}
+	return "", fmt.Errorf("action '%s' not found", action)
+}
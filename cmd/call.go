@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/nektos/act/pkg/model"
+	"github.com/nektos/act/pkg/runner"
+)
+
+func newCallCommand(ctx context.Context, input *Input) *cobra.Command {
+	callCmd := &cobra.Command{
+		Use:   "call <workflow> [flags]",
+		Short: "Run a reusable workflow (on: workflow_call) standalone and print its outputs as JSON",
+		Args:  cobra.ExactArgs(1),
+		RunE:  newCallRunCommand(ctx, input),
+	}
+
+	callCmd.Flags().StringArrayVar(&input.with, "with", []string{}, "input to pass to the reusable workflow (e.g. --with myinput=foo)")
+	callCmd.Flags().StringVar(&input.withfile, "with-file", "", "file with inputs to pass to the reusable workflow (e.g. --with-file .with)")
+	return callCmd
+}
+
+func newCallRunCommand(ctx context.Context, input *Input) func(*cobra.Command, []string) error {
+	return func(_ *cobra.Command, args []string) error {
+		if input.jsonLogger {
+			log.SetFormatter(&log.JSONFormatter{})
+		}
+
+		setupContainerEngine(input)
+
+		envs, secrets, vars := loadRunEnvironment(ctx, input)
+
+		with := parseEnvs(input.with)
+		if input.withfile != "" {
+			log.Debugf("Loading workflow inputs from %s", input.Withfile())
+			if !readEnvs(input.Withfile(), with) {
+				return fmt.Errorf("unable to read inputs from %s", input.Withfile())
+			}
+		}
+
+		plan, err := planWorkflowCall(input.resolve(args[0]), input.strict)
+		if err != nil {
+			return err
+		}
+
+		actionMocks, err := input.newActionMocks()
+		if err != nil {
+			return err
+		}
+
+		config := plan.Stages[0].Runs[0].Workflow.WorkflowCallConfig()
+		inputs := make(map[string]interface{}, len(with))
+		for k, v := range with {
+			inputs[k] = v
+		}
+		if err := config.Validate(inputs, secrets); err != nil {
+			return fmt.Errorf("invalid call of reusable workflow '%s': %w", args[0], err)
+		}
+
+		exports, err := input.newJobExports(plan)
+		if err != nil {
+			return err
+		}
+
+		runnerConfig := newRunnerConfig(input, "workflow_call", envs, with, secrets, vars, map[string]map[string]bool{}, actionMocks)
+		runnerConfig.Exports = exports
+		if err := executePlan(ctx, input, runnerConfig, plan, false); err != nil {
+			return err
+		}
+
+		outputs, err := runner.EvaluateWorkflowCallOutputs(ctx, runnerConfig, plan)
+		if err != nil {
+			return err
+		}
+		out, err := json.MarshalIndent(outputs, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+}
+
+// planWorkflowCall plans all jobs of a single workflow triggered by workflow_call
+func planWorkflowCall(path string, strict bool) (*model.Plan, error) {
+	planner, err := model.NewWorkflowPlanner(path, true, strict)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := planner.PlanEvent("workflow_call")
+	if err != nil {
+		return nil, err
+	}
+	if len(plan.Stages) == 0 {
+		return nil, fmt.Errorf("workflow '%s' is not triggered by workflow_call", path)
+	}
+	return plan, nil
+}
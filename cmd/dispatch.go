@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/mattn/go-isatty"
+
+	"github.com/nektos/act/pkg/model"
+)
+
+func isInteractive() bool {
+	return isatty.IsTerminal(os.Stdin.Fd()) && isatty.IsTerminal(os.Stdout.Fd())
+}
+
+// planWorkflows returns every distinct workflow referenced by the runs of a plan
+func planWorkflows(plan *model.Plan) []*model.Workflow {
+	workflows := make([]*model.Workflow, 0)
+	seen := map[*model.Workflow]bool{}
+	for _, stage := range plan.Stages {
+		for _, run := range stage.Runs {
+			if !seen[run.Workflow] {
+				seen[run.Workflow] = true
+				workflows = append(workflows, run.Workflow)
+			}
+		}
+	}
+	return workflows
+}
+
+// resolveDispatchInputs asks for missing workflow_dispatch inputs when running interactively
+// and validates the inputs against the declaration of every workflow in the plan
+func resolveDispatchInputs(plan *model.Plan, inputs map[string]string, interactive bool) error {
+	for _, w := range planWorkflows(plan) {
+		config := w.WorkflowDispatchConfig()
+		if config == nil || len(config.Inputs) == 0 {
+			continue
+		}
+
+		if interactive {
+			if err := askDispatchInputs(config, inputs); err != nil {
+				return err
+			}
+		}
+
+		if err := config.ValidateInputs(inputs); err != nil {
+			return fmt.Errorf("invalid workflow_dispatch inputs for workflow '%s' (%s):\n%w", w.Name, w.File, err)
+		}
+	}
+	return nil
+}
+
+// validateEventDispatchInputs validates the inputs of the event file together with the values
+// of --input, the event file has to provide the required inputs
+func validateEventDispatchInputs(plan *model.Plan, eventPath string, inputs map[string]string) error {
+	content, err := os.ReadFile(eventPath)
+	if err != nil {
+		return err
+	}
+	var event struct {
+		Inputs map[string]interface{} `json:"inputs"`
+	}
+	if err := json.Unmarshal(content, &event); err != nil {
+		return fmt.Errorf("failed to read the inputs of the event file %s: %w", eventPath, err)
+	}
+	merged := map[string]string{}
+	for name, value := range event.Inputs {
+		if value != nil {
+			merged[name] = fmt.Sprint(value)
+		}
+	}
+	for name, value := range inputs {
+		merged[name] = value
+	}
+	return resolveDispatchInputs(plan, merged, false)
+}
+
+func askDispatchInputs(config *model.WorkflowDispatch, inputs map[string]string) error {
+	names := make([]string, 0, len(config.Inputs))
+	for name := range config.Inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		input := config.Inputs[name]
+		if _, ok := inputs[name]; ok || !input.Required || input.Default != "" {
+			continue
+		}
+
+		message := fmt.Sprintf("Input '%s':", name)
+		if input.Description != "" {
+			message = fmt.Sprintf("Input '%s' (%s):", name, input.Description)
+		}
+		var answer string
+		var err error
+		switch input.Type {
+		case "boolean":
+			var confirmed bool
+			err = survey.AskOne(&survey.Confirm{
+				Message: message,
+			}, &confirmed)
+			answer = strconv.FormatBool(confirmed)
+		case "choice":
+			err = survey.AskOne(&survey.Select{
+				Message: message,
+				Options: input.Options,
+			}, &answer)
+		default:
+			err = survey.AskOne(&survey.Input{
+				Message: message,
+			}, &answer, survey.WithValidator(func(ans interface{}) error {
+				if err := survey.Required(ans); err != nil {
+					return err
+				}
+				_, err := input.Convert(ans.(string))
+				return err
+			}))
+		}
+		if err != nil {
+			return err
+		}
+		inputs[name] = answer
+	}
+	return nil
+}
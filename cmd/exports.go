@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nektos/act/pkg/model"
+	"github.com/nektos/act/pkg/runner"
+)
+
+// newJobExports parses the exports of --export, relative host directories are relative to the working
+// directory. Exports of jobs, which are not part of the plan, are rejected.
+func (i *Input) newJobExports(plan *model.Plan) ([]*runner.JobExport, error) {
+	planned := map[string]bool{}
+	for _, stage := range plan.Stages {
+		for _, run := range stage.Runs {
+			planned[run.JobID] = true
+		}
+	}
+
+	exports := []*runner.JobExport{}
+	for _, e := range i.exports {
+		export, err := runner.ParseJobExport(e)
+		if err != nil {
+			return nil, err
+		}
+		if !planned[export.Job] {
+			return nil, fmt.Errorf("invalid export '%s', job '%s' is not part of the plan", e, export.Job)
+		}
+		export.Dest = i.resolve(export.Dest)
+		exports = append(exports, export)
+	}
+	return exports, nil
+}
@@ -4,6 +4,8 @@ import (
 	"path/filepath"
 
 	log "github.com/sirupsen/logrus"
+
+	"github.com/nektos/act/pkg/runner"
 )
 
 // Input contains the input for the root command
@@ -65,6 +67,29 @@ type Input struct {
 	validate                           bool
 	strict                             bool
 	concurrentJobs                     int
+	with                               []string
+	withfile                           string
+	runsOn                             string
+	junitReport                        string
+	mockActions                        []string
+	mockFile                           string
+	skipNeeds                          bool
+	needsOutputs                       string
+	jobFilter                          string
+	matrixFilter                       string
+	matrixIndex                        int
+	expandMatrix                       bool
+	memoize                            bool
+	memoizePath                        string
+	checkpoint                         bool
+	resumeJob                          string
+	resumeFrom                         string
+	containerPool                      bool
+	containerPoolCleanup               bool
+	prepareImages                      bool
+	workspaceMode                      string
+	exportWorkspace                    string
+	exports                            []string
 }
 
 func (i *Input) resolve(path string) string {
@@ -81,6 +106,17 @@ func (i *Input) resolve(path string) string {
 	return path
 }
 
+// WorkspaceMode returns the workspace mode of the job containers, --bind selects the bind mode
+func (i *Input) WorkspaceMode() string {
+	if i.bindWorkdir {
+		return runner.WorkspaceModeBind
+	}
+	if i.workspaceMode == "" {
+		return runner.WorkspaceModeCopy
+	}
+	return i.workspaceMode
+}
+
 // Envfile returns path to .env
 func (i *Input) Envfile() string {
 	return i.resolve(i.envfile)
@@ -114,3 +150,13 @@ func (i *Input) EventPath() string {
 func (i *Input) Inputfile() string {
 	return i.resolve(i.inputfile)
 }
+
+// NeedsOutputs returns the path to the file with the outputs of skipped needs
+func (i *Input) NeedsOutputs() string {
+	return i.resolve(i.needsOutputs)
+}
+
+// Withfile returns the path to the file with workflow_call inputs
+func (i *Input) Withfile() string {
+	return i.resolve(i.withfile)
+}
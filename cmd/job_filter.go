@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/nektos/act/pkg/exprparser"
+	"github.com/nektos/act/pkg/model"
+)
+
+// newJobFilter selects the jobs whose ID matches any of the glob patterns of --job
+// and for which the expression of --job-filter is truthy
+func newJobFilter(patterns []string, expression string) (model.JobFilter, error) {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid job pattern '%s': %w", pattern, err)
+		}
+	}
+
+	return func(w *model.Workflow, jobID string) (bool, error) {
+		if len(patterns) > 0 {
+			matched := false
+			for _, pattern := range patterns {
+				if ok, _ := path.Match(pattern, jobID); ok {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return false, nil
+			}
+		}
+		if expression == "" {
+			return true, nil
+		}
+
+		interpreter := exprparser.NewInterpeter(&exprparser.EvaluationEnvironment{
+			Github:   &model.GithubContext{},
+			Contexts: jobFilterContexts(w, jobID),
+		}, exprparser.Config{})
+		result, err := interpreter.Evaluate(expression, exprparser.DefaultStatusCheckNone)
+		if err != nil {
+			return false, fmt.Errorf("unable to evaluate job filter '%s' for job '%s': %w", expression, jobID, err)
+		}
+		return exprparser.IsTruthy(result), nil
+	}, nil
+}
+
+// jobFilterContexts returns the job metadata and workflow file name available to --job-filter
+func jobFilterContexts(w *model.Workflow, jobID string) map[string]interface{} {
+	job := w.GetJob(jobID)
+
+	var runsOn interface{} = expressionArray(job.RunsOn())
+	if labels := job.RunsOn(); len(labels) == 1 {
+		runsOn = labels[0]
+	}
+
+	return map[string]interface{}{
+		"job": map[string]interface{}{
+			"id":      jobID,
+			"name":    job.Name,
+			"runs-on": runsOn,
+			"needs":   expressionArray(job.Needs()),
+			"if":      job.If.Value,
+			"uses":    job.Uses,
+			"env":     job.Environment(),
+		},
+		"workflow": w.File,
+	}
+}
+
+// expressionArray converts a string slice to an array the expression functions accept
+func expressionArray(values []string) []interface{} {
+	array := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		array = append(array, v)
+	}
+	return array
+}
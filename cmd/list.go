@@ -1,14 +1,17 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/nektos/act/pkg/model"
+	"github.com/nektos/act/pkg/runner"
 )
 
-func printList(plan *model.Plan) error {
+func printList(ctx context.Context, config *runner.Config, plan *model.Plan, expandMatrix bool) error {
 	type lineInfoDef struct {
 		jobID   string
 		jobName string
@@ -16,6 +19,8 @@ func printList(plan *model.Plan) error {
 		wfName  string
 		wfFile  string
 		events  string
+		index   string
+		matrix  string
 	}
 	lineInfos := []lineInfoDef{}
 
@@ -26,6 +31,8 @@ func printList(plan *model.Plan) error {
 		wfName:  "Workflow name",
 		wfFile:  "Workflow file",
 		events:  "Events",
+		index:   "Index",
+		matrix:  "Matrix",
 	}
 
 	jobs := map[string]bool{}
@@ -37,6 +44,7 @@ func printList(plan *model.Plan) error {
 	wfNameMaxWidth := len(header.wfName)
 	wfFileMaxWidth := len(header.wfFile)
 	eventsMaxWidth := len(header.events)
+	indexMaxWidth := len(header.index)
 
 	for i, stage := range plan.Stages {
 		for _, r := range stage.Runs {
@@ -54,7 +62,25 @@ func printList(plan *model.Plan) error {
 			} else {
 				jobs[jobID] = true
 			}
-			lineInfos = append(lineInfos, line)
+			lines := []lineInfoDef{line}
+			if expandMatrix {
+				combinations, err := matrixCombinations(ctx, config, r)
+				if err != nil {
+					return err
+				}
+				lines = lines[:0]
+				for _, combination := range combinations {
+					line.index = combination.index
+					line.matrix = combination.values
+					lines = append(lines, line)
+				}
+			}
+			for _, line := range lines {
+				lineInfos = append(lineInfos, line)
+				if indexMaxWidth < len(line.index) {
+					indexMaxWidth = len(line.index)
+				}
+			}
 			if jobIDMaxWidth < len(line.jobID) {
 				jobIDMaxWidth = len(line.jobID)
 			}
@@ -81,17 +107,13 @@ func printList(plan *model.Plan) error {
 	stageMaxWidth += 2
 	wfNameMaxWidth += 2
 	wfFileMaxWidth += 2
+	indexMaxWidth += 2
 
-	fmt.Printf("%*s%*s%*s%*s%*s%*s\n",
-		-stageMaxWidth, header.stage,
-		-jobIDMaxWidth, header.jobID,
-		-jobNameMaxWidth, header.jobName,
-		-wfNameMaxWidth, header.wfName,
-		-wfFileMaxWidth, header.wfFile,
-		-eventsMaxWidth, header.events,
-	)
-	for _, line := range lineInfos {
-		fmt.Printf("%*s%*s%*s%*s%*s%*s\n",
+	if expandMatrix {
+		eventsMaxWidth += 2
+	}
+	printLine := func(line lineInfoDef) {
+		fmt.Printf("%*s%*s%*s%*s%*s%*s",
 			-stageMaxWidth, line.stage,
 			-jobIDMaxWidth, line.jobID,
 			-jobNameMaxWidth, line.jobName,
@@ -99,9 +121,67 @@ func printList(plan *model.Plan) error {
 			-wfFileMaxWidth, line.wfFile,
 			-eventsMaxWidth, line.events,
 		)
+		if expandMatrix && line.matrix == "" {
+			fmt.Print(line.index)
+		} else if expandMatrix {
+			fmt.Printf("%*s%s", -indexMaxWidth, line.index, line.matrix)
+		}
+		fmt.Println()
+	}
+	printLine(header)
+	for _, line := range lineInfos {
+		printLine(line)
 	}
 	if duplicateJobIDs {
 		fmt.Print("\nDetected multiple jobs with the same job name, use `-W` to specify the path to the specific workflow.\n")
 	}
 	return nil
 }
+
+type matrixCombination struct {
+	index  string
+	values string
+}
+
+// matrixCombinations returns the 1-based index and the values of the evaluated matrix
+// combinations of the job, which are selected by the matrix filter and index of the config
+func matrixCombinations(ctx context.Context, config *runner.Config, run *model.Run) ([]matrixCombination, error) {
+	if run.Job().Strategy == nil {
+		return []matrixCombination{{index: "-"}}, nil
+	}
+	matrixes, err := runner.EvaluateJobMatrixes(ctx, config, run)
+	if err != nil {
+		return nil, err
+	}
+
+	combinations := []matrixCombination{}
+	for i, matrix := range matrixes {
+		if config.MatrixIndex > 0 && config.MatrixIndex != i+1 {
+			continue
+		}
+		if config.MatrixFilter != "" {
+			matched, err := runner.MatchesMatrixFilter(config.MatrixFilter, matrix)
+			if err != nil {
+				return nil, err
+			}
+			if !matched {
+				continue
+			}
+		}
+
+		keys := make([]string, 0, len(matrix))
+		for k := range matrix {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		values := make([]string, 0, len(keys))
+		for _, k := range keys {
+			values = append(values, fmt.Sprintf("%s=%v", k, matrix[k]))
+		}
+		combinations = append(combinations, matrixCombination{
+			index:  strconv.Itoa(i + 1),
+			values: strings.Join(values, ", "),
+		})
+	}
+	return combinations, nil
+}
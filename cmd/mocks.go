@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nektos/act/pkg/runner"
+)
+
+// newActionMocks reads the mocks of --mock-action followed by the mocks of --mock-file
+func (i *Input) newActionMocks() ([]*runner.ActionMock, error) {
+	mocks := []*runner.ActionMock{}
+	for _, m := range i.mockActions {
+		uses, file, ok := strings.Cut(m, "=")
+		if !ok || uses == "" || file == "" {
+			return nil, fmt.Errorf("invalid --mock-action '%s', expected <glob>=<file>", m)
+		}
+		mock, err := runner.ReadActionMock(uses, i.resolve(file))
+		if err != nil {
+			return nil, err
+		}
+		mocks = append(mocks, mock)
+	}
+
+	if i.mockFile != "" {
+		fileMocks, err := runner.ReadActionMocks(i.resolve(i.mockFile))
+		if err != nil {
+			return nil, err
+		}
+		mocks = append(mocks, fileMocks...)
+	}
+	return mocks, nil
+}
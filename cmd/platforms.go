@@ -13,9 +13,9 @@ func (i *Input) newPlatforms() map[string]string {
 	}
 
 	for _, p := range i.platforms {
-		pParts := strings.Split(p, "=")
-		if len(pParts) == 2 {
-			platforms[strings.ToLower(pParts[0])] = pParts[1]
+		// the image may contain '=', e.g. the query of an ssh:// URL
+		if platform, image, ok := strings.Cut(p, "="); ok {
+			platforms[strings.ToLower(platform)] = image
 		}
 	}
 	return platforms
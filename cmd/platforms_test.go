@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPlatforms(t *testing.T) {
+	input := &Input{platforms: []string{
+		"Ubuntu-Latest=node:20",
+		"arm-runner=ssh://builder@10.0.0.5/srv/act?identity=/keys/id_ed25519",
+		"invalid",
+	}}
+	platforms := input.newPlatforms()
+	assert.Equal(t, "node:20", platforms["ubuntu-latest"])
+	assert.Equal(t, "ssh://builder@10.0.0.5/srv/act?identity=/keys/id_ed25519", platforms["arm-runner"])
+	assert.Equal(t, "node:16-bullseye-slim", platforms["ubuntu-22.04"])
+	assert.NotContains(t, platforms, "invalid")
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/nektos/act/pkg/model"
+)
+
+func newResumeCommand(ctx context.Context, input *Input) *cobra.Command {
+	resumeCmd := &cobra.Command{
+		Use:   "resume [event] --job <job> --from <step> [flags]",
+		Short: "Continue a job from the checkpoint of the step before --from, the job has to be run with --checkpoint first",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  newResumeRunCommand(ctx, input),
+	}
+
+	resumeCmd.Flags().StringVarP(&input.resumeJob, "job", "j", "", "ID of the job to resume")
+	resumeCmd.Flags().StringVar(&input.resumeFrom, "from", "", "ID, name or 1-based number of the first step to run again")
+	resumeCmd.Flags().StringArrayVarP(&input.matrix, "matrix", "", []string{}, "specify which matrix configuration to include (e.g. --matrix java:13")
+	_ = resumeCmd.MarkFlagRequired("job")
+	_ = resumeCmd.MarkFlagRequired("from")
+	return resumeCmd
+}
+
+func newResumeRunCommand(ctx context.Context, input *Input) func(*cobra.Command, []string) error {
+	return func(_ *cobra.Command, args []string) error {
+		if input.jsonLogger {
+			log.SetFormatter(&log.JSONFormatter{})
+		}
+
+		if err := validateWorkspaceMode(input); err != nil {
+			return err
+		}
+
+		planner, err := model.NewWorkflowPlanner(input.WorkflowsPath(), input.noWorkflowRecurse, input.strict)
+		if err != nil {
+			return err
+		}
+		jobFilter, err := newJobFilter([]string{input.resumeJob}, "")
+		if err != nil {
+			return err
+		}
+		// the needs of the job are restored from the checkpoint
+		plan, err := planner.PlanJobs(jobFilter, true)
+		if plan == nil && err != nil {
+			return err
+		}
+		if len(plan.Stages) == 0 {
+			return fmt.Errorf("unable to resume, job '%s' not found", input.resumeJob)
+		}
+
+		actionMocks, err := input.newActionMocks()
+		if err != nil {
+			return err
+		}
+		exports, err := input.newJobExports(plan)
+		if err != nil {
+			return err
+		}
+
+		eventName := "push"
+		if len(args) > 0 {
+			eventName = args[0]
+		}
+
+		setupContainerEngine(input)
+
+		envs, secrets, vars := loadRunEnvironment(ctx, input)
+
+		log.Debugf("Loading action inputs from %s", input.Inputfile())
+		inputs := parseEnvs(input.inputs)
+		_ = readEnvs(input.Inputfile(), inputs)
+
+		config := newRunnerConfig(input, eventName, envs, inputs, secrets, vars, parseMatrix(input.matrix), actionMocks)
+		config.Exports = exports
+		config.ResumeFrom = input.resumeFrom
+		return executePlan(ctx, input, config, plan, false)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"regexp"
 	"runtime"
 	"runtime/debug"
+	"slices"
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
@@ -29,6 +30,7 @@ import (
 	"github.com/nektos/act/pkg/artifacts"
 	"github.com/nektos/act/pkg/common"
 	"github.com/nektos/act/pkg/container"
+	"github.com/nektos/act/pkg/exprparser"
 	"github.com/nektos/act/pkg/gh"
 	"github.com/nektos/act/pkg/model"
 	"github.com/nektos/act/pkg/runner"
@@ -67,35 +69,41 @@ func createRootCommand(ctx context.Context, input *Input, version string) *cobra
 
 	rootCmd.Flags().BoolP("watch", "w", false, "watch the contents of the local repo and run when files change")
 	rootCmd.Flags().BoolVar(&input.validate, "validate", false, "validate workflows")
-	rootCmd.Flags().BoolVar(&input.strict, "strict", false, "use strict workflow schema")
+	rootCmd.PersistentFlags().BoolVar(&input.strict, "strict", false, "use strict workflow schema")
 	rootCmd.Flags().BoolP("list", "l", false, "list workflows")
 	rootCmd.Flags().BoolP("graph", "g", false, "draw workflows")
-	rootCmd.Flags().StringP("job", "j", "", "run a specific job ID")
+	rootCmd.Flags().StringArrayP("job", "j", []string{}, "run a specific job ID or the jobs matching a glob pattern, can be repeated (e.g. -j build -j 'test-*')")
+	rootCmd.Flags().StringVar(&input.jobFilter, "job-filter", "", "run the jobs for which the expression over the job and workflow contexts is true (e.g. \"contains(job.runs-on, 'ubuntu')\" or \"workflow == 'ci.yml'\")")
+	rootCmd.Flags().BoolVar(&input.skipNeeds, "skip-needs", false, "run the jobs of --job and --job-filter without the jobs they need, they are considered successful unless set in --needs-outputs")
+	rootCmd.Flags().StringVar(&input.needsOutputs, "needs-outputs", "", "JSON file with the result and outputs of the jobs skipped by --skip-needs (e.g. {\"build\": {\"result\": \"success\", \"outputs\": {\"version\": \"1.0.0\"}}})")
 	rootCmd.Flags().BoolP("bug-report", "", false, "Display system information for bug report")
 	rootCmd.Flags().BoolP("man-page", "", false, "Print a generated manual page to stdout")
 
-	rootCmd.Flags().StringVar(&input.remoteName, "remote-name", "origin", "git remote name that will be used to retrieve url of git repo")
-	rootCmd.Flags().StringArrayVarP(&input.secrets, "secret", "s", []string{}, "secret to make available to actions with optional value (e.g. -s mysecret=foo or -s mysecret)")
-	rootCmd.Flags().StringArrayVar(&input.vars, "var", []string{}, "variable to make available to actions with optional value (e.g. --var myvar=foo or --var myvar)")
-	rootCmd.Flags().StringArrayVarP(&input.envs, "env", "", []string{}, "env to make available to actions with optional value (e.g. --env myenv=foo or --env myenv)")
-	rootCmd.Flags().StringArrayVarP(&input.inputs, "input", "", []string{}, "action input to make available to actions (e.g. --input myinput=foo)")
-	rootCmd.Flags().StringArrayVarP(&input.platforms, "platform", "P", []string{}, "custom image to use per platform (e.g. -P ubuntu-18.04=nektos/act-environments-ubuntu:18.04)")
-	rootCmd.Flags().BoolVarP(&input.reuseContainers, "reuse", "r", false, "don't remove container(s) on successfully completed workflow(s) to maintain state between runs")
-	rootCmd.Flags().BoolVarP(&input.bindWorkdir, "bind", "b", false, "bind working directory to container, rather than copy")
-	rootCmd.Flags().BoolVarP(&input.forcePull, "pull", "p", true, "pull docker image(s) even if already present")
-	rootCmd.Flags().BoolVarP(&input.forceRebuild, "rebuild", "", true, "rebuild local action docker image(s) even if already present")
+	rootCmd.PersistentFlags().StringVar(&input.remoteName, "remote-name", "origin", "git remote name that will be used to retrieve url of git repo")
+	rootCmd.PersistentFlags().StringArrayVarP(&input.secrets, "secret", "s", []string{}, "secret to make available to actions with optional value (e.g. -s mysecret=foo or -s mysecret)")
+	rootCmd.PersistentFlags().StringArrayVar(&input.vars, "var", []string{}, "variable to make available to actions with optional value (e.g. --var myvar=foo or --var myvar)")
+	rootCmd.PersistentFlags().StringArrayVarP(&input.envs, "env", "", []string{}, "env to make available to actions with optional value (e.g. --env myenv=foo or --env myenv)")
+	rootCmd.PersistentFlags().StringArrayVarP(&input.inputs, "input", "", []string{}, "action input to make available to actions (e.g. --input myinput=foo)")
+	rootCmd.PersistentFlags().StringArrayVarP(&input.platforms, "platform", "P", []string{}, "custom image to use per platform (e.g. -P ubuntu-18.04=nektos/act-environments-ubuntu:18.04), -self-hosted runs the jobs on the host, ssh://[user@]host[:port][/dir] runs them on a remote machine and backend:<name>:<image> selects another backend")
+	rootCmd.PersistentFlags().BoolVarP(&input.reuseContainers, "reuse", "r", false, "don't remove container(s) on successfully completed workflow(s) to maintain state between runs")
+	rootCmd.PersistentFlags().BoolVarP(&input.bindWorkdir, "bind", "b", false, "bind working directory to container, rather than copy")
+	rootCmd.PersistentFlags().BoolVarP(&input.forcePull, "pull", "p", true, "pull docker image(s) even if already present")
+	rootCmd.PersistentFlags().BoolVarP(&input.forceRebuild, "rebuild", "", false, "rebuild action docker image(s) even if an image of the same build context is already present")
 	rootCmd.Flags().BoolVarP(&input.autodetectEvent, "detect-event", "", false, "Use first event type from workflow as event that triggered the workflow")
 	rootCmd.Flags().StringVarP(&input.eventPath, "eventpath", "e", "", "path to event JSON file")
-	rootCmd.Flags().StringVar(&input.defaultBranch, "defaultbranch", "", "the name of the main branch")
-	rootCmd.Flags().BoolVar(&input.privileged, "privileged", false, "use privileged mode")
-	rootCmd.Flags().StringVar(&input.usernsMode, "userns", "", "user namespace to use")
-	rootCmd.Flags().BoolVar(&input.useGitIgnore, "use-gitignore", true, "Controls whether paths specified in .gitignore should be copied into container")
-	rootCmd.Flags().StringArrayVarP(&input.containerCapAdd, "container-cap-add", "", []string{}, "kernel capabilities to add to the workflow containers (e.g. --container-cap-add SYS_PTRACE)")
-	rootCmd.Flags().StringArrayVarP(&input.containerCapDrop, "container-cap-drop", "", []string{}, "kernel capabilities to remove from the workflow containers (e.g. --container-cap-drop SYS_PTRACE)")
-	rootCmd.Flags().BoolVar(&input.autoRemove, "rm", false, "automatically remove container(s)/volume(s) after a workflow(s) failure")
-	rootCmd.Flags().StringArrayVarP(&input.replaceGheActionWithGithubCom, "replace-ghe-action-with-github-com", "", []string{}, "If you are using GitHub Enterprise Server and allow specified actions from GitHub (github.com), you can set actions on this. (e.g. --replace-ghe-action-with-github-com =github/super-linter)")
-	rootCmd.Flags().StringVar(&input.replaceGheActionTokenWithGithubCom, "replace-ghe-action-token-with-github-com", "", "If you are using replace-ghe-action-with-github-com  and you want to use private actions on GitHub, you have to set personal access token")
+	rootCmd.PersistentFlags().StringVar(&input.defaultBranch, "defaultbranch", "", "the name of the main branch")
+	rootCmd.PersistentFlags().BoolVar(&input.privileged, "privileged", false, "use privileged mode")
+	rootCmd.PersistentFlags().StringVar(&input.usernsMode, "userns", "", "user namespace to use")
+	rootCmd.PersistentFlags().BoolVar(&input.useGitIgnore, "use-gitignore", true, "Controls whether paths specified in .gitignore should be copied into container")
+	rootCmd.PersistentFlags().StringArrayVarP(&input.containerCapAdd, "container-cap-add", "", []string{}, "kernel capabilities to add to the workflow containers (e.g. --container-cap-add SYS_PTRACE)")
+	rootCmd.PersistentFlags().StringArrayVarP(&input.containerCapDrop, "container-cap-drop", "", []string{}, "kernel capabilities to remove from the workflow containers (e.g. --container-cap-drop SYS_PTRACE)")
+	rootCmd.PersistentFlags().BoolVar(&input.autoRemove, "rm", false, "automatically remove container(s)/volume(s) after a workflow(s) failure")
+	rootCmd.PersistentFlags().StringArrayVarP(&input.replaceGheActionWithGithubCom, "replace-ghe-action-with-github-com", "", []string{}, "If you are using GitHub Enterprise Server and allow specified actions from GitHub (github.com), you can set actions on this. (e.g. --replace-ghe-action-with-github-com =github/super-linter)")
+	rootCmd.PersistentFlags().StringVar(&input.replaceGheActionTokenWithGithubCom, "replace-ghe-action-token-with-github-com", "", "If you are using replace-ghe-action-with-github-com  and you want to use private actions on GitHub, you have to set personal access token")
 	rootCmd.Flags().StringArrayVarP(&input.matrix, "matrix", "", []string{}, "specify which matrix configuration to include (e.g. --matrix java:13")
+	rootCmd.Flags().StringVar(&input.matrixFilter, "matrix-filter", "", "run the matrix combinations for which the expression over the matrix context is true (e.g. \"matrix.node >= 18 && matrix.os != 'windows-latest'\")")
+	rootCmd.Flags().IntVar(&input.matrixIndex, "matrix-index", 0, "run only the matrix combination with this 1-based index, as shown by --list --expand-matrix")
+	rootCmd.Flags().BoolVar(&input.expandMatrix, "expand-matrix", false, "list each matrix combination of the jobs with its index")
 	rootCmd.PersistentFlags().StringVarP(&input.actor, "actor", "a", "nektos/act", "user that triggered the event")
 	rootCmd.PersistentFlags().StringVarP(&input.workflowsPath, "workflows", "W", "./.github/workflows/", "path to workflow file(s)")
 	rootCmd.PersistentFlags().BoolVarP(&input.noWorkflowRecurse, "no-recurse", "", false, "Flag to disable running workflows from subdirectories of specified path in '--workflows'/'-W' flag")
@@ -123,6 +131,15 @@ func createRootCommand(ctx context.Context, input *Input, version string) *cobra
 	rootCmd.PersistentFlags().StringVarP(&input.cacheServerExternalURL, "cache-server-external-url", "", "", "Defines the external URL for if the cache server is behind a proxy. e.g.: https://act-cache-server.example.com. Be careful that there is no trailing slash.")
 	rootCmd.PersistentFlags().StringVarP(&input.cacheServerAddr, "cache-server-addr", "", common.GetOutboundIP().String(), "Defines the address to which the cache server binds.")
 	rootCmd.PersistentFlags().Uint16VarP(&input.cacheServerPort, "cache-server-port", "", 0, "Defines the port where the artifact server listens. 0 means a randomly available port.")
+	rootCmd.PersistentFlags().BoolVar(&input.memoize, "memoize", false, "skip run steps whose script, env and x-act-inputs files did not change since their last successful run and replay their outputs, env and path instead, files the step created in the job container are not restored")
+	rootCmd.PersistentFlags().StringVar(&input.memoizePath, "memoize-path", filepath.Join(CacheHomeDir, "actmemo"), "Defines the path where --memoize stores the results of run steps.")
+	rootCmd.PersistentFlags().BoolVar(&input.prepareImages, "prepare-images", false, "pull and build the images of the job containers, services and docker actions of all jobs in parallel before the first job starts, images only known while a job runs are still prepared by the job")
+	rootCmd.PersistentFlags().BoolVar(&input.containerPool, "container-pool", false, "keep job containers running after their job and reuse them for the next jobs and runs with the same image and options, the workspace and /var/run/act are reset before each job, jobs with services always get a container of their own, remove the idle containers with --container-pool-cleanup")
+	rootCmd.Flags().BoolVar(&input.containerPoolCleanup, "container-pool-cleanup", false, "remove the job containers of --container-pool and their volumes, which are not used by a running act process, and exit")
+	rootCmd.PersistentFlags().StringVar(&input.workspaceMode, "workspace-mode", runner.WorkspaceModeCopy, "how the working directory is provided to job containers: copy (local actions/checkout copies it into a volume), bind (same as --bind) or overlay (the working directory is the read-only lower layer of a copy-on-write overlay, uses fuse-overlayfs with a rootless docker daemon)")
+	rootCmd.PersistentFlags().StringVar(&input.exportWorkspace, "export-workspace", "", "directory the workspace of each job is extracted to after the job, one subdirectory per job, with --workspace-mode overlay the upper layer with the changes of the job is moved there")
+	rootCmd.PersistentFlags().StringArrayVar(&input.exports, "export", []string{}, "copy a path of a job container to a host directory after the job, relative paths are relative to the workspace (e.g. --export build:dist=./out), can be repeated")
+	rootCmd.PersistentFlags().BoolVar(&input.checkpoint, "checkpoint", false, "commit the job container and save the workspace, env, path and outputs after each successful step, continue a job from a checkpoint with act resume")
 	rootCmd.PersistentFlags().StringVarP(&input.actionCachePath, "action-cache-path", "", filepath.Join(CacheHomeDir, "act"), "Defines the path where the actions get cached and host workspaces created.")
 	rootCmd.PersistentFlags().BoolVarP(&input.actionOfflineMode, "action-offline-mode", "", false, "If action contents exists, it will not be fetch and pull again. If turn on this, will turn off force pull")
 	rootCmd.PersistentFlags().StringVarP(&input.networkName, "network", "", "host", "Sets a docker network name. Defaults to host.")
@@ -130,6 +147,12 @@ func createRootCommand(ctx context.Context, input *Input, version string) *cobra
 	rootCmd.PersistentFlags().StringArrayVarP(&input.localRepository, "local-repository", "", []string{}, "Replaces the specified repository and ref with a local folder (e.g. https://github.com/test/test@v0=/home/act/test or test/test@v0=/home/act/test, the latter matches any hosts or protocols)")
 	rootCmd.PersistentFlags().BoolVar(&input.listOptions, "list-options", false, "Print a json structure of compatible options")
 	rootCmd.PersistentFlags().IntVar(&input.concurrentJobs, "concurrent-jobs", 0, "Maximum number of concurrent jobs to run. Default is the number of CPUs available.")
+	rootCmd.PersistentFlags().StringArrayVar(&input.mockActions, "mock-action", []string{}, "replace the actions matching a glob with the outputs, env and exit-code of a JSON or YAML file, without fetching or running them (e.g. --mock-action 'aws-actions/configure-aws-credentials@*=outputs.json')")
+	rootCmd.PersistentFlags().StringVar(&input.mockFile, "mock-file", "", "JSON or YAML file with a list of action mocks, each with uses, outputs, env and exit-code")
+	rootCmd.AddCommand(newCallCommand(ctx, input))
+	rootCmd.AddCommand(newActionCommand(ctx, input))
+	rootCmd.AddCommand(newTestCommand(ctx, input))
+	rootCmd.AddCommand(newResumeCommand(ctx, input))
 	rootCmd.SetArgs(args())
 	return rootCmd
 }
@@ -370,6 +393,29 @@ func readEnvsEx(path string, envs map[string]string, caseInsensitive bool) bool
 	return false
 }
 
+// readNeedsOutputs reads the results and outputs of skipped needs by job ID
+func readNeedsOutputs(path string) (map[string]exprparser.Needs, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	needs := map[string]exprparser.Needs{}
+	if err := json.Unmarshal(content, &needs); err != nil {
+		return nil, fmt.Errorf("unable to read needs outputs from %s: %w", path, err)
+	}
+	fields := map[string]map[string]json.RawMessage{}
+	if err := json.Unmarshal(content, &fields); err == nil {
+		for jobID, job := range fields {
+			for key := range job {
+				if key != "result" && key != "outputs" {
+					log.Warnf("Unknown key '%s' of job '%s' in %s, expected result or outputs", key, jobID, path)
+				}
+			}
+		}
+	}
+	return needs, nil
+}
+
 func parseMatrix(matrix []string) map[string]map[string]bool {
 	// each matrix entry should be of the form - string:string
 	r := regexp.MustCompile(":")
@@ -406,57 +452,57 @@ func newRunCommand(ctx context.Context, input *Input) func(*cobra.Command, []str
 			return listOptions(cmd)
 		}
 
-		if ret, err := container.GetSocketAndHost(input.containerDaemonSocket); err != nil {
-			log.Warnf("Couldn't get a valid docker connection: %+v", err)
-		} else {
-			os.Setenv("DOCKER_HOST", ret.Host)
-			input.containerDaemonSocket = ret.Socket
-			log.Infof("Using docker host '%s', and daemon socket '%s'", ret.Host, ret.Socket)
+		setupContainerEngine(input)
+		if input.containerPoolCleanup {
+			return runner.RemoveContainerPool(ctx, input.actionCachePath)
 		}
 
-		if runtime.GOOS == "darwin" && runtime.GOARCH == "arm64" && input.containerArchitecture == "" {
-			l := log.New()
-			l.SetFormatter(&log.TextFormatter{
-				DisableQuote:     true,
-				DisableTimestamp: true,
-			})
-			l.Warnf(" \U000026A0 You are using Apple M-series chip and you have not specified container architecture, you might encounter issues while running act. If so, try running it with '--container-architecture linux/amd64'. \U000026A0 \n")
-		}
-
-		log.Debugf("Loading environment from %s", input.Envfile())
-		envs := parseEnvs(input.envs)
-		_ = readEnvs(input.Envfile(), envs)
+		envs, secrets, vars := loadRunEnvironment(ctx, input)
 
 		log.Debugf("Loading action inputs from %s", input.Inputfile())
 		inputs := parseEnvs(input.inputs)
 		_ = readEnvs(input.Inputfile(), inputs)
 
-		log.Debugf("Loading secrets from %s", input.Secretfile())
-		secrets := newSecrets(input.secrets)
-		_ = readEnvsEx(input.Secretfile(), secrets, true)
-
-		if _, hasGitHubToken := secrets["GITHUB_TOKEN"]; !hasGitHubToken {
-			ctx, cancel := common.EarlyCancelContext(ctx)
-			defer cancel()
-			secrets["GITHUB_TOKEN"], _ = gh.GetToken(ctx, "")
-		}
-
-		log.Debugf("Loading vars from %s", input.Varfile())
-		vars := newSecrets(input.vars)
-		_ = readEnvs(input.Varfile(), vars)
-
 		matrixes := parseMatrix(input.matrix)
 		log.Debugf("Evaluated matrix inclusions: %v", matrixes)
 
+		actionMocks, err := input.newActionMocks()
+		if err != nil {
+			return err
+		}
+
 		planner, err := model.NewWorkflowPlanner(input.WorkflowsPath(), input.noWorkflowRecurse, input.strict)
 		if err != nil {
 			return err
 		}
 
-		jobID, err := cmd.Flags().GetString("job")
+		jobPatterns, err := cmd.Flags().GetStringArray("job")
 		if err != nil {
 			return err
 		}
+		selectJobs := len(jobPatterns) > 0 || input.jobFilter != ""
+		if input.skipNeeds && !selectJobs {
+			return fmt.Errorf("--skip-needs requires --job or --job-filter")
+		}
+		if input.needsOutputs != "" && !input.skipNeeds {
+			return fmt.Errorf("--needs-outputs requires --skip-needs")
+		}
+		jobFilter, err := newJobFilter(jobPatterns, input.jobFilter)
+		if err != nil {
+			return err
+		}
+		if input.matrixIndex < 0 {
+			return fmt.Errorf("--matrix-index must be a positive number")
+		}
+		if err := validateWorkspaceMode(input); err != nil {
+			return err
+		}
+		if input.matrixFilter != "" {
+			// evaluate the filter once upfront to report syntax errors before any job runs
+			if _, err := runner.MatchesMatrixFilter(input.matrixFilter, map[string]interface{}{}); err != nil {
+				return err
+			}
+		}
 
 		// check if we should just list the workflows
 		list, err := cmd.Flags().GetBool("list")
@@ -495,9 +541,9 @@ func newRunCommand(ctx context.Context, input *Input) func(*cobra.Command, []str
 		}
 
 		var plannerErr error
-		if jobID != "" {
-			log.Debugf("Preparing plan with a job: %s", jobID)
-			filterPlan, plannerErr = planner.PlanJob(jobID)
+		if selectJobs {
+			log.Debugf("Preparing plan with jobs: %v %s", jobPatterns, input.jobFilter)
+			filterPlan, plannerErr = planner.PlanJobs(jobFilter, input.skipNeeds)
 		} else if filterEventName != "" {
 			log.Debugf("Preparing plan for a event: %s", filterEventName)
 			filterPlan, plannerErr = planner.PlanEvent(filterEventName)
@@ -510,7 +556,24 @@ func newRunCommand(ctx context.Context, input *Input) func(*cobra.Command, []str
 		}
 
 		if list {
-			err = printList(filterPlan)
+			// the matrixes are evaluated like the ones of the run, so that the indices match --matrix-index
+			listEventName := filterEventName
+			if listEventName == "" {
+				listEventName = "push"
+			}
+			listConfig := &runner.Config{
+				Workdir:      input.Workdir(),
+				EventName:    listEventName,
+				EventPath:    input.EventPath(),
+				Env:          envs,
+				Secrets:      secrets,
+				Vars:         vars,
+				Inputs:       inputs,
+				MatrixFilter: input.matrixFilter,
+				MatrixIndex:  input.matrixIndex,
+				RemoteName:   input.remoteName,
+			}
+			err = printList(ctx, listConfig, filterPlan, input.expandMatrix)
 			if err != nil {
 				return err
 			}
@@ -548,9 +611,9 @@ func newRunCommand(ctx context.Context, input *Input) func(*cobra.Command, []str
 		}
 
 		// build the plan for this run
-		if jobID != "" {
-			log.Debugf("Planning job: %s", jobID)
-			plan, plannerErr = planner.PlanJob(jobID)
+		if selectJobs {
+			log.Debugf("Planning jobs: %v %s", jobPatterns, input.jobFilter)
+			plan, plannerErr = planner.PlanJobs(jobFilter, input.skipNeeds)
 		} else {
 			log.Debugf("Planning jobs for event: %s", eventName)
 			plan, plannerErr = planner.PlanEvent(eventName)
@@ -564,153 +627,251 @@ func newRunCommand(ctx context.Context, input *Input) func(*cobra.Command, []str
 			return plannerErr
 		}
 
-		// check to see if the main branch was defined
-		defaultbranch, err := cmd.Flags().GetString("defaultbranch")
-		if err != nil {
-			return err
-		}
-
-		// Check if platforms flag is set, if not, run default image survey
-		if len(input.platforms) == 0 {
-			cfgFound := false
-			cfgLocations := configLocations()
-			for _, v := range cfgLocations {
-				_, err := os.Stat(v)
-				if os.IsExist(err) {
-					cfgFound = true
-				}
-			}
-			if !cfgFound && len(cfgLocations) > 0 {
-				// The first config location refers to the global config folder one
-				if err := defaultImageSurvey(cfgLocations[0]); err != nil {
-					log.Fatal(err)
-				}
-				input.platforms = readArgsFile(cfgLocations[0], true)
-			}
-		}
-		deprecationWarning := "--%s is deprecated and will be removed soon, please switch to cli: `--container-options \"%[2]s\"` or `.actrc`: `--container-options %[2]s`."
-		if input.privileged {
-			log.Warnf(deprecationWarning, "privileged", "--privileged")
-		}
-		if len(input.usernsMode) > 0 {
-			log.Warnf(deprecationWarning, "userns", fmt.Sprintf("--userns=%s", input.usernsMode))
-		}
-		if len(input.containerCapAdd) > 0 {
-			log.Warnf(deprecationWarning, "container-cap-add", fmt.Sprintf("--cap-add=%s", input.containerCapAdd))
-		}
-		if len(input.containerCapDrop) > 0 {
-			log.Warnf(deprecationWarning, "container-cap-drop", fmt.Sprintf("--cap-drop=%s", input.containerCapDrop))
-		}
-
-		// run the plan
-		config := &runner.Config{
-			Actor:                              input.actor,
-			EventName:                          eventName,
-			EventPath:                          input.EventPath(),
-			DefaultBranch:                      defaultbranch,
-			ForcePull:                          !input.actionOfflineMode && input.forcePull,
-			ForceRebuild:                       input.forceRebuild,
-			ReuseContainers:                    input.reuseContainers,
-			Workdir:                            input.Workdir(),
-			ActionCacheDir:                     input.actionCachePath,
-			ActionOfflineMode:                  input.actionOfflineMode,
-			BindWorkdir:                        input.bindWorkdir,
-			LogOutput:                          !input.noOutput,
-			JSONLogger:                         input.jsonLogger,
-			LogPrefixJobID:                     input.logPrefixJobID,
-			Env:                                envs,
-			Secrets:                            secrets,
-			Vars:                               vars,
-			Inputs:                             inputs,
-			Token:                              secrets["GITHUB_TOKEN"],
-			InsecureSecrets:                    input.insecureSecrets,
-			Platforms:                          input.newPlatforms(),
-			Privileged:                         input.privileged,
-			UsernsMode:                         input.usernsMode,
-			ContainerArchitecture:              input.containerArchitecture,
-			ContainerDaemonSocket:              input.containerDaemonSocket,
-			ContainerOptions:                   input.containerOptions,
-			UseGitIgnore:                       input.useGitIgnore,
-			GitHubInstance:                     input.githubInstance,
-			ContainerCapAdd:                    input.containerCapAdd,
-			ContainerCapDrop:                   input.containerCapDrop,
-			AutoRemove:                         input.autoRemove,
-			ArtifactServerPath:                 input.artifactServerPath,
-			ArtifactServerAddr:                 input.artifactServerAddr,
-			ArtifactServerPort:                 input.artifactServerPort,
-			NoSkipCheckout:                     input.noSkipCheckout,
-			RemoteName:                         input.remoteName,
-			ReplaceGheActionWithGithubCom:      input.replaceGheActionWithGithubCom,
-			ReplaceGheActionTokenWithGithubCom: input.replaceGheActionTokenWithGithubCom,
-			Matrix:                             matrixes,
-			ContainerNetworkMode:               docker_container.NetworkMode(input.networkName),
-			ConcurrentJobs:                     input.concurrentJobs,
-		}
-		if input.useNewActionCache || len(input.localRepository) > 0 {
-			if input.actionOfflineMode {
-				config.ActionCache = &runner.GoGitActionCacheOfflineMode{
-					Parent: runner.GoGitActionCache{
-						Path: config.ActionCacheDir,
-					},
-				}
+		if eventName == "workflow_dispatch" {
+			if input.eventPath == "" {
+				err = resolveDispatchInputs(plan, inputs, isInteractive())
 			} else {
-				config.ActionCache = &runner.GoGitActionCache{
-					Path: config.ActionCacheDir,
-				}
+				// the inputs of the event file and --input are validated without asking for missing ones
+				err = validateEventDispatchInputs(plan, input.EventPath(), inputs)
 			}
-			if len(input.localRepository) > 0 {
-				localRepositories := map[string]string{}
-				for _, l := range input.localRepository {
-					k, v, _ := strings.Cut(l, "=")
-					localRepositories[k] = v
-				}
-				config.ActionCache = &runner.LocalRepositoryCache{
-					Parent:            config.ActionCache,
-					LocalRepositories: localRepositories,
-					CacheDirCache:     map[string]string{},
-				}
+			if err != nil {
+				return err
 			}
 		}
-		r, err := runner.New(config)
+
+		exports, err := input.newJobExports(plan)
 		if err != nil {
 			return err
 		}
 
-		cancel := artifacts.Serve(ctx, input.artifactServerPath, input.artifactServerAddr, input.artifactServerPort)
-
-		const cacheURLKey = "ACTIONS_CACHE_URL"
-		var cacheHandler *artifactcache.Handler
-		if !input.noCacheServer && envs[cacheURLKey] == "" {
-			var err error
-			cacheHandler, err = artifactcache.StartHandler(input.cacheServerPath, input.cacheServerExternalURL, input.cacheServerAddr, input.cacheServerPort, common.Logger(ctx))
+		config := newRunnerConfig(input, eventName, envs, inputs, secrets, vars, matrixes, actionMocks)
+		config.Exports = exports
+		if input.needsOutputs != "" {
+			config.SkippedNeeds, err = readNeedsOutputs(input.NeedsOutputs())
 			if err != nil {
 				return err
 			}
-			envs[cacheURLKey] = cacheHandler.ExternalURL() + "/"
 		}
 
-		ctx = common.WithDryrun(ctx, input.dryrun)
-		if watch, err := cmd.Flags().GetBool("watch"); err != nil {
+		watch, err := cmd.Flags().GetBool("watch")
+		if err != nil {
+			return err
+		}
+		if err := executePlan(ctx, input, config, plan, watch); err != nil {
 			return err
-		} else if watch {
-			err = watchAndRun(ctx, r.NewPlanExecutor(plan))
-			if err != nil {
-				return err
-			}
-			return plannerErr
 		}
+		return plannerErr
+	}
+}
 
-		executor := r.NewPlanExecutor(plan).Finally(func(_ context.Context) error {
-			cancel()
-			_ = cacheHandler.Close()
-			return nil
+// setupContainerEngine resolves the docker host and daemon socket used by the runner
+func setupContainerEngine(input *Input) {
+	if ret, err := container.GetSocketAndHost(input.containerDaemonSocket); err != nil {
+		log.Warnf("Couldn't get a valid docker connection: %+v", err)
+	} else {
+		os.Setenv("DOCKER_HOST", ret.Host)
+		input.containerDaemonSocket = ret.Socket
+		log.Infof("Using docker host '%s', and daemon socket '%s'", ret.Host, ret.Socket)
+	}
+
+	if runtime.GOOS == "darwin" && runtime.GOARCH == "arm64" && input.containerArchitecture == "" {
+		l := log.New()
+		l.SetFormatter(&log.TextFormatter{
+			DisableQuote:     true,
+			DisableTimestamp: true,
 		})
-		err = executor(ctx)
+		l.Warnf(" \U000026A0 You are using Apple M-series chip and you have not specified container architecture, you might encounter issues while running act. If so, try running it with '--container-architecture linux/amd64'. \U000026A0 \n")
+	}
+}
+
+// loadRunEnvironment reads the envs, secrets and vars from the flags and their files
+func loadRunEnvironment(ctx context.Context, input *Input) (map[string]string, map[string]string, map[string]string) {
+	log.Debugf("Loading environment from %s", input.Envfile())
+	envs := parseEnvs(input.envs)
+	_ = readEnvs(input.Envfile(), envs)
+
+	log.Debugf("Loading secrets from %s", input.Secretfile())
+	secrets := newSecrets(input.secrets)
+	_ = readEnvsEx(input.Secretfile(), secrets, true)
+
+	if _, hasGitHubToken := secrets["GITHUB_TOKEN"]; !hasGitHubToken {
+		ctx, cancel := common.EarlyCancelContext(ctx)
+		defer cancel()
+		secrets["GITHUB_TOKEN"], _ = gh.GetToken(ctx, "")
+	}
+
+	log.Debugf("Loading vars from %s", input.Varfile())
+	vars := newSecrets(input.vars)
+	_ = readEnvs(input.Varfile(), vars)
+
+	return envs, secrets, vars
+}
+
+// validateWorkspaceMode reports an unknown --workspace-mode and workspace flags conflicting with it
+func validateWorkspaceMode(input *Input) error {
+	if input.bindWorkdir && input.workspaceMode != "" && input.workspaceMode != runner.WorkspaceModeCopy && input.workspaceMode != runner.WorkspaceModeBind {
+		return fmt.Errorf("--bind conflicts with --workspace-mode %s", input.workspaceMode)
+	}
+	if !slices.Contains(runner.WorkspaceModes, input.WorkspaceMode()) {
+		return fmt.Errorf("--workspace-mode must be one of %s", strings.Join(runner.WorkspaceModes, ", "))
+	}
+	if input.exportWorkspace != "" && input.WorkspaceMode() == runner.WorkspaceModeBind {
+		return fmt.Errorf("--export-workspace conflicts with --bind, the working directory is the workspace")
+	}
+	return nil
+}
+
+// newRunnerConfig builds the runner configuration from the flags
+func newRunnerConfig(input *Input, eventName string, envs, inputs, secrets, vars map[string]string, matrixes map[string]map[string]bool, actionMocks []*runner.ActionMock) *runner.Config {
+	// Check if platforms flag is set, if not, run default image survey
+	if len(input.platforms) == 0 {
+		cfgFound := false
+		cfgLocations := configLocations()
+		for _, v := range cfgLocations {
+			_, err := os.Stat(v)
+			if os.IsExist(err) {
+				cfgFound = true
+			}
+		}
+		if !cfgFound && len(cfgLocations) > 0 {
+			// The first config location refers to the global config folder one
+			if err := defaultImageSurvey(cfgLocations[0]); err != nil {
+				log.Fatal(err)
+			}
+			input.platforms = readArgsFile(cfgLocations[0], true)
+		}
+	}
+	deprecationWarning := "--%s is deprecated and will be removed soon, please switch to cli: `--container-options \"%[2]s\"` or `.actrc`: `--container-options %[2]s`."
+	if input.privileged {
+		log.Warnf(deprecationWarning, "privileged", "--privileged")
+	}
+	if len(input.usernsMode) > 0 {
+		log.Warnf(deprecationWarning, "userns", fmt.Sprintf("--userns=%s", input.usernsMode))
+	}
+	if len(input.containerCapAdd) > 0 {
+		log.Warnf(deprecationWarning, "container-cap-add", fmt.Sprintf("--cap-add=%s", input.containerCapAdd))
+	}
+	if len(input.containerCapDrop) > 0 {
+		log.Warnf(deprecationWarning, "container-cap-drop", fmt.Sprintf("--cap-drop=%s", input.containerCapDrop))
+	}
+
+	config := &runner.Config{
+		Actor:                              input.actor,
+		EventName:                          eventName,
+		EventPath:                          input.EventPath(),
+		DefaultBranch:                      input.defaultBranch,
+		ForcePull:                          !input.actionOfflineMode && input.forcePull,
+		ForceRebuild:                       input.forceRebuild,
+		ReuseContainers:                    input.reuseContainers,
+		Workdir:                            input.Workdir(),
+		ActionCacheDir:                     input.actionCachePath,
+		ActionOfflineMode:                  input.actionOfflineMode,
+		BindWorkdir:                        input.WorkspaceMode() == runner.WorkspaceModeBind,
+		LogOutput:                          !input.noOutput,
+		JSONLogger:                         input.jsonLogger,
+		LogPrefixJobID:                     input.logPrefixJobID,
+		Env:                                envs,
+		Secrets:                            secrets,
+		Vars:                               vars,
+		Inputs:                             inputs,
+		Token:                              secrets["GITHUB_TOKEN"],
+		InsecureSecrets:                    input.insecureSecrets,
+		Platforms:                          input.newPlatforms(),
+		Privileged:                         input.privileged,
+		UsernsMode:                         input.usernsMode,
+		ContainerArchitecture:              input.containerArchitecture,
+		ContainerDaemonSocket:              input.containerDaemonSocket,
+		ContainerOptions:                   input.containerOptions,
+		UseGitIgnore:                       input.useGitIgnore,
+		GitHubInstance:                     input.githubInstance,
+		ContainerCapAdd:                    input.containerCapAdd,
+		ContainerCapDrop:                   input.containerCapDrop,
+		AutoRemove:                         input.autoRemove,
+		ArtifactServerPath:                 input.artifactServerPath,
+		ArtifactServerAddr:                 input.artifactServerAddr,
+		ArtifactServerPort:                 input.artifactServerPort,
+		NoSkipCheckout:                     input.noSkipCheckout,
+		RemoteName:                         input.remoteName,
+		ReplaceGheActionWithGithubCom:      input.replaceGheActionWithGithubCom,
+		ReplaceGheActionTokenWithGithubCom: input.replaceGheActionTokenWithGithubCom,
+		Matrix:                             matrixes,
+		MatrixFilter:                       input.matrixFilter,
+		MatrixIndex:                        input.matrixIndex,
+		Checkpoint:                         input.checkpoint,
+		ContainerPool:                      input.containerPool,
+		PrepareImages:                      input.prepareImages,
+		WorkspaceMode:                      input.WorkspaceMode(),
+		ExportWorkspaceDir:                 input.resolve(input.exportWorkspace),
+		ContainerNetworkMode:               docker_container.NetworkMode(input.networkName),
+		ConcurrentJobs:                     input.concurrentJobs,
+		ActionMocks:                        actionMocks,
+	}
+	if input.memoize {
+		config.MemoizeDir = input.memoizePath
+	}
+	if input.useNewActionCache || len(input.localRepository) > 0 {
+		if input.actionOfflineMode {
+			config.ActionCache = &runner.GoGitActionCacheOfflineMode{
+				Parent: runner.GoGitActionCache{
+					Path: config.ActionCacheDir,
+				},
+			}
+		} else {
+			config.ActionCache = &runner.GoGitActionCache{
+				Path: config.ActionCacheDir,
+			}
+		}
+		if len(input.localRepository) > 0 {
+			localRepositories := map[string]string{}
+			for _, l := range input.localRepository {
+				k, v, _ := strings.Cut(l, "=")
+				localRepositories[k] = v
+			}
+			config.ActionCache = &runner.LocalRepositoryCache{
+				Parent:            config.ActionCache,
+				LocalRepositories: localRepositories,
+				CacheDirCache:     map[string]string{},
+			}
+		}
+	}
+	return config
+}
+
+// executePlan starts the artifact and cache servers and runs the plan
+func executePlan(ctx context.Context, input *Input, config *runner.Config, plan *model.Plan, watch bool) error {
+	r, err := runner.New(config)
+	if err != nil {
+		return err
+	}
+
+	return executeWithServers(ctx, input, config, r.NewPlanExecutor(plan), watch)
+}
+
+// executeWithServers starts the artifact and cache servers and runs the executor
+func executeWithServers(ctx context.Context, input *Input, config *runner.Config, executor common.Executor, watch bool) error {
+	cancel := artifacts.Serve(ctx, input.artifactServerPath, input.artifactServerAddr, input.artifactServerPort)
+
+	const cacheURLKey = "ACTIONS_CACHE_URL"
+	var cacheHandler *artifactcache.Handler
+	if !input.noCacheServer && config.Env[cacheURLKey] == "" {
+		var err error
+		cacheHandler, err = artifactcache.StartHandler(input.cacheServerPath, input.cacheServerExternalURL, input.cacheServerAddr, input.cacheServerPort, common.Logger(ctx))
 		if err != nil {
 			return err
 		}
-		return plannerErr
+		config.Env[cacheURLKey] = cacheHandler.ExternalURL() + "/"
 	}
+
+	ctx = common.WithDryrun(ctx, input.dryrun)
+	if watch {
+		return watchAndRun(ctx, executor)
+	}
+
+	return executor.Finally(func(_ context.Context) error {
+		cancel()
+		_ = cacheHandler.Close()
+		return nil
+	})(ctx)
 }
 
 func defaultImageSurvey(actrc string) error {
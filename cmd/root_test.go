@@ -2,10 +2,16 @@ package cmd
 
 import (
 	"context"
+	"os"
 	"path"
+	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/nektos/act/pkg/model"
+	"github.com/nektos/act/pkg/runner"
 )
 
 func TestReadSecrets(t *testing.T) {
@@ -123,3 +129,215 @@ func TestReadArgsFile(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveDispatchInputs(t *testing.T) {
+	planner, err := model.NewWorkflowPlanner("../pkg/runner/testdata/workflow_dispatch/workflow_dispatch.yml", true, false)
+	assert.NoError(t, err)
+	plan, err := planner.PlanEvent("workflow_dispatch")
+	assert.NoError(t, err)
+
+	err = resolveDispatchInputs(plan, map[string]string{}, false)
+	assert.ErrorContains(t, err, "input 'required' is required")
+
+	err = resolveDispatchInputs(plan, map[string]string{"required": "required input", "boolean": "on"}, false)
+	assert.ErrorContains(t, err, "input 'boolean': 'on' is not a valid boolean")
+
+	err = resolveDispatchInputs(plan, map[string]string{"required": "required input", "boolean": "true"}, false)
+	assert.NoError(t, err)
+}
+
+func TestValidateEventDispatchInputs(t *testing.T) {
+	planner, err := model.NewWorkflowPlanner("../pkg/runner/testdata/workflow_dispatch/workflow_dispatch.yml", true, false)
+	assert.NoError(t, err)
+	plan, err := planner.PlanEvent("workflow_dispatch")
+	assert.NoError(t, err)
+	eventPath := filepath.Join(t.TempDir(), "event.json")
+	assert.NoError(t, os.WriteFile(eventPath, []byte(`{"inputs":{"required":"from the event","boolean":true}}`), 0o600))
+
+	assert.NoError(t, validateEventDispatchInputs(plan, eventPath, map[string]string{}))
+	err = validateEventDispatchInputs(plan, eventPath, map[string]string{"boolean": "on"})
+	assert.ErrorContains(t, err, "input 'boolean': 'on' is not a valid boolean")
+
+	assert.NoError(t, os.WriteFile(eventPath, []byte(`{}`), 0o600))
+	err = validateEventDispatchInputs(plan, eventPath, map[string]string{})
+	assert.ErrorContains(t, err, "input 'required' is required")
+}
+
+func TestCallInvalidInputs(t *testing.T) {
+	input := &Input{
+		workdir: "../pkg/runner/testdata/",
+	}
+	callCmd := newCallCommand(context.Background(), input)
+	input.with = []string{"string_required=string", "bool_required=yes", "undeclared=1"}
+	err := newCallRunCommand(context.Background(), input)(callCmd, []string{".github/workflows/local-reusable-workflow.yml"})
+	assert.ErrorContains(t, err, "Input bool_required: expected a boolean but got 'yes'")
+	assert.ErrorContains(t, err, "Input number_required is required, but not provided while calling")
+	assert.ErrorContains(t, err, "Invalid input, undeclared is not defined in the referenced workflow")
+
+	err = newCallRunCommand(context.Background(), input)(callCmd, []string{"basic/push.yml"})
+	assert.ErrorContains(t, err, "is not triggered by workflow_call")
+}
+
+func TestActionUses(t *testing.T) {
+	workdir, err := filepath.Abs("../pkg/runner/testdata")
+	assert.NoError(t, err)
+
+	tables := []struct {
+		action string
+		uses   string
+		err    string
+	}{
+		{"actions/node20", "./actions/node20", ""},
+		{filepath.Join(workdir, "actions", "node20"), "./actions/node20", ""},
+		{".", "./", ""},
+		{"docker://alpine:3", "docker://alpine:3", ""},
+		{"actions/hello-world-javascript-action@main", "actions/hello-world-javascript-action@main", ""},
+		{"..", "", "must be located inside the working directory"},
+		{"actions/missing", "", "not found"},
+	}
+	for _, table := range tables {
+		t.Run(table.action, func(t *testing.T) {
+			uses, err := actionUses(workdir, table.action)
+			if table.err != "" {
+				assert.ErrorContains(t, err, table.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, table.uses, uses)
+		})
+	}
+}
+
+func TestJobFilter(t *testing.T) {
+	planner, err := model.NewWorkflowPlanner("testdata/job-filter", true, false)
+	assert.NoError(t, err)
+
+	plan := func(patterns []string, expression string) []string {
+		filter, err := newJobFilter(patterns, expression)
+		assert.NoError(t, err)
+		p, err := planner.PlanJobs(filter, true)
+		assert.NoError(t, err)
+		jobIDs := []string{}
+		for _, stage := range p.Stages {
+			jobIDs = append(jobIDs, stage.GetJobIDs()...)
+		}
+		return jobIDs
+	}
+
+	assert.ElementsMatch(t, []string{"test-unit", "test-e2e"}, plan([]string{"test-*"}, ""))
+	assert.ElementsMatch(t, []string{"test-unit", "lint"}, plan([]string{"test-unit", "lint"}, ""))
+	assert.ElementsMatch(t, []string{"test-unit", "lint"}, plan(nil, "contains(job.runs-on, 'ubuntu')"))
+	assert.ElementsMatch(t, []string{"test-e2e"}, plan([]string{"test-*"}, "contains(job.runs-on, 'linux') && workflow == 'ci.yml'"))
+
+	_, err = newJobFilter([]string{"test-["}, "")
+	assert.Error(t, err)
+}
+
+func TestValidateWorkspaceMode(t *testing.T) {
+	assert.NoError(t, validateWorkspaceMode(&Input{}))
+	assert.NoError(t, validateWorkspaceMode(&Input{bindWorkdir: true, workspaceMode: "copy"}))
+	assert.NoError(t, validateWorkspaceMode(&Input{workspaceMode: "overlay", exportWorkspace: "changes"}))
+	assert.Error(t, validateWorkspaceMode(&Input{workspaceMode: "tmpfs"}))
+	assert.Error(t, validateWorkspaceMode(&Input{bindWorkdir: true, workspaceMode: "overlay"}))
+	assert.NoError(t, validateWorkspaceMode(&Input{exportWorkspace: "workspace"}))
+	assert.Error(t, validateWorkspaceMode(&Input{bindWorkdir: true, exportWorkspace: "workspace"}))
+
+	assert.Equal(t, "bind", (&Input{bindWorkdir: true, workspaceMode: "copy"}).WorkspaceMode())
+}
+
+func TestWorkflowTestSuite(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the self-hosted jobs of the suite run with sh")
+	}
+	input := &Input{
+		platforms:     []string{"ubuntu-latest=-self-hosted"},
+		workdir:       "../pkg/workflowtest/testdata/",
+		workflowsPath: "./.github/workflows/",
+		noCacheServer: true,
+	}
+	testCmd := newTestCommand(context.Background(), input)
+	input.junitReport = filepath.Join(t.TempDir(), "report.xml")
+	err := newTestRunCommand(context.Background(), input)(testCmd, []string{"release.yaml"})
+	assert.NoError(t, err)
+	assert.FileExists(t, input.junitReport)
+
+	failing := filepath.Join(t.TempDir(), "failing.yaml")
+	assert.NoError(t, os.WriteFile(failing, []byte(`
+tests:
+  - workflow: .github/workflows/release.yml
+    payload:
+      ref: refs/tags/v2.0.0
+    expect:
+      jobs:
+        build:
+          outputs:
+            version: 1.2.3
+`), 0o600))
+	err = newTestRunCommand(context.Background(), input)(testCmd, []string{failing})
+	assert.EqualError(t, err, "1 of 1 workflow tests failed")
+}
+
+func TestRunInvalidMocks(t *testing.T) {
+	rootCmd := createRootCommand(context.Background(), &Input{}, "")
+	err := newRunCommand(context.Background(), &Input{
+		platforms:     []string{"ubuntu-latest=-self-hosted"},
+		workdir:       "../pkg/runner/testdata/",
+		workflowsPath: "./basic/push.yml",
+		mockActions:   []string{"actions/checkout@*"},
+	})(rootCmd, []string{"push"})
+	assert.EqualError(t, err, "invalid --mock-action 'actions/checkout@*', expected <glob>=<file>")
+
+	err = newRunCommand(context.Background(), &Input{
+		platforms:     []string{"ubuntu-latest=-self-hosted"},
+		workdir:       "../pkg/runner/testdata/",
+		workflowsPath: "./basic/push.yml",
+		mockFile:      "missing-mocks.yml",
+	})(rootCmd, []string{"push"})
+	assert.ErrorContains(t, err, "missing-mocks.yml")
+}
+
+func TestRunInvalidExports(t *testing.T) {
+	rootCmd := createRootCommand(context.Background(), &Input{}, "")
+	err := newRunCommand(context.Background(), &Input{
+		platforms:     []string{"ubuntu-latest=-self-hosted"},
+		workdir:       "../pkg/runner/testdata/",
+		workflowsPath: "./basic/push.yml",
+		exports:       []string{"build:dist"},
+	})(rootCmd, []string{"push"})
+	assert.EqualError(t, err, "invalid export 'build:dist', expected <job>:<container-path>=<host-dir>")
+
+	err = newRunCommand(context.Background(), &Input{
+		platforms:     []string{"ubuntu-latest=-self-hosted"},
+		workdir:       "../pkg/runner/testdata/",
+		workflowsPath: "./basic/push.yml",
+		exports:       []string{"missing:dist=./out"},
+	})(rootCmd, []string{"push"})
+	assert.EqualError(t, err, "invalid export 'missing:dist=./out', job 'missing' is not part of the plan")
+}
+
+func TestMatrixCombinations(t *testing.T) {
+	planner, err := model.NewWorkflowPlanner("testdata/matrix-list", true, false)
+	assert.NoError(t, err)
+	plan, err := planner.PlanEvent("push")
+	assert.NoError(t, err)
+	run := plan.Stages[0].Runs[0]
+
+	config := &runner.Config{
+		Workdir:     "testdata/matrix-list",
+		EventName:   "push",
+		Vars:        map[string]string{"NODE_VERSIONS": "[18, 20]"},
+		MatrixIndex: 3,
+	}
+	combinations, err := matrixCombinations(context.Background(), config, run)
+	assert.NoError(t, err)
+	assert.Equal(t, []matrixCombination{{index: "3", values: "node=20, os=linux"}}, combinations)
+
+	config.MatrixIndex = 0
+	config.MatrixFilter = "matrix.os == 'windows'"
+	combinations, err = matrixCombinations(context.Background(), config, run)
+	assert.NoError(t, err)
+	assert.Equal(t, []matrixCombination{
+		{index: "2", values: "node=18, os=windows"},
+		{index: "4", values: "node=20, os=windows"},
+	}, combinations)
+}
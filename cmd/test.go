@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/nektos/act/pkg/model"
+	"github.com/nektos/act/pkg/runner"
+	"github.com/nektos/act/pkg/workflowtest"
+)
+
+func newTestCommand(ctx context.Context, input *Input) *cobra.Command {
+	testCmd := &cobra.Command{
+		Use:   "test <file>... [flags]",
+		Short: "Run declarative workflow tests and verify the results, outputs, env and logs of their jobs",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  newTestRunCommand(ctx, input),
+	}
+
+	testCmd.Flags().StringVar(&input.junitReport, "junit", "", "write a JUnit compatible XML report of the test results to this file")
+	return testCmd
+}
+
+func newTestRunCommand(ctx context.Context, input *Input) func(*cobra.Command, []string) error {
+	return func(_ *cobra.Command, args []string) error {
+		if input.jsonLogger {
+			log.SetFormatter(&log.JSONFormatter{})
+		}
+
+		suites := make([]*workflowtest.Suite, 0, len(args))
+		for _, arg := range args {
+			suite, err := workflowtest.ReadSuite(input.resolve(arg))
+			if err != nil {
+				return err
+			}
+			suites = append(suites, suite)
+		}
+
+		actionMocks, err := input.newActionMocks()
+		if err != nil {
+			return err
+		}
+
+		setupContainerEngine(input)
+
+		envs, secrets, vars := loadRunEnvironment(ctx, input)
+
+		total, failed := 0, 0
+		reports := make([]workflowtest.SuiteResults, 0, len(suites))
+		for _, suite := range suites {
+			report := workflowtest.SuiteResults{Suite: suite}
+			for _, test := range suite.Tests {
+				result := runWorkflowTest(ctx, input, suite, test, envs, secrets, vars, actionMocks)
+				printTestResult(result)
+				report.Results = append(report.Results, result)
+				total++
+				if !result.Passed() {
+					failed++
+				}
+			}
+			reports = append(reports, report)
+		}
+
+		if input.junitReport != "" {
+			f, err := os.Create(input.junitReport)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			if err := workflowtest.WriteJUnit(f, reports); err != nil {
+				return err
+			}
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d workflow tests failed", failed, total)
+		}
+		fmt.Printf("PASS: %d workflow tests\n", total)
+		return nil
+	}
+}
+
+// runWorkflowTest plans the workflows for the event of the test, runs them and verifies the expectations
+func runWorkflowTest(ctx context.Context, input *Input, suite *workflowtest.Suite, test *workflowtest.Test, envs, secrets, vars map[string]string, actionMocks []*runner.ActionMock) *workflowtest.Result {
+	start := time.Now()
+	result := &workflowtest.Result{Name: test.Name}
+	defer func() {
+		result.Duration = time.Since(start)
+	}()
+
+	workflows := input.WorkflowsPath()
+	if test.Workflow != "" {
+		workflows = input.resolve(test.Workflow)
+	}
+	planner, err := model.NewWorkflowPlanner(workflows, input.noWorkflowRecurse, input.strict)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	plan, err := planner.PlanEvent(test.Event)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	payload, err := test.EventPayload(filepath.Dir(suite.File))
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	eventFile, err := os.CreateTemp("", "act-test-event-*.json")
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	defer os.Remove(eventFile.Name())
+	_, err = eventFile.Write(payload)
+	if closeErr := eventFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	exports, err := input.newJobExports(plan)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	// mocks of the test take precedence over the mocks of the flags
+	mocks := append(append([]*runner.ActionMock{}, test.Mocks...), actionMocks...)
+	config := newRunnerConfig(input, test.Event, mergeMaps(envs, test.Env), test.Inputs, mergeMaps(secrets, test.Secrets), mergeMaps(vars, test.Vars), map[string]map[string]bool{}, mocks)
+	config.Exports = exports
+	config.EventPath = eventFile.Name()
+
+	recorder := &workflowtest.Recorder{}
+	runErr := executePlan(runner.WithJobLoggerFactory(ctx, recorder), input, config, plan, false)
+
+	result.Entries = recorder.Entries()
+	result.Failures = test.Verify(plan, result.Entries, runErr)
+	return result
+}
+
+func printTestResult(result *workflowtest.Result) {
+	if result.Passed() {
+		fmt.Printf("--- PASS: %s (%.2fs)\n", result.Name, result.Duration.Seconds())
+		return
+	}
+
+	fmt.Printf("--- FAIL: %s (%.2fs)\n", result.Name, result.Duration.Seconds())
+	if result.Error != nil {
+		fmt.Printf("    %v\n", result.Error)
+	}
+	for _, failure := range result.Failures {
+		fmt.Printf("    %s\n", failure)
+	}
+	for _, entry := range result.Entries {
+		fmt.Printf("        %s\n", entry)
+	}
+}
+
+func mergeMaps(maps ...map[string]string) map[string]string {
+	merged := map[string]string{}
+	for _, m := range maps {
+		for k, v := range m {
+			merged[k] = v
+		}
+	}
+	return merged
+}
@@ -1,12 +1,18 @@
 package common
 
+import "sort"
+
 // CartesianProduct takes map of lists and returns list of unique tuples
 func CartesianProduct(mapOfLists map[string][]interface{}) []map[string]interface{} {
 	listNames := make([]string, 0)
-	lists := make([][]interface{}, 0)
-	for k, v := range mapOfLists {
+	for k := range mapOfLists {
 		listNames = append(listNames, k)
-		lists = append(lists, v)
+	}
+	// sort the names, so the combinations are always returned in the same order
+	sort.Strings(listNames)
+	lists := make([][]interface{}, 0)
+	for _, k := range listNames {
+		lists = append(lists, mapOfLists[k])
 	}
 
 	listCart := cartN(lists...)
@@ -25,6 +25,10 @@ func TestCartesianProduct(t *testing.T) {
 		assert.Contains(v, "baz")
 	}
 
+	assert.Equal(map[string]interface{}{"bar": "a", "baz": false, "foo": 1}, output[0])
+	assert.Equal(map[string]interface{}{"bar": "a", "baz": true, "foo": 1}, output[4])
+	assert.Equal(map[string]interface{}{"bar": "c", "baz": true, "foo": 4}, output[23])
+
 	input = map[string][]interface{}{
 		"foo": {1, 2, 3, 4},
 		"bar": {},
@@ -0,0 +1,74 @@
+package container
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-billy/v5/helper/polyfill"
+	"github.com/go-git/go-billy/v5/osfs"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+
+	"github.com/nektos/act/pkg/common"
+	"github.com/nektos/act/pkg/filecollector"
+)
+
+// newFilesArchive returns a tar archive of the files for backends copying files with tar
+func newFilesArchive(ctx context.Context, files ...*FileEntry) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, file := range files {
+		common.Logger(ctx).Debugf("Writing entry to tarball %s len:%d", file.Name, len(file.Body))
+		hdr := &tar.Header{
+			Name: file.Name,
+			Mode: int64(file.Mode),
+			Size: int64(len(file.Body)),
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write([]byte(file.Body)); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}
+
+// writeDirArchive writes a tar archive of srcPath with entries below dstDir to w, the
+// content of srcPath is archived without its name if it ends with a separator like CopyDir
+func writeDirArchive(ctx context.Context, w io.Writer, srcPath string, dstDir string, useGitIgnore bool) error {
+	srcPrefix := filepath.Dir(srcPath)
+	if !strings.HasSuffix(srcPrefix, string(filepath.Separator)) {
+		srcPrefix += string(filepath.Separator)
+	}
+	var ignorer gitignore.Matcher
+	if useGitIgnore {
+		ps, err := gitignore.ReadPatterns(polyfill.New(osfs.New(srcPath)), nil)
+		if err != nil {
+			common.Logger(ctx).Debugf("Error loading .gitignore: %v", err)
+		}
+		ignorer = gitignore.NewMatcher(ps)
+	}
+
+	tw := tar.NewWriter(w)
+	fc := &filecollector.FileCollector{
+		Fs:        &filecollector.DefaultFs{},
+		Ignorer:   ignorer,
+		SrcPath:   srcPath,
+		SrcPrefix: srcPrefix,
+		Handler: &filecollector.TarCollector{
+			TarWriter: tw,
+			DstDir:    dstDir,
+		},
+	}
+	if err := filepath.Walk(srcPath, fc.CollectFiles(ctx, []string{})); err != nil {
+		return err
+	}
+	return tw.Close()
+}
@@ -0,0 +1,155 @@
+package container
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// names of the built-in backends
+const (
+	BackendDocker = "docker" // runs jobs in docker containers, the default for platforms with an image
+	BackendHost   = "host"   // runs jobs on the host, selected by -self-hosted
+)
+
+// names of the kubernetes backend, it is only included in builds with the WITH_KUBERNETES
+// build tag to keep the kubernetes client out of act
+const (
+	BackendKubernetes    = "kubernetes"
+	BackendKubernetesK8s = "k8s"
+)
+
+// BackendInput is the environment of a job requested from a Backend
+type BackendInput struct {
+	NewContainerInput
+	Workdir  string // working directory of the job on the host
+	CacheDir string // directory for the files of the job on the host
+	// Services are the service containers of the job by service id, backends without
+	// support for services return an error. The runner starts the services of the
+	// docker backend itself.
+	Services map[string]*NewContainerInput
+}
+
+// Backend creates the environments jobs run in. The runner pulls, creates and starts
+// the environment before the first step of the job and removes it after the job.
+type Backend interface {
+	NewEnvironment(ctx context.Context, input *BackendInput) (ExecutionsEnvironment, error)
+}
+
+// StepContainerEnvironment is implemented by job environments of backends which run the
+// containers of docker actions and docker:// steps themselves. The runner runs these
+// containers with docker for the docker and host backends, the jobs of other backends
+// without it fail on such steps. NetworkMode and the binds and mounts of the input are
+// those of docker, the backend provides the network and workspace of the job its own way.
+// The image of a docker action with a Dockerfile is built by the backend from build, which
+// is nil for the images of docker:// steps and actions.
+type StepContainerEnvironment interface {
+	NewStepContainer(input *NewContainerInput, build *NewDockerBuildExecutorInput) Container
+}
+
+// BackendFunc is a Backend implemented by a function
+type BackendFunc func(ctx context.Context, input *BackendInput) (ExecutionsEnvironment, error)
+
+func (f BackendFunc) NewEnvironment(ctx context.Context, input *BackendInput) (ExecutionsEnvironment, error) {
+	return f(ctx, input)
+}
+
+var (
+	backendsMu sync.RWMutex
+	backends   = map[string]Backend{}
+)
+
+func init() {
+	RegisterBackend(BackendDocker, BackendFunc(newDockerEnvironment))
+	RegisterBackend(BackendHost, BackendFunc(newHostEnvironment))
+	RegisterBackend(BackendSSH, BackendFunc(newSSHEnvironment))
+	RegisterBackend(BackendHooks, BackendFunc(newHooksEnvironment))
+}
+
+// RegisterBackend makes a backend selectable with -P <platform>=backend:<name>:<image>,
+// a backend registered before with the same name is replaced
+func RegisterBackend(name string, backend Backend) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	backends[strings.ToLower(name)] = backend
+}
+
+// GetBackend returns the backend registered with the name
+func GetBackend(name string) (Backend, error) {
+	backendsMu.RLock()
+	backend, ok := backends[strings.ToLower(name)]
+	backendsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown backend '%s', the registered backends are %s", name, strings.Join(BackendNames(), ", "))
+	}
+	return backend, nil
+}
+
+// BackendNames returns the sorted names of the registered backends
+func BackendNames() []string {
+	backendsMu.RLock()
+	defer backendsMu.RUnlock()
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func newDockerEnvironment(_ context.Context, input *BackendInput) (ExecutionsEnvironment, error) {
+	c := NewContainer(&input.NewContainerInput)
+	if c == nil {
+		return nil, errors.New("Failed to create job container")
+	}
+	return c, nil
+}
+
+// newHostEnvironment creates the directories of a job running on the host in a
+// random directory of the cache directory, they are removed with the environment
+func newHostEnvironment(_ context.Context, input *BackendInput) (ExecutionsEnvironment, error) {
+	randBytes := make([]byte, 8)
+	_, _ = rand.Read(randBytes)
+	miscpath := filepath.Join(input.CacheDir, hex.EncodeToString(randBytes))
+	actPath := filepath.Join(miscpath, "act")
+	if err := os.MkdirAll(actPath, 0o777); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(miscpath, "hostexecutor")
+	if err := os.MkdirAll(path, 0o777); err != nil {
+		return nil, err
+	}
+	runnerTmp := filepath.Join(miscpath, "tmp")
+	if err := os.MkdirAll(runnerTmp, 0o777); err != nil {
+		return nil, err
+	}
+	return &HostEnvironment{
+		Path:      path,
+		TmpDir:    runnerTmp,
+		ToolCache: filepath.Join(input.CacheDir, "tool_cache"),
+		Workdir:   input.Workdir,
+		ActPath:   actPath,
+		CleanUp: func() {
+			os.RemoveAll(miscpath)
+		},
+		StdOut: input.Stdout,
+	}, nil
+}
+
+// trimToLen returns the first l bytes of s, like the names of backends limited in length
+func trimToLen(s string, l int) string {
+	if l < 0 {
+		l = 0
+	}
+	if len(s) > l {
+		return s[:l]
+	}
+	return s
+}
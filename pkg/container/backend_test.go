@@ -0,0 +1,43 @@
+package container
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackendRegistry(t *testing.T) {
+	assert.Contains(t, BackendNames(), BackendDocker)
+	assert.Contains(t, BackendNames(), BackendHost)
+
+	_, err := GetBackend("unknown")
+	assert.ErrorContains(t, err, "unknown backend 'unknown'")
+
+	env := &HostEnvironment{}
+	RegisterBackend("Custom", BackendFunc(func(context.Context, *BackendInput) (ExecutionsEnvironment, error) {
+		return env, nil
+	}))
+	backend, err := GetBackend("custom")
+	assert.NoError(t, err)
+	created, err := backend.NewEnvironment(context.Background(), &BackendInput{})
+	assert.NoError(t, err)
+	assert.Same(t, env, created)
+}
+
+func TestHostBackend(t *testing.T) {
+	backend, err := GetBackend(BackendHost)
+	assert.NoError(t, err)
+	env, err := backend.NewEnvironment(context.Background(), &BackendInput{Workdir: "/src", CacheDir: t.TempDir()})
+	assert.NoError(t, err)
+
+	host := env.(*HostEnvironment)
+	assert.Equal(t, "/src", host.Workdir)
+	assert.DirExists(t, host.ActPath)
+	assert.DirExists(t, host.TmpDir)
+
+	assert.NoError(t, env.Remove()(context.Background()))
+	_, err = os.Stat(host.ActPath)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,175 @@
+package container
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/moby/patternmatcher"
+	"github.com/moby/patternmatcher/ignorefile"
+)
+
+// BuildContext is a docker build context reduced to the files included by
+// its .dockerignore, the Dockerfile and .dockerignore are always included.
+// Only the hash is kept, the archive is read again by Open for a build.
+type BuildContext struct {
+	Hash string // sha256 of the names, modes, link targets and contents of the included files
+
+	open          func() (io.ReadCloser, error)
+	relDockerfile string
+	dockerfile    []byte // content of the Dockerfile, nil if it is not part of the build context
+	excludes      *patternmatcher.PatternMatcher
+}
+
+type buildContextEntry struct {
+	typeflag byte
+	mode     int64
+	linkname string
+	digest   [sha256.Size]byte
+}
+
+// NewBuildContext reads the tar archive of a build context returned by open and hashes the
+// included files, the entries are sorted by name so the same files always produce the same
+// hash independent of the order of the archive. The contents are hashed while they are read.
+func NewBuildContext(open func() (io.ReadCloser, error), relDockerfile string) (*BuildContext, error) {
+	archive, err := open()
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+
+	relDockerfile = path.Clean(relDockerfile)
+	entries := map[string]*buildContextEntry{}
+	var dockerignore, dockerfile []byte
+	tr := tar.NewReader(archive)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read build context: %w", err)
+		}
+		name := buildContextName(header)
+		if name == "." {
+			continue
+		}
+		hash := sha256.New()
+		content := io.Reader(tr)
+		if name == ".dockerignore" || name == relDockerfile {
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read build context: %w", err)
+			}
+			if name == ".dockerignore" {
+				dockerignore = data
+			} else {
+				dockerfile = data
+			}
+			content = bytes.NewReader(data)
+		}
+		if _, err := io.Copy(hash, content); err != nil {
+			return nil, fmt.Errorf("failed to read build context: %w", err)
+		}
+		entry := &buildContextEntry{typeflag: header.Typeflag, mode: header.Mode, linkname: header.Linkname}
+		hash.Sum(entry.digest[:0])
+		entries[name] = entry
+	}
+
+	var excludes []string
+	if dockerignore != nil {
+		if excludes, err = ignorefile.ReadAll(bytes.NewReader(dockerignore)); err != nil {
+			return nil, err
+		}
+	}
+	pm, err := patternmatcher.New(excludes)
+	if err != nil {
+		return nil, err
+	}
+	buildContext := &BuildContext{
+		open:          open,
+		relDockerfile: relDockerfile,
+		dockerfile:    dockerfile,
+		excludes:      pm,
+	}
+
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		if included, err := buildContext.includes(name); err != nil {
+			return nil, err
+		} else if included {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	hash := sha256.New()
+	for _, name := range names {
+		entry := entries[name]
+		fmt.Fprintf(hash, "%s\x00%c\x00%o\x00%s\x00%x\n", name, entry.typeflag, entry.mode&0o7777, entry.linkname, entry.digest)
+	}
+	buildContext.Hash = hex.EncodeToString(hash.Sum(nil))
+	return buildContext, nil
+}
+
+func buildContextName(header *tar.Header) string {
+	return path.Clean(strings.TrimPrefix(header.Name, "/"))
+}
+
+func (c *BuildContext) includes(name string) (bool, error) {
+	if name == ".dockerignore" || name == c.relDockerfile {
+		return true, nil
+	}
+	excluded, err := c.excludes.MatchesOrParentMatches(name)
+	return !excluded, err
+}
+
+// Open reads the build context again and returns the tar archive of the included files
+func (c *BuildContext) Open() (io.ReadCloser, error) {
+	archive, err := c.open()
+	if err != nil {
+		return nil, err
+	}
+	rpipe, wpipe := io.Pipe()
+	go func() {
+		defer archive.Close()
+		wpipe.CloseWithError(c.copyIncluded(tar.NewWriter(wpipe), tar.NewReader(archive)))
+	}()
+	return rpipe, nil
+}
+
+func (c *BuildContext) copyIncluded(tw *tar.Writer, tr *tar.Reader) error {
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return tw.Close()
+		} else if err != nil {
+			return fmt.Errorf("failed to read build context: %w", err)
+		}
+		name := buildContextName(header)
+		if name == "." {
+			continue
+		}
+		if included, err := c.includes(name); err != nil {
+			return err
+		} else if !included {
+			continue
+		}
+		header.Name = name
+		if header.Typeflag == tar.TypeDir {
+			header.Name += "/"
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package container
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type buildContextFile struct {
+	name    string
+	content string
+}
+
+func buildContextArchive(t *testing.T, files ...buildContextFile) func() (io.ReadCloser, error) {
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	for _, file := range files {
+		assert.NoError(t, tw.WriteHeader(&tar.Header{Name: file.name, Mode: 0o644, Size: int64(len(file.content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(file.content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+	return func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(buf.Bytes())), nil
+	}
+}
+
+func buildContextNames(t *testing.T, buildContext *BuildContext) []string {
+	names := []string{}
+	archive, err := buildContext.Open()
+	assert.NoError(t, err)
+	defer archive.Close()
+	tr := tar.NewReader(archive)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		names = append(names, header.Name)
+	}
+	return names
+}
+
+func TestNewBuildContext(t *testing.T) {
+	files := []buildContextFile{
+		{"./Dockerfile", "FROM alpine"},
+		{"./.dockerignore", "*.md\nDockerfile\ntmp\n!tmp/keep"},
+		{"./entrypoint.sh", "#!/bin/sh"},
+		{"./README.md", "readme"},
+		{"./tmp/cache", "cache"},
+		{"./tmp/keep", "keep"},
+	}
+
+	buildContext, err := NewBuildContext(buildContextArchive(t, files...), "Dockerfile")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{".dockerignore", "Dockerfile", "entrypoint.sh", "tmp/keep"}, buildContextNames(t, buildContext))
+
+	reversed := append([]buildContextFile{}, files...)
+	sort.Slice(reversed, func(i, j int) bool { return reversed[i].name > reversed[j].name })
+	other, err := NewBuildContext(buildContextArchive(t, reversed...), "Dockerfile")
+	assert.NoError(t, err)
+	assert.Equal(t, buildContext.Hash, other.Hash, "the order of the archive does not change the hash")
+
+	ignored := append([]buildContextFile{}, files...)
+	ignored[3] = buildContextFile{"./README.md", "changed readme"}
+	other, err = NewBuildContext(buildContextArchive(t, ignored...), "Dockerfile")
+	assert.NoError(t, err)
+	assert.Equal(t, buildContext.Hash, other.Hash, "ignored files do not change the hash")
+
+	changed := append([]buildContextFile{}, files...)
+	changed[2] = buildContextFile{"./entrypoint.sh", "#!/bin/bash"}
+	other, err = NewBuildContext(buildContextArchive(t, changed...), "Dockerfile")
+	assert.NoError(t, err)
+	assert.NotEqual(t, buildContext.Hash, other.Hash)
+}
+
+func TestBuildContextOpen(t *testing.T) {
+	opened := 0
+	archive := buildContextArchive(t, buildContextFile{"Dockerfile", "FROM alpine"}, buildContextFile{"main.go", "package main"})
+	buildContext, err := NewBuildContext(func() (io.ReadCloser, error) {
+		opened++
+		return archive()
+	}, "Dockerfile")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, opened)
+
+	assert.Equal(t, []string{"Dockerfile", "main.go"}, buildContextNames(t, buildContext))
+	assert.Equal(t, 2, opened, "the build context is read again to be sent")
+}
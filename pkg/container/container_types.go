@@ -57,13 +57,33 @@ type Container interface {
 	GetHealth(ctx context.Context) Health
 }
 
+// Checkpointer is implemented by containers whose filesystem can be committed to an image
+type Checkpointer interface {
+	Commit(image string) common.Executor
+}
+
+// ServiceContext is the container of a service in the job context
+type ServiceContext struct {
+	ID      string            // the id of the container
+	Network string            // the id of the network the container is connected to
+	Ports   map[string]string // the host port of each published port, ports of other protocols than tcp have the protocol as suffix like 53/udp
+}
+
+// ServiceInspector is implemented by the containers of services
+type ServiceInspector interface {
+	InspectService(ctx context.Context) (*ServiceContext, error)
+}
+
 // NewDockerBuildExecutorInput the input for the NewDockerBuildExecutor function
 type NewDockerBuildExecutorInput struct {
 	ContextDir   string
 	Dockerfile   string
-	BuildContext io.Reader
+	BuildContext *BuildContext // the build context to send, the context dir is archived if nil
 	ImageTag     string
 	Platform     string
+	BuildArgs    map[string]string
+	Target       string            // the stage of a multi-stage Dockerfile to build
+	Secrets      map[string][]byte // secrets of RUN --mount=type=secret,id=<key>, only available with BuildKit
 }
 
 // NewDockerPullExecutorInput the input for the NewDockerPullExecutor function
@@ -4,12 +4,14 @@ package container
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/moby/go-archive"
+	"github.com/moby/moby/api/types/build"
 	"github.com/moby/moby/client"
 	specs "github.com/opencontainers/image-spec/specs-go/v1"
 
@@ -53,9 +55,30 @@ func NewDockerBuildExecutor(input NewDockerBuildExecutorInput) common.Executor {
 				options.Platforms = []specs.Platform{{OS: parts[0], Architecture: parts[1]}}
 			}
 		}
+		if len(input.BuildArgs) > 0 {
+			options.BuildArgs = map[string]*string{}
+			for k, v := range input.BuildArgs {
+				options.BuildArgs[k] = &v
+			}
+		}
+		options.Target = input.Target
+
+		if useBuildKit(ctx, cli) {
+			s, err := startBuildKitSession(ctx, cli, input.Secrets)
+			if err != nil {
+				return err
+			}
+			defer s.Close()
+			options.Version = build.BuilderBuildKit
+			options.SessionID = s.ID()
+		} else if len(input.Secrets) > 0 {
+			return fmt.Errorf("build secrets of image '%s' require BuildKit, enable it with DOCKER_BUILDKIT=1", input.ImageTag)
+		} else if feature := buildKitFeature(readDockerfile(input)); feature != "" {
+			return fmt.Errorf("the Dockerfile of image '%s' uses %s, which requires BuildKit, enable it with DOCKER_BUILDKIT=1", input.ImageTag, feature)
+		}
 		var buildContext io.ReadCloser
 		if input.BuildContext != nil {
-			buildContext = io.NopCloser(input.BuildContext)
+			buildContext, err = input.BuildContext.Open()
 		} else {
 			buildContext, err = createBuildContext(ctx, input.ContextDir, input.Dockerfile)
 		}
@@ -75,6 +98,22 @@ func NewDockerBuildExecutor(input NewDockerBuildExecutorInput) common.Executor {
 		return nil
 	}
 }
+
+// readDockerfile returns the content of the Dockerfile of the build or nil if it can't be read
+func readDockerfile(input NewDockerBuildExecutorInput) []byte {
+	if input.BuildContext != nil {
+		return input.BuildContext.dockerfile
+	}
+	dockerfile, _ := os.ReadFile(filepath.Join(input.ContextDir, input.Dockerfile))
+	return dockerfile
+}
+
+// NewBuildContextArchive returns the build context of the directory, the
+// files excluded by the .dockerignore of the directory are left out
+func NewBuildContextArchive(ctx context.Context, contextDir string, relDockerfile string) (io.ReadCloser, error) {
+	return createBuildContext(ctx, contextDir, relDockerfile)
+}
+
 func createBuildContext(ctx context.Context, contextDir string, relDockerfile string) (io.ReadCloser, error) {
 	common.Logger(ctx).Debugf("Creating archive for build context dir '%s' with relative dockerfile '%s'", contextDir, relDockerfile)
 
@@ -0,0 +1,337 @@
+//go:build !(WITHOUT_DOCKER || !(linux || darwin || windows || netbsd))
+
+package container
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/moby/moby/api/types/build"
+	"github.com/moby/moby/client"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/http2"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/nektos/act/pkg/common"
+)
+
+const buildKitTraceID = "moby.buildkit.trace"
+
+// useBuildKit returns whether images are built with BuildKit, like the docker cli
+// DOCKER_BUILDKIT overrides the default builder of the daemon
+func useBuildKit(ctx context.Context, cli client.APIClient) bool {
+	if value, ok := os.LookupEnv("DOCKER_BUILDKIT"); ok {
+		enabled, err := strconv.ParseBool(value)
+		if err == nil {
+			return enabled
+		}
+		common.Logger(ctx).Warnf("DOCKER_BUILDKIT=%s is not a boolean, using the default builder of the docker daemon", value)
+	}
+	ping, err := cli.Ping(ctx, client.PingOptions{})
+	if err != nil {
+		common.Logger(ctx).Debugf("Unable to detect the builder of the docker daemon: %v", err)
+		return false
+	}
+	return ping.BuilderVersion == build.BuilderBuildKit
+}
+
+// buildKitFeature returns the first feature of the Dockerfile, which the classic builder does
+// not support, like a syntax directive, heredocs or RUN --mount, or "" if there is none
+func buildKitFeature(dockerfile []byte) string {
+	escape := "\\"
+	lines := strings.Split(strings.ReplaceAll(string(dockerfile), "\r\n", "\n"), "\n")
+	directives := true
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if directives {
+			// parser directives are only recognized before the first instruction or comment
+			if m := dockerfileDirective.FindStringSubmatch(line); m != nil {
+				switch strings.ToLower(m[1]) {
+				case "syntax":
+					return fmt.Sprintf("the syntax directive '%s'", m[2])
+				case "escape":
+					escape = m[2]
+				}
+				continue
+			}
+			directives = false
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		start := i + 1
+		for strings.HasSuffix(line, escape) && i+1 < len(lines) {
+			i++
+			next := strings.TrimSpace(lines[i])
+			if strings.HasPrefix(next, "#") {
+				continue
+			}
+			line = strings.TrimSuffix(line, escape) + " " + next
+		}
+
+		fields := strings.Fields(line)
+		instruction := strings.ToUpper(fields[0])
+		for _, field := range fields[1:] {
+			if !strings.HasPrefix(field, "--") {
+				break
+			}
+			name, _, _ := strings.Cut(field, "=")
+			if !classicBuilderFlags[name] {
+				return fmt.Sprintf("%s %s on line %d", instruction, name, start)
+			}
+		}
+		if instruction == "RUN" || instruction == "COPY" || instruction == "ADD" {
+			for _, field := range fields[1:] {
+				if dockerfileHeredoc.MatchString(field) {
+					return fmt.Sprintf("a heredoc in %s on line %d", instruction, start)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+var (
+	dockerfileDirective = regexp.MustCompile(`^#\s*([a-zA-Z]+)\s*=\s*(\S+)\s*$`)
+	dockerfileHeredoc   = regexp.MustCompile(`^<<-?(["']?)[A-Za-z_][A-Za-z0-9_]*(["']?)$`)
+)
+
+// classicBuilderFlags are the instruction flags supported by the classic builder
+var classicBuilderFlags = map[string]bool{
+	"--platform":     true,
+	"--from":         true,
+	"--chown":        true,
+	"--interval":     true,
+	"--timeout":      true,
+	"--start-period": true,
+	"--retries":      true,
+}
+
+// buildKitSession is the session of a BuildKit build. The daemon calls the gRPC methods of
+// the session over the hijacked /session endpoint, act only serves the health check and the
+// secrets of the build.
+type buildKitSession struct {
+	id      string
+	secrets map[string][]byte
+	conn    net.Conn
+}
+
+const (
+	buildKitHealthCheck = "/grpc.health.v1.Health/Check"
+	buildKitGetSecret   = "/moby.buildkit.secrets.v1.Secrets/GetSecret"
+)
+
+// startBuildKitSession starts the session of a BuildKit build, it provides the
+// secrets of the build to RUN --mount=type=secret without storing them in the image
+func startBuildKitSession(ctx context.Context, cli client.APIClient, secrets map[string][]byte) (*buildKitSession, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	s := &buildKitSession{id: hex.EncodeToString(id), secrets: secrets}
+
+	conn, err := cli.DialHijack(ctx, "/session", "h2c", map[string][]string{
+		"X-Docker-Expose-Session-Uuid":        {s.id},
+		"X-Docker-Expose-Session-Sharedkey":   {"act"},
+		"X-Docker-Expose-Session-Grpc-Method": {buildKitHealthCheck, buildKitGetSecret},
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.conn = conn
+	go func() {
+		(&http2.Server{}).ServeConn(conn, &http2.ServeConnOpts{Context: ctx, Handler: s})
+		common.Logger(ctx).Debugf("BuildKit session %s ended", s.id)
+	}()
+	return s, nil
+}
+
+// ID returns the id of the session for the build options
+func (s *buildKitSession) ID() string {
+	return s.id
+}
+
+// Close ends the session
+func (s *buildKitSession) Close() error {
+	return s.conn.Close()
+}
+
+// ServeHTTP handles a unary gRPC call of the daemon
+func (s *buildKitSession) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeGRPCResponse(w, nil, grpcInvalidArgument, err.Error())
+		return
+	}
+	// a message is prefixed with a compression flag and its length
+	if len(body) < 5 || body[0] != 0 || int(binary.BigEndian.Uint32(body[1:5])) != len(body)-5 {
+		writeGRPCResponse(w, nil, grpcInvalidArgument, "unable to read the request message")
+		return
+	}
+	request := body[5:]
+
+	switch r.URL.Path {
+	case buildKitHealthCheck:
+		// HealthCheckResponse{status: SERVING}
+		response := protowire.AppendTag(nil, 1, protowire.VarintType)
+		writeGRPCResponse(w, protowire.AppendVarint(response, 1), grpcOK, "")
+	case buildKitGetSecret:
+		var id string
+		err := protoFields(request, func(num protowire.Number, typ protowire.Type, value []byte, _ uint64) {
+			if num == 1 && typ == protowire.BytesType {
+				id = string(value)
+			}
+		})
+		if err != nil {
+			writeGRPCResponse(w, nil, grpcInvalidArgument, err.Error())
+			return
+		}
+		data, ok := s.secrets[id]
+		if !ok {
+			writeGRPCResponse(w, nil, grpcNotFound, fmt.Sprintf("secret %s not found", id))
+			return
+		}
+		response := protowire.AppendTag(nil, 1, protowire.BytesType)
+		writeGRPCResponse(w, protowire.AppendBytes(response, data), grpcOK, "")
+	default:
+		writeGRPCResponse(w, nil, grpcUnimplemented, fmt.Sprintf("unknown method %s", r.URL.Path))
+	}
+}
+
+// gRPC status codes returned by the session
+const (
+	grpcOK              = 0
+	grpcInvalidArgument = 3
+	grpcNotFound        = 5
+	grpcUnimplemented   = 12
+)
+
+// writeGRPCResponse writes the response message of a gRPC call, the message is omitted if
+// the status is not grpcOK
+func writeGRPCResponse(w http.ResponseWriter, message []byte, status int, errorMessage string) {
+	w.Header().Set("Content-Type", "application/grpc")
+	w.WriteHeader(http.StatusOK)
+	if status == grpcOK {
+		frame := make([]byte, 5, 5+len(message))
+		binary.BigEndian.PutUint32(frame[1:], uint32(len(message)))
+		_, _ = w.Write(append(frame, message...))
+	}
+	w.Header().Set(http.TrailerPrefix+"Grpc-Status", strconv.Itoa(status))
+	if errorMessage != "" {
+		w.Header().Set(http.TrailerPrefix+"Grpc-Message", url.PathEscape(errorMessage))
+	}
+}
+
+// protoFields calls fn with the number, type and value of each field of a protobuf message,
+// value holds the bytes of length-delimited fields and varint the value of varint fields
+func protoFields(b []byte, fn func(num protowire.Number, typ protowire.Type, value []byte, varint uint64)) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		var value []byte
+		var varint uint64
+		switch typ {
+		case protowire.BytesType:
+			value, n = protowire.ConsumeBytes(b)
+		case protowire.VarintType:
+			varint, n = protowire.ConsumeVarint(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		fn(num, typ, value, varint)
+	}
+	return nil
+}
+
+// buildKitVertex is the part of a Vertex of the BuildKit control API logged by act
+type buildKitVertex struct {
+	name      string
+	cached    bool
+	completed bool
+	err       string
+}
+
+// parseBuildKitStatus returns the vertexes and log messages of a StatusResponse of the BuildKit control API
+func parseBuildKitStatus(data []byte) ([]buildKitVertex, [][]byte, error) {
+	var vertexes []buildKitVertex
+	var logs [][]byte
+	var fieldErr error
+	err := protoFields(data, func(num protowire.Number, typ protowire.Type, value []byte, _ uint64) {
+		if typ != protowire.BytesType || fieldErr != nil {
+			return
+		}
+		switch num {
+		case 1:
+			vertex := buildKitVertex{}
+			fieldErr = protoFields(value, func(num protowire.Number, typ protowire.Type, value []byte, varint uint64) {
+				switch {
+				case num == 3 && typ == protowire.BytesType:
+					vertex.name = string(value)
+				case num == 4 && typ == protowire.VarintType:
+					vertex.cached = varint != 0
+				case num == 6 && typ == protowire.BytesType:
+					vertex.completed = true
+				case num == 7 && typ == protowire.BytesType:
+					vertex.err = string(value)
+				}
+			})
+			vertexes = append(vertexes, vertex)
+		case 3:
+			fieldErr = protoFields(value, func(num protowire.Number, typ protowire.Type, value []byte, _ uint64) {
+				if num == 4 && typ == protowire.BytesType {
+					logs = append(logs, value)
+				}
+			})
+		}
+	})
+	if err == nil {
+		err = fieldErr
+	}
+	return vertexes, logs, err
+}
+
+// logBuildKitTrace logs the steps and output of a BuildKit build
+func logBuildKitTrace(logger logrus.FieldLogger, aux json.RawMessage, isError bool) {
+	var data []byte
+	if err := json.Unmarshal(aux, &data); err != nil {
+		writeLog(logger, false, "Unable to unmarshal BuildKit trace: %v", err)
+		return
+	}
+	vertexes, logs, err := parseBuildKitStatus(data)
+	if err != nil {
+		writeLog(logger, false, "Unable to unmarshal BuildKit trace: %v", err)
+		return
+	}
+
+	for _, vertex := range vertexes {
+		if vertex.err != "" {
+			writeLog(logger, isError, "%s: %s", vertex.name, vertex.err)
+		} else if vertex.cached {
+			writeLog(logger, isError, "CACHED %s", vertex.name)
+		} else if vertex.completed {
+			writeLog(logger, isError, "DONE %s", vertex.name)
+		}
+	}
+	for _, msg := range logs {
+		writeLog(logger, isError, "%s", strings.TrimRight(string(msg), "\n"))
+	}
+}
@@ -0,0 +1,94 @@
+package container
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestLogBuildKitTrace(t *testing.T) {
+	message := func(b []byte, num protowire.Number, value []byte) []byte {
+		return protowire.AppendBytes(protowire.AppendTag(b, num, protowire.BytesType), value)
+	}
+	// StatusResponse{Vertexes: [{Name, Cached}, {Name, Completed}, {Name}], Logs: [{Msg}]}
+	cached := message(nil, 3, []byte("[1/2] FROM alpine"))
+	cached = protowire.AppendVarint(protowire.AppendTag(cached, 4, protowire.VarintType), 1)
+	completed := message(message(nil, 3, []byte("[2/2] RUN make")), 6, nil)
+	data := message(nil, 1, cached)
+	data = message(data, 1, completed)
+	data = message(data, 1, message(nil, 3, []byte("[2/2] RUN make")))
+	data = message(data, 3, message(nil, 4, []byte("compiling\n")))
+	aux, err := json.Marshal(data)
+	assert.NoError(t, err)
+	line, err := json.Marshal(map[string]interface{}{"id": buildKitTraceID, "aux": json.RawMessage(aux)})
+	assert.NoError(t, err)
+
+	logger, hook := test.NewNullLogger()
+	logger.SetLevel(logrus.DebugLevel)
+	assert.NoError(t, logDockerResponse(logger, io.NopCloser(strings.NewReader(string(line)+"\n")), false))
+
+	messages := []string{}
+	for _, entry := range hook.AllEntries() {
+		messages = append(messages, entry.Message)
+	}
+	assert.Equal(t, []string{"CACHED [1/2] FROM alpine", "DONE [2/2] RUN make", "compiling"}, messages)
+}
+
+func TestBuildKitFeature(t *testing.T) {
+	table := map[string]string{
+		"FROM alpine\nRUN make\n": "",
+		"FROM --platform=linux/amd64 alpine AS build\nCOPY --from=build --chown=1000 /a /b\n": "",
+		"# syntax=docker/dockerfile:1\nFROM alpine\n":                                         "the syntax directive 'docker/dockerfile:1'",
+		"FROM alpine\nRUN --mount=type=secret,id=token make\n":                                "RUN --mount on line 2",
+		"FROM alpine\nCOPY --chmod=755 run.sh /\n":                                            "COPY --chmod on line 2",
+		"FROM alpine\nRUN <<EOF\nmake\nEOF\n":                                                 "a heredoc in RUN on line 2",
+		"FROM alpine\n# build it\nRUN make \\\n  --mount=x && cat <<< a\n":                    "",
+		"# check=skip=all\n#syntax = docker/dockerfile:1\nFROM alpine\n":                      "the syntax directive 'docker/dockerfile:1'",
+		"FROM alpine\nCOPY \\\n  --chmod=755 run.sh /\n":                                      "COPY --chmod on line 2",
+	}
+	for dockerfile, feature := range table {
+		assert.Equal(t, feature, buildKitFeature([]byte(dockerfile)), dockerfile)
+	}
+}
+
+func TestBuildKitSession(t *testing.T) {
+	s := &buildKitSession{secrets: map[string][]byte{"token": []byte("secret")}}
+	call := func(method string, request []byte) *http.Response {
+		body := binary.BigEndian.AppendUint32([]byte{0}, uint32(len(request)))
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, httptest.NewRequest(http.MethodPost, method, bytes.NewReader(append(body, request...))))
+		return w.Result()
+	}
+	getSecret := func(id string) []byte {
+		return protowire.AppendString(protowire.AppendTag(nil, 1, protowire.BytesType), id)
+	}
+
+	response := call(buildKitGetSecret, getSecret("token"))
+	body, err := io.ReadAll(response.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "0", response.Trailer.Get("Grpc-Status"))
+	data := protowire.AppendBytes(protowire.AppendTag(nil, 1, protowire.BytesType), []byte("secret"))
+	assert.Equal(t, append([]byte{0, 0, 0, 0, byte(len(data))}, data...), body)
+
+	response = call(buildKitGetSecret, getSecret("missing"))
+	body, err = io.ReadAll(response.Body)
+	assert.NoError(t, err)
+	assert.Empty(t, body)
+	assert.Equal(t, "5", response.Trailer.Get("Grpc-Status"))
+
+	response = call(buildKitHealthCheck, nil)
+	assert.Equal(t, "0", response.Trailer.Get("Grpc-Status"))
+
+	response = call("/moby.filesync.v1.FileSync/DiffCopy", nil)
+	assert.Equal(t, "12", response.Trailer.Get("Grpc-Status"))
+}
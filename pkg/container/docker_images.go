@@ -5,6 +5,8 @@ package container
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	cerrdefs "github.com/containerd/errdefs"
 	"github.com/moby/moby/client"
@@ -39,6 +41,24 @@ func ImageExistsLocally(ctx context.Context, imageName string, platform string)
 	return false, nil
 }
 
+// ImageID returns the ID of the image in the local docker image store or an empty
+// string if the image does not exist
+func ImageID(ctx context.Context, imageName string) (string, error) {
+	cli, err := GetDockerClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer cli.Close()
+
+	inspectImage, err := cli.ImageInspect(ctx, imageName)
+	if cerrdefs.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	return inspectImage.ID, nil
+}
+
 // RemoveImage removes image from local store, the function is used to run different
 // container image architectures
 func RemoveImage(ctx context.Context, imageName string, force bool, pruneChildren bool) (bool, error) {
@@ -64,3 +84,35 @@ func RemoveImage(ctx context.Context, imageName string, force bool, pruneChildre
 
 	return true, nil
 }
+
+// RemoveOtherImageTags removes all tags of the repository except keep, images
+// without any remaining tag are deleted by docker
+func RemoveOtherImageTags(ctx context.Context, repository string, keep ...string) error {
+	cli, err := GetDockerClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+
+	images, err := cli.ImageList(ctx, client.ImageListOptions{
+		Filters: make(client.Filters).Add("reference", repository),
+	})
+	if err != nil {
+		return err
+	}
+
+	logger := common.Logger(ctx)
+	for _, image := range images.Items {
+		for _, tag := range image.RepoTags {
+			if slices.Contains(keep, tag) || !strings.HasPrefix(tag, repository+":") {
+				continue
+			}
+			if _, err := cli.ImageRemove(ctx, tag, client.ImageRemoveOptions{}); err != nil {
+				logger.Debugf("Unable to remove image %s: %v", tag, err)
+				continue
+			}
+			logger.Debugf("Removed image %s", tag)
+		}
+	}
+	return nil
+}
@@ -18,8 +18,9 @@ type dockerMessage struct {
 	ErrorDetail struct {
 		Message string
 	}
-	Status   string `json:"status"`
-	Progress string `json:"progress"`
+	Status   string          `json:"status"`
+	Progress string          `json:"progress"`
+	Aux      json.RawMessage `json:"aux"`
 }
 
 const logPrefix = "  \U0001F433  "
@@ -42,6 +43,7 @@ func logDockerResponse(logger logrus.FieldLogger, dockerResponse io.ReadCloser,
 		msg.ErrorDetail.Message = ""
 		msg.Status = ""
 		msg.Progress = ""
+		msg.Aux = nil
 
 		if err := json.Unmarshal(line, &msg); err != nil {
 			writeLog(logger, false, "Unable to unmarshal line [%s] ==> %v", string(line), err)
@@ -58,7 +60,9 @@ func logDockerResponse(logger logrus.FieldLogger, dockerResponse io.ReadCloser,
 			return errors.New(msg.Error)
 		}
 
-		if msg.Status != "" {
+		if msg.ID == buildKitTraceID && msg.Aux != nil {
+			logBuildKitTrace(logger, msg.Aux, isError)
+		} else if msg.Status != "" {
 			if msg.Progress != "" {
 				writeLog(logger, isError, "%s :: %s :: %s\n", msg.Status, msg.ID, msg.Progress)
 			} else {
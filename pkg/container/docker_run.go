@@ -20,6 +20,7 @@ import (
 
 	"dario.cat/mergo"
 	"github.com/Masterminds/semver"
+	cerrdefs "github.com/containerd/errdefs"
 	"github.com/docker/cli/cli/connhelper"
 	"github.com/docker/go-connections/nat"
 	"github.com/go-git/go-billy/v5/helper/polyfill"
@@ -48,6 +49,49 @@ func NewContainer(input *NewContainerInput) ExecutionsEnvironment {
 	return cr
 }
 
+// ListContainerNames returns the names of all containers, which start with the prefix
+func ListContainerNames(ctx context.Context, prefix string) ([]string, error) {
+	cli, err := GetDockerClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer cli.Close()
+
+	result, err := cli.ContainerList(ctx, client.ContainerListOptions{
+		All: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+	names := []string{}
+	for _, c := range result.Items {
+		for _, name := range c.Names {
+			if name = strings.TrimPrefix(name, "/"); strings.HasPrefix(name, prefix) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names, nil
+}
+
+// ContainerImageID returns the ID of the image of the container or an empty string if
+// the container does not exist
+func ContainerImageID(ctx context.Context, name string) (string, error) {
+	cli, err := GetDockerClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer cli.Close()
+
+	inspectResult, err := cli.ContainerInspect(ctx, name, client.ContainerInspectOptions{})
+	if cerrdefs.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("failed to inspect container: %w", err)
+	}
+	return inspectResult.Container.Image, nil
+}
+
 // supportsContainerImagePlatform returns true if the underlying Docker server
 // API version is 1.41 and beyond
 func supportsContainerImagePlatform(ctx context.Context, cli client.APIClient) bool {
@@ -123,6 +167,10 @@ func (cr *containerReference) Copy(destPath string, files ...*FileEntry) common.
 	).IfNot(common.Dryrun)
 }
 
+// CopyDir copies the files of srcPath to destPath. A reused container only receives the files
+// added or changed since the previous copy and the removed files are deleted. Copied files the
+// jobs changed in the container, which are newer than the manifest, are copied from the host
+// again, only the files the jobs created themselves are kept.
 func (cr *containerReference) CopyDir(destPath string, srcPath string, useGitIgnore bool) common.Executor {
 	return common.NewPipelineExecutor(
 		common.NewInfoExecutor("%sdocker cp src=%s dst=%s", logPrefix, srcPath, destPath),
@@ -145,6 +193,52 @@ func (cr *containerReference) GetContainerArchive(ctx context.Context, srcPath s
 	return result.Content, err
 }
 
+// Commit creates the image from the current filesystem of the container, volumes are not part of the image
+func (cr *containerReference) Commit(image string) common.Executor {
+	return common.
+		NewDebugExecutor("%sdocker commit image=%s", logPrefix, image).
+		Then(
+			common.NewPipelineExecutor(
+				cr.connect(),
+				cr.find(),
+				cr.commit(image),
+			).IfNot(common.Dryrun),
+		)
+}
+
+// InspectService returns the id, the network of the network mode and the published ports of the container
+func (cr *containerReference) InspectService(ctx context.Context) (*ServiceContext, error) {
+	if err := common.NewPipelineExecutor(cr.connect(), cr.find())(ctx); err != nil {
+		return nil, err
+	}
+	if cr.id == "" {
+		return nil, fmt.Errorf("container %s not found", cr.input.Name)
+	}
+	inspectResult, err := cr.cli.ContainerInspect(ctx, cr.id, client.ContainerInspectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect container: %w", err)
+	}
+	service := &ServiceContext{ID: inspectResult.Container.ID, Ports: map[string]string{}}
+	settings := inspectResult.Container.NetworkSettings
+	if settings == nil {
+		return service, nil
+	}
+	if endpoint := settings.Networks[cr.input.NetworkMode]; endpoint != nil {
+		service.Network = endpoint.NetworkID
+	}
+	for port, bindings := range settings.Ports {
+		if len(bindings) == 0 {
+			continue
+		}
+		key := strconv.Itoa(int(port.Num()))
+		if port.Proto() != network.TCP {
+			key += "/" + string(port.Proto())
+		}
+		service.Ports[key] = bindings[0].HostPort
+	}
+	return service, nil
+}
+
 func (cr *containerReference) UpdateFromEnv(srcPath string, env *map[string]string) common.Executor {
 	return parseEnvFile(cr, srcPath, env).IfNot(common.Dryrun)
 }
@@ -330,6 +424,22 @@ func (cr *containerReference) find() common.Executor {
 	}
 }
 
+func (cr *containerReference) commit(image string) common.Executor {
+	return func(ctx context.Context) error {
+		if cr.id == "" {
+			return fmt.Errorf("unable to commit the container '%s', it does not exist", cr.input.Name)
+		}
+		_, err := cr.cli.ContainerCommit(ctx, cr.id, client.ContainerCommitOptions{
+			Reference: image,
+			Comment:   "act checkpoint",
+		})
+		if err != nil {
+			return fmt.Errorf("failed to commit container: %w", err)
+		}
+		return nil
+	}
+}
+
 func (cr *containerReference) remove() common.Executor {
 	return func(ctx context.Context) error {
 		if cr.id == "" {
@@ -609,14 +719,7 @@ func (cr *containerReference) exec(cmd []string, env map[string]string, user, wo
 			return fmt.Errorf("failed to inspect exec: %w", err)
 		}
 
-		switch inspectResp.ExitCode {
-		case 0:
-			return nil
-		case 127:
-			return fmt.Errorf("exitcode '%d': command not found, please refer to https://github.com/nektos/act/issues/107 for more information", inspectResp.ExitCode)
-		default:
-			return fmt.Errorf("exitcode '%d': failure", inspectResp.ExitCode)
-		}
+		return execExitError(inspectResp.ExitCode)
 	}
 }
 
@@ -772,11 +875,14 @@ func (cr *containerReference) copyDir(dstPath string, srcPath string, useGitIgno
 			ignorer = gitignore.NewMatcher(ps)
 		}
 
-		fc := &filecollector.FileCollector{
-			Fs:        &filecollector.DefaultFs{},
-			Ignorer:   ignorer,
-			SrcPath:   srcPath,
-			SrcPrefix: srcPrefix,
+		manifest := &syncManifest{
+			Source:       srcPath,
+			UseGitIgnore: useGitIgnore,
+			UID:          cr.UID,
+			GID:          cr.GID,
+		}
+		sc := &filecollector.SyncCollector{
+			Previous: cr.verifySyncedFiles(ctx, dstPath, cr.readSyncManifest(ctx, dstPath, manifest)),
 			Handler: &filecollector.TarCollector{
 				TarWriter: tw,
 				UID:       cr.UID,
@@ -784,6 +890,13 @@ func (cr *containerReference) copyDir(dstPath string, srcPath string, useGitIgno
 				DstDir:    dstPath[1:],
 			},
 		}
+		fc := &filecollector.FileCollector{
+			Fs:        &filecollector.DefaultFs{},
+			Ignorer:   ignorer,
+			SrcPath:   srcPath,
+			SrcPrefix: srcPrefix,
+			Handler:   sc,
+		}
 
 		err = filepath.Walk(srcPath, fc.CollectFiles(ctx, []string{}))
 		if err != nil {
@@ -793,14 +906,32 @@ func (cr *containerReference) copyDir(dstPath string, srcPath string, useGitIgno
 			return err
 		}
 
-		logger.Debugf("Extracting content from '%s' to '%s'", tarFile.Name(), dstPath)
-		_, err = tarFile.Seek(0, 0)
-		if err != nil {
-			return fmt.Errorf("failed to seek tar archive: %w", err)
+		removed := sc.Removed()
+		if sc.Previous != nil {
+			logger.Debugf("Copying %d changed files to '%s', %d unchanged, %d removed", sc.Changed, dstPath, len(sc.Current)-sc.Changed, len(removed))
 		}
-		_, err = cr.cli.CopyToContainer(ctx, cr.id, client.CopyToContainerOptions{DestinationPath: "/", Content: tarFile})
-		if err != nil {
-			return fmt.Errorf("failed to copy content to container: %w", err)
+		if sc.Changed > 0 || sc.Previous == nil {
+			logger.Debugf("Extracting content from '%s' to '%s'", tarFile.Name(), dstPath)
+			_, err = tarFile.Seek(0, 0)
+			if err != nil {
+				return fmt.Errorf("failed to seek tar archive: %w", err)
+			}
+			_, err = cr.cli.CopyToContainer(ctx, cr.id, client.CopyToContainerOptions{DestinationPath: "/", Content: tarFile})
+			if err != nil {
+				return fmt.Errorf("failed to copy content to container: %w", err)
+			}
+		}
+		if err := cr.removeSyncedFiles(ctx, dstPath, removed); err != nil {
+			return fmt.Errorf("failed to remove deleted files from container: %w", err)
+		}
+		if err := cr.removeSyncedDirs(ctx, dstPath, sc.RemovedDirs()); err != nil {
+			return fmt.Errorf("failed to remove deleted directories from container: %w", err)
+		}
+
+		manifest.Files = sc.Current
+		if err := cr.writeSyncManifest(ctx, dstPath, manifest); err != nil {
+			// the next copy copies all files again
+			logger.Debugf("Unable to write the manifest of '%s': %v", dstPath, err)
 		}
 		return nil
 	}
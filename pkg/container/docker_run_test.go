@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"net"
@@ -11,8 +12,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/moby/moby/api/pkg/stdcopy"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/network"
 	"github.com/moby/moby/client"
 	"github.com/nektos/act/pkg/common"
+	"github.com/nektos/act/pkg/filecollector"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -77,6 +82,11 @@ func (m *mockDockerClient) ExecInspect(ctx context.Context, execID string, opts
 	return args.Get(0).(client.ExecInspectResult), args.Error(1)
 }
 
+func (m *mockDockerClient) ContainerInspect(ctx context.Context, id string, opts client.ContainerInspectOptions) (client.ContainerInspectResult, error) {
+	args := m.Called(ctx, id, opts)
+	return args.Get(0).(client.ContainerInspectResult), args.Error(1)
+}
+
 func (m *mockDockerClient) CopyToContainer(ctx context.Context, id string, options client.CopyToContainerOptions) (client.CopyToContainerResult, error) {
 	args := m.Called(ctx, id, options.DestinationPath, options.Content, options)
 	return client.CopyToContainerResult{}, args.Error(0)
@@ -272,3 +282,74 @@ func TestDockerCopyTarStreamErrorInMkdir(t *testing.T) {
 
 // Type assert containerReference implements ExecutionsEnvironment
 var _ ExecutionsEnvironment = &containerReference{}
+
+func TestVerifySyncedFiles(t *testing.T) {
+	ctx := context.Background()
+	cli := &mockDockerClient{}
+	cr := &containerReference{
+		id:    "123",
+		cli:   cli,
+		input: &NewContainerInput{Image: "image"},
+	}
+	output := func(id string, cmd []string, stdout string) {
+		cli.On("ExecCreate", ctx, "123", mock.MatchedBy(func(opts client.ExecCreateOptions) bool {
+			return strings.Join(opts.Cmd, " ") == strings.Join(cmd, " ")
+		})).Return(client.ExecCreateResult{ID: id}, nil)
+		// a frame of the multiplexed exec output, see stdcopy
+		buf := bytes.NewBuffer([]byte{byte(stdcopy.Stdout), 0, 0, 0})
+		_ = binary.Write(buf, binary.BigEndian, uint32(len(stdout)))
+		buf.WriteString(stdout)
+		cli.On("ExecAttach", ctx, id, mock.Anything).Return(client.ExecAttachResult{
+			HijackedResponse: client.HijackedResponse{Conn: &mockConn{}, Reader: bufio.NewReader(buf)},
+		}, nil)
+		cli.On("ExecInspect", ctx, id, mock.Anything).Return(client.ExecInspectResult{ExitCode: 0}, nil)
+	}
+	output("find", []string{"find", "/work"}, "/work\n/work/src\n/work/src/unchanged.go\n/work/src/edited.go\n/work/created.txt\n")
+	output("newer", []string{"find", "/work", "-newer", cr.syncManifestPath("/work")}, "/work/src\n/work/src/edited.go\n/work/created.txt\n")
+
+	previous := map[string]filecollector.ManifestEntry{
+		"src/unchanged.go": {Size: 1},
+		"src/edited.go":    {Size: 2},
+		"src/removed.go":   {Size: 3},
+	}
+	assert.Equal(t, map[string]filecollector.ManifestEntry{
+		"src/unchanged.go": {Size: 1},
+	}, cr.verifySyncedFiles(ctx, "/work", previous), "the files edited or removed by jobs are copied again")
+	assert.Nil(t, cr.verifySyncedFiles(ctx, "/work", nil))
+	cli.AssertExpectations(t)
+}
+
+func TestDockerInspectService(t *testing.T) {
+	ctx := context.Background()
+	cli := &mockDockerClient{}
+	cli.On("ContainerInspect", ctx, "123", client.ContainerInspectOptions{}).Return(client.ContainerInspectResult{
+		Container: container.InspectResponse{
+			ID: "123",
+			NetworkSettings: &container.NetworkSettings{
+				Networks: map[string]*network.EndpointSettings{
+					"act-job-network": {NetworkID: "net-1"},
+					"bridge":          {NetworkID: "net-2"},
+				},
+				Ports: network.PortMap{
+					network.MustParsePort("5432/tcp"): {{HostPort: "49153"}},
+					network.MustParsePort("53/udp"):   {{HostPort: "49154"}},
+					network.MustParsePort("80/tcp"):   nil,
+				},
+			},
+		},
+	}, nil)
+
+	cr := &containerReference{
+		id:    "123",
+		cli:   cli,
+		input: &NewContainerInput{Name: "postgres", NetworkMode: "act-job-network"},
+	}
+	service, err := cr.InspectService(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, &ServiceContext{
+		ID:      "123",
+		Network: "net-1",
+		Ports:   map[string]string{"5432": "49153", "53/udp": "49154"},
+	}, service)
+	cli.AssertExpectations(t)
+}
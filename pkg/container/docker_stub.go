@@ -4,6 +4,7 @@ package container
 
 import (
 	"context"
+	"io"
 	"runtime"
 
 	"github.com/moby/moby/api/types/system"
@@ -17,12 +18,39 @@ func ImageExistsLocally(ctx context.Context, imageName string, platform string)
 	return false, errors.New("Unsupported Operation")
 }
 
+// ImageID returns the ID of the image in the local docker image store or an empty
+// string if the image does not exist
+func ImageID(ctx context.Context, imageName string) (string, error) {
+	return "", errors.New("Unsupported Operation")
+}
+
 // RemoveImage removes image from local store, the function is used to run different
 // container image architectures
 func RemoveImage(ctx context.Context, imageName string, force bool, pruneChildren bool) (bool, error) {
 	return false, errors.New("Unsupported Operation")
 }
 
+// RemoveOtherImageTags removes all tags of the repository except keep
+func RemoveOtherImageTags(ctx context.Context, repository string, keep ...string) error {
+	return errors.New("Unsupported Operation")
+}
+
+// ListContainerNames returns the names of all containers, which start with the prefix
+func ListContainerNames(ctx context.Context, prefix string) ([]string, error) {
+	return nil, errors.New("Unsupported Operation")
+}
+
+// ContainerImageID returns the ID of the image of the container or an empty string if
+// the container does not exist
+func ContainerImageID(ctx context.Context, name string) (string, error) {
+	return "", errors.New("Unsupported Operation")
+}
+
+// NewBuildContextArchive returns the build context of the directory
+func NewBuildContextArchive(ctx context.Context, contextDir string, relDockerfile string) (io.ReadCloser, error) {
+	return nil, errors.New("Unsupported Operation")
+}
+
 // NewDockerBuildExecutor function to create a run executor for the container
 func NewDockerBuildExecutor(input NewDockerBuildExecutorInput) common.Executor {
 	return func(ctx context.Context) error {
@@ -56,6 +84,12 @@ func NewDockerVolumeRemoveExecutor(volume string, force bool) common.Executor {
 	}
 }
 
+func NewDockerVolumeCreateExecutor(volumeName string, driverOpts map[string]string) common.Executor {
+	return func(ctx context.Context) error {
+		return nil
+	}
+}
+
 func NewDockerNetworkCreateExecutor(name string) common.Executor {
 	return func(ctx context.Context) error {
 		return nil
@@ -0,0 +1,184 @@
+//go:build !(WITHOUT_DOCKER || !(linux || darwin || windows || netbsd))
+
+package container
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/moby/moby/api/pkg/stdcopy"
+	"github.com/moby/moby/client"
+
+	"github.com/nektos/act/pkg/common"
+	"github.com/nektos/act/pkg/filecollector"
+)
+
+// removeBatchSize is the number of files removed by one rm command
+const removeBatchSize = 500
+
+// syncManifest is the state of the files CopyDir copied to a directory of the
+// container. It is stored in the act path of the container, so a reused container
+// only receives the files added or changed since the previous copy.
+type syncManifest struct {
+	Source       string                                 `json:"source"`
+	UseGitIgnore bool                                   `json:"use-gitignore"`
+	UID          int                                    `json:"uid"`
+	GID          int                                    `json:"gid"`
+	Files        map[string]filecollector.ManifestEntry `json:"files"`
+}
+
+// syncManifestPath returns the path of the manifest of the destination directory in the container
+func (cr *containerReference) syncManifestPath(dstPath string) string {
+	digest := sha256.Sum256([]byte(dstPath))
+	return path.Join(cr.GetActPath(), "sync", hex.EncodeToString(digest[:8])+".json")
+}
+
+// readSyncManifest returns the files of the previous copy of srcPath to dstPath, or nil
+// if the directory was not copied before or with other options
+func (cr *containerReference) readSyncManifest(ctx context.Context, dstPath string, manifest *syncManifest) map[string]filecollector.ManifestEntry {
+	logger := common.Logger(ctx)
+	archive, err := cr.GetContainerArchive(ctx, cr.syncManifestPath(dstPath))
+	if err != nil {
+		logger.Debugf("No previous copy of '%s': %v", dstPath, err)
+		return nil
+	}
+	defer archive.Close()
+
+	tr := tar.NewReader(archive)
+	if _, err := tr.Next(); err != nil {
+		logger.Debugf("Unable to read the manifest of '%s': %v", dstPath, err)
+		return nil
+	}
+	previous := &syncManifest{}
+	if err := json.NewDecoder(tr).Decode(previous); err != nil {
+		logger.Debugf("Unable to read the manifest of '%s': %v", dstPath, err)
+		return nil
+	}
+	if previous.Source != manifest.Source || previous.UseGitIgnore != manifest.UseGitIgnore || previous.UID != manifest.UID || previous.GID != manifest.GID {
+		logger.Debugf("Copying all files to '%s', the options of the previous copy differ", dstPath)
+		return nil
+	}
+	return previous.Files
+}
+
+// writeSyncManifest stores the manifest of the copy to dstPath in the container, the
+// modification time of the manifest is the time of the copy in the container
+func (cr *containerReference) writeSyncManifest(ctx context.Context, dstPath string, manifest *syncManifest) error {
+	content, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	manifestPath := cr.syncManifestPath(dstPath)
+	return cr.copyContent(path.Dir(path.Dir(manifestPath)), &FileEntry{
+		Name: path.Join(path.Base(path.Dir(manifestPath)), path.Base(manifestPath)),
+		Mode: 0o644,
+		Body: string(content),
+	}).Then(cr.exec([]string{"touch", "--", manifestPath}, nil, "0", ""))(ctx)
+}
+
+// verifySyncedFiles returns the files of the previous copy, which still exist unchanged in the
+// container. The files the jobs changed or removed since the manifest was written are left out,
+// so they are copied again.
+func (cr *containerReference) verifySyncedFiles(ctx context.Context, dstPath string, previous map[string]filecollector.ManifestEntry) map[string]filecollector.ManifestEntry {
+	if previous == nil {
+		return nil
+	}
+	logger := common.Logger(ctx)
+	existing, err := cr.execOutput(ctx, []string{"find", dstPath})
+	if err != nil {
+		logger.Debugf("Copying all files to '%s', unable to list the files of the previous copy: %v", dstPath, err)
+		return nil
+	}
+	changed, err := cr.execOutput(ctx, []string{"find", dstPath, "-newer", cr.syncManifestPath(dstPath)})
+	if err != nil {
+		logger.Debugf("Copying all files to '%s', unable to list the files changed since the previous copy: %v", dstPath, err)
+		return nil
+	}
+	exists := map[string]bool{}
+	for _, file := range strings.Split(existing, "\n") {
+		exists[path.Clean(file)] = true
+	}
+	for _, file := range strings.Split(changed, "\n") {
+		exists[path.Clean(file)] = false
+	}
+
+	verified := make(map[string]filecollector.ManifestEntry, len(previous))
+	for fpath, entry := range previous {
+		if exists[path.Join(dstPath, fpath)] {
+			verified[fpath] = entry
+		}
+	}
+	if len(verified) < len(previous) {
+		logger.Debugf("Copying %d files changed or removed in '%s' since the previous copy again", len(previous)-len(verified), dstPath)
+	}
+	return verified
+}
+
+// execOutput runs the command as root in the container and returns its output
+func (cr *containerReference) execOutput(ctx context.Context, cmd []string) (string, error) {
+	idResp, err := cr.cli.ExecCreate(ctx, cr.id, client.ExecCreateOptions{
+		User:         "0",
+		Cmd:          cmd,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create exec: %w", err)
+	}
+	resp, err := cr.cli.ExecAttach(ctx, idResp.ID, client.ExecAttachOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to attach to exec: %w", err)
+	}
+	defer resp.Close()
+
+	stdout, stderr := &strings.Builder{}, &strings.Builder{}
+	if _, err := stdcopy.StdCopy(stdout, stderr, resp.Reader); err != nil {
+		return "", err
+	}
+	inspectResp, err := cr.cli.ExecInspect(ctx, idResp.ID, client.ExecInspectOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect exec: %w", err)
+	}
+	if inspectResp.ExitCode != 0 {
+		return "", fmt.Errorf("exitcode '%d': %s", inspectResp.ExitCode, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// removeSyncedFiles removes the files of the previous copy, which no longer exist in the source directory
+func (cr *containerReference) removeSyncedFiles(ctx context.Context, dstPath string, removed []string) error {
+	for start := 0; start < len(removed); start += removeBatchSize {
+		end := min(start+removeBatchSize, len(removed))
+		cmd := []string{"rm", "-f", "--"}
+		for _, file := range removed[start:end] {
+			cmd = append(cmd, path.Join(dstPath, file))
+		}
+		if err := cr.exec(cmd, nil, "0", "")(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeSyncedDirs removes the directories of the previous copy, which are empty after the
+// removed files are gone. Directories holding files created by the jobs are kept.
+func (cr *containerReference) removeSyncedDirs(ctx context.Context, dstPath string, removed []string) error {
+	for start := 0; start < len(removed); start += removeBatchSize {
+		end := min(start+removeBatchSize, len(removed))
+		cmd := []string{"find"}
+		for _, dir := range removed[start:end] {
+			cmd = append(cmd, path.Join(dstPath, dir))
+		}
+		cmd = append(cmd, "-depth", "-type", "d", "-empty", "-delete")
+		if err := cr.exec(cmd, nil, "0", "")(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
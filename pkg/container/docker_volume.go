@@ -52,3 +52,29 @@ func removeExecutor(volume string, force bool) common.Executor {
 		return err
 	}
 }
+
+// NewDockerVolumeCreateExecutor creates a volume of the local driver with the
+// driver options, an existing volume of the same name is used as it is
+func NewDockerVolumeCreateExecutor(volumeName string, driverOpts map[string]string) common.Executor {
+	return func(ctx context.Context) error {
+		logger := common.Logger(ctx)
+		logger.Debugf("%sdocker volume create %s %v", logPrefix, volumeName, driverOpts)
+
+		if common.Dryrun(ctx) {
+			return nil
+		}
+
+		cli, err := GetDockerClient(ctx)
+		if err != nil {
+			return err
+		}
+		defer cli.Close()
+
+		_, err = cli.VolumeCreate(ctx, client.VolumeCreateOptions{
+			Name:       volumeName,
+			Driver:     "local",
+			DriverOpts: driverOpts,
+		})
+		return err
+	}
+}
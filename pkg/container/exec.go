@@ -0,0 +1,77 @@
+package container
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kballard/go-shellquote"
+)
+
+// execExitError returns the error of a command of Exec exited with the exit code like docker exec
+func execExitError(exitCode int) error {
+	switch exitCode {
+	case 0:
+		return nil
+	case 127:
+		return fmt.Errorf("exitcode '%d': command not found, please refer to https://github.com/nektos/act/issues/107 for more information", exitCode)
+	default:
+		return fmt.Errorf("exitcode '%d': failure", exitCode)
+	}
+}
+
+// checkExecUser returns an error if a backend is unable to run commands as the user of
+// Exec, users are the users it runs commands as in addition to the default user ""
+func checkExecUser(backend, user string, users ...string) error {
+	if user == "" {
+		return nil
+	}
+	for _, u := range users {
+		if user == u {
+			return nil
+		}
+	}
+	return fmt.Errorf("running commands as user %s is not supported by the %s backend", user, backend)
+}
+
+// newExecArchive returns the tar archive written by run to stdout in the background.
+// The first block is read before returning to report missing files like docker.
+func newExecArchive(run func(stdout io.Writer) error) (io.ReadCloser, error) {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(run(pw))
+	}()
+	reader := bufio.NewReader(pr)
+	if _, err := reader.Peek(1); err != nil && !errors.Is(err, io.EOF) {
+		pr.Close()
+		return nil, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{reader, pr}, nil
+}
+
+var execEnvName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// execEnvScript returns a shell script exporting the env of Exec. Backends source it from
+// stdin or a private file to keep the values, like secrets, out of the command line.
+// Names a shell is unable to export are skipped.
+func execEnvScript(env map[string]string) []byte {
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		if execEnvName.MatchString(k) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	script := &strings.Builder{}
+	for _, k := range keys {
+		fmt.Fprintf(script, "export %s=%s\n", k, shellquote.Join(env[k]))
+	}
+	return []byte(script.String())
+}
@@ -0,0 +1,16 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecEnvScript(t *testing.T) {
+	script := execEnvScript(map[string]string{
+		"TOKEN":     "it's $secret",
+		"EMPTY":     "",
+		"not-a-var": "skipped",
+	})
+	assert.Equal(t, "export EMPTY=''\nexport TOKEN='it'\\''s $secret'\n", string(script))
+}
@@ -0,0 +1,645 @@
+package container
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/distribution/reference"
+	"github.com/docker/go-connections/nat"
+
+	"github.com/nektos/act/pkg/common"
+)
+
+// BackendHooks runs the job container with the container hooks of the GitHub runner, the script
+// of the hooks is read from ACTIONS_RUNNER_CONTAINER_HOOKS like the runner does
+const BackendHooks = "hooks"
+
+const hooksLogPrefix = "  \U0001FA9D  "
+
+// the commands of the container hooks
+const (
+	hooksPrepareJob       = "prepare_job"
+	hooksRunScriptStep    = "run_script_step"
+	hooksRunContainerStep = "run_container_step"
+	hooksCleanupJob       = "cleanup_job"
+	hooksEnvironmentVar   = "ACTIONS_RUNNER_CONTAINER_HOOKS"
+)
+
+type hooksRequest struct {
+	Command      string                 `json:"command"`
+	ResponseFile string                 `json:"responseFile"`
+	State        map[string]interface{} `json:"state"`
+	Args         interface{}            `json:"args"`
+}
+
+type hooksMount struct {
+	SourceVolumePath string `json:"sourceVolumePath"`
+	TargetVolumePath string `json:"targetVolumePath"`
+	ReadOnly         bool   `json:"readOnly"`
+}
+
+type hooksRegistry struct {
+	Username  string `json:"username,omitempty"`
+	Password  string `json:"password,omitempty"`
+	ServerURL string `json:"serverUrl"`
+}
+
+type hooksContainer struct {
+	ContextName          string            `json:"contextName,omitempty"`
+	Image                string            `json:"image,omitempty"`
+	Dockerfile           string            `json:"dockerfile,omitempty"` // the Dockerfile of the image built by run_container_step
+	EntryPoint           string            `json:"entryPoint,omitempty"`
+	EntryPointArgs       []string          `json:"entryPointArgs,omitempty"`
+	WorkingDirectory     string            `json:"workingDirectory,omitempty"`
+	CreateOptions        string            `json:"createOptions,omitempty"`
+	EnvironmentVariables map[string]string `json:"environmentVariables"`
+	UserMountVolumes     []hooksMount      `json:"userMountVolumes"`
+	SystemMountVolumes   []hooksMount      `json:"systemMountVolumes,omitempty"`
+	Registry             *hooksRegistry    `json:"registry,omitempty"`
+	PortMappings         []string          `json:"portMappings"`
+}
+
+type hooksPrepareJobArgs struct {
+	Container *hooksContainer   `json:"container"`
+	Services  []*hooksContainer `json:"services"`
+}
+
+type hooksRunScriptStepArgs struct {
+	EntryPoint           string            `json:"entryPoint"`
+	EntryPointArgs       []string          `json:"entryPointArgs"`
+	EnvironmentVariables map[string]string `json:"environmentVariables"`
+	PrependPath          []string          `json:"prependPath"`
+	WorkingDirectory     string            `json:"workingDirectory"`
+}
+
+type hooksResponse struct {
+	State   map[string]interface{} `json:"state"`
+	Context map[string]interface{} `json:"context"`
+	Alpine  bool                   `json:"isAlpine"`
+}
+
+// hooksEnvironment runs the job container with the prepare_job, run_script_step and cleanup_job
+// hooks of the GitHub runner and the containers of docker steps with run_container_step. Files are shared with the job container by mounting directories of
+// the job on the host like the runner shares its work directory, act reads and writes the files
+// of the job container in these directories.
+type hooksEnvironment struct {
+	LinuxContainerEnvironmentExtensions
+	input    *BackendInput
+	hook     string
+	dir      string
+	mounts   []hooksMount // the host directories shared with the job container
+	state    map[string]interface{}
+	prepared bool
+	stdout   io.Writer
+	stderr   io.Writer
+}
+
+func newHooksEnvironment(_ context.Context, input *BackendInput) (ExecutionsEnvironment, error) {
+	hook := os.Getenv(hooksEnvironmentVar)
+	if hook == "" {
+		return nil, fmt.Errorf("%s is not set, it must point to the script of the container hooks", hooksEnvironmentVar)
+	}
+	if input.Image == "" {
+		return nil, errors.New("the hooks backend requires an image for the job container")
+	}
+	e := &hooksEnvironment{
+		input:  input,
+		hook:   hook,
+		dir:    filepath.Join(input.CacheDir, "hooks", input.Name),
+		stdout: input.Stdout,
+		stderr: input.Stderr,
+	}
+	e.mounts = []hooksMount{
+		{SourceVolumePath: filepath.Join(e.dir, "workspace"), TargetVolumePath: input.WorkingDir},
+		{SourceVolumePath: filepath.Join(e.dir, "act"), TargetVolumePath: e.GetActPath()},
+	}
+	// binds of the host like --bind are shared as they are
+	for _, bind := range input.Binds {
+		source, target, _ := strings.Cut(bind, ":")
+		target, mode, _ := strings.Cut(target, ":")
+		if target != "" && filepath.IsAbs(source) && !e.isShared(target) {
+			e.mounts = append(e.mounts, hooksMount{SourceVolumePath: source, TargetVolumePath: target, ReadOnly: mode == "ro"})
+		}
+	}
+	return e, nil
+}
+
+// isShared returns true if the path of the job container is a shared directory
+func (e *hooksEnvironment) isShared(target string) bool {
+	for _, m := range e.mounts {
+		if m.TargetVolumePath == target {
+			return true
+		}
+	}
+	return false
+}
+
+// hostPath returns the path on the host of a path of the job container
+func (e *hooksEnvironment) hostPath(containerPath string) (string, error) {
+	containerPath = path.Clean(containerPath)
+	for _, m := range e.mounts {
+		if containerPath == m.TargetVolumePath {
+			return m.SourceVolumePath, nil
+		}
+		if rel, ok := strings.CutPrefix(containerPath, strings.TrimSuffix(m.TargetVolumePath, "/")+"/"); ok {
+			return filepath.Join(m.SourceVolumePath, filepath.FromSlash(rel)), nil
+		}
+	}
+	return "", fmt.Errorf("the path %s of the job container is not shared with the host", containerPath)
+}
+
+// hooksPortMappings returns the ports of the input in the format of docker run -p
+func hooksPortMappings(input *NewContainerInput) []string {
+	mappings := []string{}
+	for port := range input.ExposedPorts {
+		bindings := input.PortBindings[port]
+		if len(bindings) == 0 {
+			mappings = append(mappings, string(port))
+		}
+		for _, binding := range bindings {
+			mappings = append(mappings, hooksPortMapping(port, binding))
+		}
+	}
+	sort.Strings(mappings)
+	return mappings
+}
+
+func hooksPortMapping(port nat.Port, binding nat.PortBinding) string {
+	mapping := string(port)
+	if binding.HostPort != "" {
+		mapping = binding.HostPort + ":" + mapping
+	}
+	if binding.HostIP != "" {
+		mapping = binding.HostIP + ":" + mapping
+	}
+	return mapping
+}
+
+// hooksContainerOf returns the container of the hooks of the input, named volumes are user mounts
+func hooksContainerOf(input *NewContainerInput) *hooksContainer {
+	c := &hooksContainer{
+		Image:                input.Image,
+		CreateOptions:        input.Options,
+		EnvironmentVariables: map[string]string{},
+		UserMountVolumes:     []hooksMount{},
+		PortMappings:         hooksPortMappings(input),
+	}
+	for _, kv := range input.Env {
+		k, v, _ := strings.Cut(kv, "=")
+		c.EnvironmentVariables[k] = v
+	}
+	names := make([]string, 0, len(input.Mounts))
+	for name := range input.Mounts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		c.UserMountVolumes = append(c.UserMountVolumes, hooksMount{SourceVolumePath: name, TargetVolumePath: input.Mounts[name]})
+	}
+	for _, bind := range input.Binds {
+		source, target, _ := strings.Cut(bind, ":")
+		target, mode, _ := strings.Cut(target, ":")
+		if target != "" {
+			c.UserMountVolumes = append(c.UserMountVolumes, hooksMount{SourceVolumePath: source, TargetVolumePath: target, ReadOnly: mode == "ro"})
+		}
+	}
+	if input.Username != "" || input.Password != "" {
+		c.Registry = &hooksRegistry{Username: input.Username, Password: input.Password, ServerURL: "https://index.docker.io/v1/"}
+		if named, err := reference.ParseNormalizedNamed(input.Image); err == nil && reference.Domain(named) != "docker.io" {
+			c.Registry.ServerURL = reference.Domain(named)
+		}
+	}
+	return c
+}
+
+// jobContainerOf returns the container of the hooks of the job container or of a step
+// container, they share the directories of the job
+func (e *hooksEnvironment) jobContainerOf(input NewContainerInput) *hooksContainer {
+	mounts := map[string]string{}
+	for name, target := range input.Mounts {
+		// the workspace and the act path are shared directories
+		if !e.isShared(target) {
+			mounts[name] = target
+		}
+	}
+	input.Mounts = mounts
+	// binds of the job are shared directories
+	input.Binds = nil
+	c := hooksContainerOf(&input)
+	c.SystemMountVolumes = e.mounts
+	return c
+}
+
+// invoke runs the hook script with the request on stdin and returns the response file
+func (e *hooksEnvironment) invoke(ctx context.Context, command string, args interface{}) (*hooksResponse, error) {
+	return e.invokeWithOutput(ctx, command, args, e.stdout, e.stderr)
+}
+
+// invokeWithOutput runs the hook script like invoke and writes its output to stdout and stderr
+func (e *hooksEnvironment) invokeWithOutput(ctx context.Context, command string, args interface{}, stdout, stderr io.Writer) (*hooksResponse, error) {
+	responseFile := filepath.Join(e.dir, "response.json")
+	if err := os.Remove(responseFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	state := e.state
+	if state == nil {
+		state = map[string]interface{}{}
+	}
+	request, err := json.Marshal(&hooksRequest{Command: command, ResponseFile: responseFile, State: state, Args: args})
+	if err != nil {
+		return nil, err
+	}
+
+	var cmd *exec.Cmd
+	if strings.HasSuffix(e.hook, ".js") {
+		cmd = exec.CommandContext(ctx, "node", e.hook)
+	} else {
+		cmd = exec.CommandContext(ctx, e.hook)
+	}
+	cmd.Stdin = bytes.NewReader(request)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, execExitError(exitErr.ExitCode())
+		}
+		return nil, fmt.Errorf("failed to run the %s hook: %w", command, err)
+	}
+
+	response := &hooksResponse{}
+	content, err := os.ReadFile(responseFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return response, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, response); err != nil {
+		return nil, fmt.Errorf("invalid response of the %s hook: %w", command, err)
+	}
+	if response.State != nil {
+		e.state = response.State
+	}
+	return response, nil
+}
+
+func (e *hooksEnvironment) Pull(_ bool) common.Executor {
+	return func(_ context.Context) error {
+		return nil
+	}
+}
+
+// Create runs the prepare_job hook, which pulls the images and starts the job and service containers
+func (e *hooksEnvironment) Create(_ []string, _ []string) common.Executor {
+	return func(ctx context.Context) error {
+		logger := common.Logger(ctx)
+		logger.Infof("%s%s %s image=%s", hooksLogPrefix, e.hook, hooksPrepareJob, e.input.Image)
+		if common.Dryrun(ctx) {
+			return nil
+		}
+		for _, m := range e.mounts[:2] {
+			if err := os.MkdirAll(m.SourceVolumePath, 0o777); err != nil {
+				return err
+			}
+		}
+
+		job := e.jobContainerOf(e.input.NewContainerInput)
+		job.WorkingDirectory = e.input.WorkingDir
+
+		args := &hooksPrepareJobArgs{Container: job, Services: []*hooksContainer{}}
+		names := make([]string, 0, len(e.input.Services))
+		for name := range e.input.Services {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			service := hooksContainerOf(e.input.Services[name])
+			service.ContextName = name
+			args.Services = append(args.Services, service)
+		}
+
+		e.prepared = true
+		response, err := e.invoke(ctx, hooksPrepareJob, args)
+		if err != nil {
+			return err
+		}
+		logger.Debugf("%s%s context=%v", hooksLogPrefix, hooksPrepareJob, response.Context)
+		return nil
+	}
+}
+
+func (e *hooksEnvironment) Start(_ bool) common.Executor {
+	return func(_ context.Context) error {
+		return nil
+	}
+}
+
+// Exec runs the run_script_step hook with the command
+func (e *hooksEnvironment) Exec(command []string, env map[string]string, user, workdir string) common.Executor {
+	return func(ctx context.Context) error {
+		logger := common.Logger(ctx)
+		logger.Infof("%s%s %s cmd=[%s] workdir=%s", hooksLogPrefix, e.hook, hooksRunScriptStep, strings.Join(command, " "), workdir)
+		if common.Dryrun(ctx) {
+			return nil
+		}
+		if len(command) == 0 {
+			return errors.New("no command to run")
+		}
+		if err := checkExecUser(BackendHooks, user, "0", "root"); err != nil {
+			return err
+		}
+		if workdir == "" {
+			workdir = e.input.WorkingDir
+		} else if !path.IsAbs(workdir) {
+			workdir = path.Join(e.input.WorkingDir, workdir)
+		}
+		args := &hooksRunScriptStepArgs{
+			EntryPoint:           command[0],
+			EntryPointArgs:       command[1:],
+			EnvironmentVariables: env,
+			PrependPath:          []string{},
+			WorkingDirectory:     workdir,
+		}
+		if args.EnvironmentVariables == nil {
+			args.EnvironmentVariables = map[string]string{}
+		}
+		_, err := e.invoke(ctx, hooksRunScriptStep, args)
+		return err
+	}
+}
+
+func (e *hooksEnvironment) host() *HostEnvironment {
+	return &HostEnvironment{StdOut: e.stdout}
+}
+
+func (e *hooksEnvironment) Copy(destPath string, files ...*FileEntry) common.Executor {
+	return func(ctx context.Context) error {
+		if common.Dryrun(ctx) {
+			return nil
+		}
+		hostPath, err := e.hostPath(destPath)
+		if err != nil {
+			return err
+		}
+		return e.host().Copy(hostPath, files...)(ctx)
+	}
+}
+
+func (e *hooksEnvironment) CopyTarStream(ctx context.Context, destPath string, tarStream io.Reader) error {
+	if common.Dryrun(ctx) {
+		return nil
+	}
+	hostPath, err := e.hostPath(destPath)
+	if err != nil {
+		return err
+	}
+	return e.host().CopyTarStream(ctx, hostPath, tarStream)
+}
+
+func (e *hooksEnvironment) CopyDir(destPath string, srcPath string, useGitIgnore bool) common.Executor {
+	return func(ctx context.Context) error {
+		common.Logger(ctx).Infof("%scp src=%s dst=%s", hooksLogPrefix, srcPath, destPath)
+		if common.Dryrun(ctx) {
+			return nil
+		}
+		hostPath, err := e.hostPath(destPath)
+		if err != nil {
+			return err
+		}
+		return e.host().CopyDir(hostPath+string(filepath.Separator), srcPath, useGitIgnore)(ctx)
+	}
+}
+
+func (e *hooksEnvironment) GetContainerArchive(ctx context.Context, srcPath string) (io.ReadCloser, error) {
+	if common.Dryrun(ctx) {
+		return nil, fmt.Errorf("DRYRUN is not supported in GetContainerArchive")
+	}
+	hostPath, err := e.hostPath(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	return e.host().GetContainerArchive(ctx, hostPath)
+}
+
+func (e *hooksEnvironment) UpdateFromEnv(srcPath string, env *map[string]string) common.Executor {
+	return parseEnvFile(e, srcPath, env).IfNot(common.Dryrun)
+}
+
+// UpdateFromImageEnv adds the env of the image, the hooks do not return it so it is read from
+// the job container with a script step writing it to the shared act path
+func (e *hooksEnvironment) UpdateFromImageEnv(env *map[string]string) common.Executor {
+	envMap := *env
+	return func(ctx context.Context) error {
+		if common.Dryrun(ctx) {
+			return nil
+		}
+		imageEnv := path.Join(e.GetActPath(), "image-env")
+		if _, err := e.invoke(ctx, hooksRunScriptStep, &hooksRunScriptStepArgs{
+			EntryPoint:           "sh",
+			EntryPointArgs:       []string{"-c", "env > " + imageEnv},
+			EnvironmentVariables: map[string]string{},
+			PrependPath:          []string{},
+			WorkingDirectory:     e.input.WorkingDir,
+		}); err != nil {
+			return fmt.Errorf("failed to read the env of the job container: %w", err)
+		}
+		hostPath, err := e.hostPath(imageEnv)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(hostPath)
+		if err != nil {
+			return err
+		}
+		mergeImageEnv(envMap, strings.Split(string(content), "\n"))
+		return nil
+	}
+}
+
+// Remove runs the cleanup_job hook and removes the directories of the job
+func (e *hooksEnvironment) Remove() common.Executor {
+	return func(ctx context.Context) error {
+		if common.Dryrun(ctx) {
+			return nil
+		}
+		if e.prepared {
+			common.Logger(ctx).Infof("%s%s %s", hooksLogPrefix, e.hook, hooksCleanupJob)
+			if _, err := e.invoke(ctx, hooksCleanupJob, map[string]interface{}{}); err != nil {
+				return err
+			}
+			e.prepared, e.state = false, nil
+		}
+		return os.RemoveAll(e.dir)
+	}
+}
+
+func (e *hooksEnvironment) Close() common.Executor {
+	return func(_ context.Context) error {
+		return nil
+	}
+}
+
+func (e *hooksEnvironment) GetHealth(_ context.Context) Health {
+	return HealthHealthy
+}
+
+func (e *hooksEnvironment) ReplaceLogWriter(stdout io.Writer, stderr io.Writer) (io.Writer, io.Writer) {
+	oldStdout, oldStderr := e.stdout, e.stderr
+	e.stdout, e.stderr = stdout, stderr
+	return oldStdout, oldStderr
+}
+
+// NewStepContainer returns the container of a docker step, which is run by the run_container_step hook
+func (e *hooksEnvironment) NewStepContainer(input *NewContainerInput, build *NewDockerBuildExecutorInput) Container {
+	return &hooksStepContainer{
+		env:    e,
+		input:  input,
+		build:  build,
+		stdout: input.Stdout,
+		stderr: input.Stderr,
+	}
+}
+
+// hooksStepContainer is the container of a docker action or docker:// step of a job of the hooks
+// backend. The run_container_step hook pulls or builds the image and runs the container to
+// completion, so the container only exists while it is started.
+type hooksStepContainer struct {
+	env    *hooksEnvironment
+	input  *NewContainerInput
+	build  *NewDockerBuildExecutorInput
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// buildDir returns the directory of the build context of the image on the host
+func (c *hooksStepContainer) buildDir() string {
+	return filepath.Join(c.env.dir, "build", c.input.Name)
+}
+
+// Pull does nothing, the run_container_step hook pulls the image
+func (c *hooksStepContainer) Pull(_ bool) common.Executor {
+	return func(_ context.Context) error {
+		return nil
+	}
+}
+
+// Create does nothing, the run_container_step hook creates the container
+func (c *hooksStepContainer) Create(_ []string, _ []string) common.Executor {
+	return func(_ context.Context) error {
+		return nil
+	}
+}
+
+// Start runs the run_container_step hook with the image or with the Dockerfile of the build,
+// whose build context is extracted to a directory of the job on the host
+func (c *hooksStepContainer) Start(_ bool) common.Executor {
+	return func(ctx context.Context) error {
+		image := c.input.Image
+		if c.build != nil {
+			image = c.build.Dockerfile
+		}
+		common.Logger(ctx).Infof("%s%s %s image=%s entrypoint=%+q cmd=%+q", hooksLogPrefix, c.env.hook, hooksRunContainerStep, image, c.input.Entrypoint, c.input.Cmd)
+		if common.Dryrun(ctx) {
+			return nil
+		}
+
+		args := c.env.jobContainerOf(*c.input)
+		args.WorkingDirectory = c.input.WorkingDir
+		if len(c.input.Entrypoint) > 0 {
+			args.EntryPoint = c.input.Entrypoint[0]
+			args.EntryPointArgs = append(args.EntryPointArgs, c.input.Entrypoint[1:]...)
+		}
+		args.EntryPointArgs = append(args.EntryPointArgs, c.input.Cmd...)
+		if c.build != nil {
+			if len(c.build.BuildArgs) > 0 || c.build.Target != "" || len(c.build.Secrets) > 0 {
+				return errors.New("build args, targets and secrets of x-act-build are not supported by the container hooks")
+			}
+			archive, err := c.build.BuildContext.Open()
+			if err != nil {
+				return err
+			}
+			defer archive.Close()
+			if err := c.env.host().CopyTarStream(ctx, c.buildDir(), archive); err != nil {
+				return fmt.Errorf("failed to extract the build context: %w", err)
+			}
+			args.Image = ""
+			args.Dockerfile = filepath.Join(c.buildDir(), filepath.FromSlash(c.build.Dockerfile))
+		}
+		_, err := c.env.invokeWithOutput(ctx, hooksRunContainerStep, args, c.stdout, c.stderr)
+		return err
+	}
+}
+
+func (c *hooksStepContainer) Exec(_ []string, _ map[string]string, _, _ string) common.Executor {
+	return func(_ context.Context) error {
+		return errors.New("commands can not be run in the step containers of the container hooks")
+	}
+}
+
+func (c *hooksStepContainer) Copy(_ string, _ ...*FileEntry) common.Executor {
+	return func(_ context.Context) error {
+		return errors.New("files can not be copied to the step containers of the container hooks")
+	}
+}
+
+func (c *hooksStepContainer) CopyTarStream(_ context.Context, _ string, _ io.Reader) error {
+	return errors.New("files can not be copied to the step containers of the container hooks")
+}
+
+func (c *hooksStepContainer) CopyDir(_ string, _ string, _ bool) common.Executor {
+	return func(_ context.Context) error {
+		return errors.New("files can not be copied to the step containers of the container hooks")
+	}
+}
+
+func (c *hooksStepContainer) GetContainerArchive(_ context.Context, _ string) (io.ReadCloser, error) {
+	return nil, errors.New("files can not be copied from the step containers of the container hooks")
+}
+
+func (c *hooksStepContainer) UpdateFromEnv(_ string, _ *map[string]string) common.Executor {
+	return func(_ context.Context) error {
+		return nil
+	}
+}
+
+func (c *hooksStepContainer) UpdateFromImageEnv(_ *map[string]string) common.Executor {
+	return func(_ context.Context) error {
+		return nil
+	}
+}
+
+// Remove removes the build context of the image, the hook removes the container
+func (c *hooksStepContainer) Remove() common.Executor {
+	return func(ctx context.Context) error {
+		if common.Dryrun(ctx) {
+			return nil
+		}
+		return os.RemoveAll(c.buildDir())
+	}
+}
+
+func (c *hooksStepContainer) Close() common.Executor {
+	return func(_ context.Context) error {
+		return nil
+	}
+}
+
+func (c *hooksStepContainer) ReplaceLogWriter(stdout io.Writer, stderr io.Writer) (io.Writer, io.Writer) {
+	oldStdout, oldStderr := c.stdout, c.stderr
+	c.stdout, c.stderr = stdout, stderr
+	return oldStdout, oldStderr
+}
+
+func (c *hooksStepContainer) GetHealth(_ context.Context) Health {
+	return HealthHealthy
+}
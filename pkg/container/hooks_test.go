@@ -0,0 +1,198 @@
+package container
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHooksHelperProcess is the container hooks of TestHooksEnvironment, it runs the steps on
+// the host with the paths of the mounts of the job container replaced by the host paths
+func TestHooksHelperProcess(_ *testing.T) {
+	logFile := os.Getenv("ACT_TEST_HOOKS_LOG")
+	if logFile == "" {
+		return
+	}
+	var request struct {
+		Command      string `json:"command"`
+		ResponseFile string `json:"responseFile"`
+		State        struct {
+			Mounts []hooksMount `json:"mounts"`
+		} `json:"state"`
+		Args json.RawMessage `json:"args"`
+	}
+	if err := json.NewDecoder(os.Stdin).Decode(&request); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	log, _ := os.OpenFile(logFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	fmt.Fprintf(log, "%s %s\n", request.Command, request.Args)
+	log.Close()
+
+	switch request.Command {
+	case hooksPrepareJob:
+		var args hooksPrepareJobArgs
+		_ = json.Unmarshal(request.Args, &args)
+		response, _ := json.Marshal(map[string]interface{}{
+			"state":   map[string]interface{}{"mounts": args.Container.SystemMountVolumes},
+			"context": map[string]interface{}{"container": map[string]interface{}{"id": "job"}},
+		})
+		_ = os.WriteFile(request.ResponseFile, response, 0o600)
+	case hooksRunContainerStep:
+		var args hooksContainer
+		_ = json.Unmarshal(request.Args, &args)
+		if args.Dockerfile != "" {
+			content, _ := os.ReadFile(args.Dockerfile)
+			fmt.Printf("build %s\n", content)
+		} else {
+			fmt.Printf("run %s\n", args.Image)
+		}
+	case hooksRunScriptStep:
+		var args hooksRunScriptStepArgs
+		_ = json.Unmarshal(request.Args, &args)
+		mounts := request.State.Mounts
+		sort.Slice(mounts, func(i, j int) bool { return len(mounts[i].TargetVolumePath) > len(mounts[j].TargetVolumePath) })
+		oldnew := []string{}
+		for _, m := range mounts {
+			oldnew = append(oldnew, m.TargetVolumePath, m.SourceVolumePath)
+		}
+		toHost := strings.NewReplacer(oldnew...).Replace
+		cmdArgs := []string{}
+		for _, arg := range args.EntryPointArgs {
+			cmdArgs = append(cmdArgs, toHost(arg))
+		}
+		cmd := exec.Command(args.EntryPoint, cmdArgs...)
+		cmd.Dir = toHost(args.WorkingDirectory)
+		cmd.Env = os.Environ()
+		for k, v := range args.EnvironmentVariables {
+			cmd.Env = append(cmd.Env, k+"="+toHost(v))
+		}
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				os.Exit(exitErr.ExitCode())
+			}
+			os.Exit(127)
+		}
+	}
+	os.Exit(0)
+}
+
+func TestHooksEnvironment(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the hooks of the test are a shell script")
+	}
+	ctx := context.Background()
+	dir := t.TempDir()
+	hook := filepath.Join(dir, "hooks.sh")
+	require.NoError(t, os.WriteFile(hook, []byte(fmt.Sprintf("#!/bin/sh\nexec %q -test.run='^TestHooksHelperProcess$'\n", os.Args[0])), 0o700))
+	logFile := filepath.Join(dir, "hooks.log")
+	t.Setenv(hooksEnvironmentVar, hook)
+	t.Setenv("ACT_TEST_HOOKS_LOG", logFile)
+
+	workspace := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(workspace, "input.txt"), []byte("input\n"), 0o600))
+	stdout := &bytes.Buffer{}
+	backend, err := GetBackend(BackendHooks)
+	require.NoError(t, err)
+	env, err := backend.NewEnvironment(ctx, &BackendInput{
+		NewContainerInput: NewContainerInput{
+			Name:       "act-hooks-test",
+			Image:      "ubuntu:24.04",
+			WorkingDir: "/act-hooks-test/workspace",
+			Env:        []string{"RUNNER_OS=Linux"},
+			Mounts:     map[string]string{"act-toolcache": "/opt/hostedtoolcache", "act-hooks-test-env": "/var/run/act"},
+			Stdout:     stdout,
+			Stderr:     stdout,
+		},
+		Workdir:  workspace,
+		CacheDir: t.TempDir(),
+		Services: map[string]*NewContainerInput{
+			"redis": {
+				Image:        "redis",
+				ExposedPorts: nat.PortSet{"6379/tcp": {}},
+				PortBindings: nat.PortMap{"6379/tcp": {{HostPort: "16379"}}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	hooks := env.(*hooksEnvironment)
+
+	require.NoError(t, env.Pull(false).Then(env.Create(nil, nil)).Then(env.Start(false))(ctx))
+	log, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(log), `prepare_job {"container":{"image":"ubuntu:24.04","workingDirectory":"/act-hooks-test/workspace"`)
+	assert.Contains(t, string(log), `"userMountVolumes":[{"sourceVolumePath":"act-toolcache","targetVolumePath":"/opt/hostedtoolcache","readOnly":false}]`)
+	assert.Contains(t, string(log), `"contextName":"redis","image":"redis"`)
+	assert.Contains(t, string(log), `"portMappings":["16379:6379/tcp"]`)
+
+	require.NoError(t, env.CopyDir("/act-hooks-test/workspace/", workspace+"/", false)(ctx))
+	require.NoError(t, env.Copy("/var/run/act/", &FileEntry{Name: "workflow/event.json", Mode: 0o644, Body: "{}"})(ctx))
+	assert.FileExists(t, filepath.Join(hooks.dir, "act", "workflow", "event.json"))
+	assert.ErrorContains(t, env.Copy("/etc/", &FileEntry{Name: "passwd", Mode: 0o644})(ctx), "not shared with the host")
+
+	assert.NoError(t, env.Exec([]string{"sh", "-c", `echo $FOO; cat input.txt > output.txt`}, map[string]string{"FOO": "bar"}, "", "")(ctx))
+	assert.Equal(t, "bar\n", stdout.String())
+	archive, err := env.GetContainerArchive(ctx, "/act-hooks-test/workspace/output.txt")
+	require.NoError(t, err)
+	content, err := io.ReadAll(archive)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "input\n")
+	assert.EqualError(t, env.Exec([]string{"false"}, nil, "", "")(ctx), "exitcode '1': failure")
+
+	envMap := map[string]string{"PATH": "/opt/bin"}
+	require.NoError(t, env.UpdateFromImageEnv(&envMap)(ctx))
+	assert.NotEqual(t, "/opt/bin", envMap["PATH"])
+
+	stepStdout := &bytes.Buffer{}
+	stepInput := &NewContainerInput{
+		Name:       "act-hooks-test-step",
+		Image:      "alpine:3",
+		Entrypoint: []string{"sh", "-c"},
+		Cmd:        []string{"echo hi"},
+		WorkingDir: "/act-hooks-test/workspace",
+		Mounts:     map[string]string{"act-toolcache": "/opt/hostedtoolcache", "act-hooks-test-env": "/var/run/act"},
+		Stdout:     stepStdout,
+		Stderr:     stepStdout,
+	}
+	step := hooks.NewStepContainer(stepInput, nil)
+	require.NoError(t, step.Pull(false).Then(step.Create(nil, nil)).Then(step.Start(true)).Finally(step.Remove())(ctx))
+	assert.Equal(t, "run alpine:3\n", stepStdout.String())
+	log, err = os.ReadFile(logFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(log), `run_container_step {"image":"alpine:3","entryPoint":"sh","entryPointArgs":["-c","echo hi"],"workingDirectory":"/act-hooks-test/workspace"`)
+	assert.Contains(t, string(log), `"userMountVolumes":[{"sourceVolumePath":"act-toolcache","targetVolumePath":"/opt/hostedtoolcache","readOnly":false}],"systemMountVolumes":[{"sourceVolumePath":"`+hooks.dir)
+
+	buildContext, err := NewBuildContext(buildContextArchive(t, buildContextFile{"Dockerfile", "FROM alpine:3"}), "Dockerfile")
+	require.NoError(t, err)
+	stepStdout.Reset()
+	step = hooks.NewStepContainer(stepInput, &NewDockerBuildExecutorInput{Dockerfile: "Dockerfile", ImageTag: "act-build-dockeraction:0123", BuildContext: buildContext})
+	require.NoError(t, step.Start(true).Finally(step.Remove())(ctx))
+	assert.Equal(t, "build FROM alpine:3\n", stepStdout.String())
+	assert.NoDirExists(t, filepath.Join(hooks.dir, "build", "act-hooks-test-step"))
+
+	step = hooks.NewStepContainer(stepInput, &NewDockerBuildExecutorInput{Dockerfile: "Dockerfile", BuildContext: buildContext, Target: "release"})
+	assert.ErrorContains(t, step.Start(true)(ctx), "not supported by the container hooks")
+
+	assert.NoError(t, env.Remove()(ctx))
+	assert.NoDirExists(t, hooks.dir)
+	log, err = os.ReadFile(logFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(log), "cleanup_job {}")
+}
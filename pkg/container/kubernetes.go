@@ -0,0 +1,614 @@
+//go:build WITH_KUBERNETES
+
+package container
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/distribution/reference"
+	"github.com/kballard/go-shellquote"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+
+	"github.com/nektos/act/pkg/common"
+)
+
+func init() {
+	RegisterBackend(BackendKubernetes, BackendFunc(newKubernetesEnvironment))
+	RegisterBackend(BackendKubernetesK8s, BackendFunc(newKubernetesEnvironment))
+}
+
+const kubernetesLogPrefix = "  \u2638  "
+
+// kubernetesJobContainer is the name of the job container in the pod of the job
+const kubernetesJobContainer = "job"
+
+// kubernetesExecFunc runs a command in the job container of the pod
+type kubernetesExecFunc func(ctx context.Context, cmd []string, stdin io.Reader, stdout, stderr io.Writer) error
+
+// kubernetesEnvironment runs a job in a pod. The job container and the services of the
+// job are containers of the pod and share its network, so the services are reachable on
+// localhost and by their service id. The workspace and files are copied with tar over exec.
+type kubernetesEnvironment struct {
+	LinuxContainerEnvironmentExtensions
+	client       kubernetes.Interface
+	namespace    string
+	input        *BackendInput
+	podName      string
+	forcePull    bool
+	stdout       io.Writer
+	stderr       io.Writer
+	pollInterval time.Duration
+	startTimeout time.Duration
+	exec         kubernetesExecFunc
+}
+
+// newKubernetesEnvironment connects to the cluster of the kubeconfig, KUBECONFIG or the
+// cluster act is running in and runs the job in the namespace of the current context
+func newKubernetesEnvironment(_ context.Context, input *BackendInput) (ExecutionsEnvironment, error) {
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(clientcmd.NewDefaultClientConfigLoadingRules(), &clientcmd.ConfigOverrides{})
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the kubernetes config: %w", err)
+	}
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the kubernetes namespace: %w", err)
+	}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	e := newKubernetesEnvironmentWithClient(client, namespace, input)
+	e.exec = func(ctx context.Context, cmd []string, stdin io.Reader, stdout, stderr io.Writer) error {
+		req := client.CoreV1().RESTClient().Post().
+			Resource("pods").
+			Namespace(e.namespace).
+			Name(e.podName).
+			SubResource("exec").
+			VersionedParams(&corev1.PodExecOptions{
+				Container: kubernetesJobContainer,
+				Command:   cmd,
+				Stdin:     stdin != nil,
+				Stdout:    true,
+				Stderr:    true,
+			}, scheme.ParameterCodec)
+		executor, err := remotecommand.NewSPDYExecutor(config, "POST", req.URL())
+		if err != nil {
+			return err
+		}
+		return executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+			Stdin:  stdin,
+			Stdout: stdout,
+			Stderr: stderr,
+		})
+	}
+	return e, nil
+}
+
+func newKubernetesEnvironmentWithClient(client kubernetes.Interface, namespace string, input *BackendInput) *kubernetesEnvironment {
+	return &kubernetesEnvironment{
+		client:       client,
+		namespace:    namespace,
+		input:        input,
+		podName:      kubernetesName(input.Name, 63),
+		stdout:       input.Stdout,
+		stderr:       input.Stderr,
+		pollInterval: time.Second,
+		startTimeout: 10 * time.Minute,
+	}
+}
+
+var kubernetesInvalidChars = regexp.MustCompile("[^a-z0-9-]+")
+
+// kubernetesName returns a valid name of kubernetes objects, names longer than
+// maxLen end with a hash of the name to stay unique
+func kubernetesName(name string, maxLen int) string {
+	valid := strings.Trim(kubernetesInvalidChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if len(valid) <= maxLen && valid != "" {
+		return valid
+	}
+	digest := sha256.Sum256([]byte(name))
+	hash := hex.EncodeToString(digest[:8])
+	prefix := strings.Trim(trimToLen(valid, maxLen-len(hash)-1), "-")
+	if prefix == "" {
+		return hash
+	}
+	return prefix + "-" + hash
+}
+
+func (e *kubernetesEnvironment) pullSecretName() string {
+	return kubernetesName(e.podName+"-registry", 253)
+}
+
+// pod returns the pod of the job with the job container first and the services sorted by id
+func (e *kubernetesEnvironment) pod(capAdd []string, capDrop []string) (*corev1.Pod, error) {
+	pullPolicy := corev1.PullIfNotPresent
+	if e.forcePull {
+		pullPolicy = corev1.PullAlways
+	}
+
+	volumes := map[string]bool{}
+	volumeMounts := func(mounts map[string]string) []corev1.VolumeMount {
+		names := make([]string, 0, len(mounts))
+		for name := range mounts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		result := []corev1.VolumeMount{}
+		for _, name := range names {
+			volume := kubernetesName(name, 63)
+			volumes[volume] = true
+			result = append(result, corev1.VolumeMount{Name: volume, MountPath: mounts[name]})
+		}
+		return result
+	}
+	envVars := func(env []string) []corev1.EnvVar {
+		result := []corev1.EnvVar{}
+		for _, kv := range env {
+			k, v, _ := strings.Cut(kv, "=")
+			result = append(result, corev1.EnvVar{Name: k, Value: v})
+		}
+		return result
+	}
+
+	privileged := e.input.Privileged
+	capabilities := &corev1.Capabilities{}
+	for _, c := range capAdd {
+		capabilities.Add = append(capabilities.Add, corev1.Capability(c))
+	}
+	for _, c := range capDrop {
+		capabilities.Drop = append(capabilities.Drop, corev1.Capability(c))
+	}
+	containers := []corev1.Container{{
+		Name:            kubernetesJobContainer,
+		Image:           e.input.Image,
+		ImagePullPolicy: pullPolicy,
+		Command:         e.input.Entrypoint,
+		Args:            e.input.Cmd,
+		WorkingDir:      e.input.WorkingDir,
+		Env:             envVars(e.input.Env),
+		VolumeMounts:    volumeMounts(e.input.Mounts),
+		SecurityContext: &corev1.SecurityContext{
+			Privileged:   &privileged,
+			Capabilities: capabilities,
+		},
+	}}
+
+	serviceIDs := make([]string, 0, len(e.input.Services))
+	for id := range e.input.Services {
+		serviceIDs = append(serviceIDs, id)
+	}
+	sort.Strings(serviceIDs)
+	for _, id := range serviceIDs {
+		service := e.input.Services[id]
+		probe, err := kubernetesReadinessProbe(service.Options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the options of service %s: %w", id, err)
+		}
+		servicePrivileged := service.Privileged
+		containers = append(containers, corev1.Container{
+			Name:            kubernetesName(id, 63),
+			Image:           service.Image,
+			ImagePullPolicy: pullPolicy,
+			Command:         service.Entrypoint,
+			Args:            service.Cmd,
+			Env:             envVars(service.Env),
+			VolumeMounts:    volumeMounts(service.Mounts),
+			ReadinessProbe:  probe,
+			SecurityContext: &corev1.SecurityContext{
+				Privileged: &servicePrivileged,
+			},
+		})
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      e.podName,
+			Namespace: e.namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "act",
+			},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers:    containers,
+		},
+	}
+	if len(serviceIDs) > 0 {
+		// the services share the network of the pod, so their ids resolve to localhost
+		pod.Spec.HostAliases = []corev1.HostAlias{{IP: "127.0.0.1", Hostnames: serviceIDs}}
+	}
+	volumeNames := make([]string, 0, len(volumes))
+	for name := range volumes {
+		volumeNames = append(volumeNames, name)
+	}
+	sort.Strings(volumeNames)
+	for _, name := range volumeNames {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name:         name,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		})
+	}
+	return pod, nil
+}
+
+// kubernetesReadinessProbe maps the health check of docker options to a readiness probe
+func kubernetesReadinessProbe(options string) (*corev1.Probe, error) {
+	args, err := shellquote.Split(options)
+	if err != nil {
+		return nil, err
+	}
+	flags := pflag.NewFlagSet("options", pflag.ContinueOnError)
+	flags.ParseErrorsAllowlist.UnknownFlags = true
+	healthCmd := flags.String("health-cmd", "", "")
+	interval := flags.Duration("health-interval", 0, "")
+	timeout := flags.Duration("health-timeout", 0, "")
+	retries := flags.Int32("health-retries", 0, "")
+	startPeriod := flags.Duration("health-start-period", 0, "")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if *healthCmd == "" {
+		return nil, nil
+	}
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{Command: []string{"/bin/sh", "-c", *healthCmd}},
+		},
+		PeriodSeconds:       int32(interval.Seconds()),
+		TimeoutSeconds:      int32(timeout.Seconds()),
+		FailureThreshold:    *retries,
+		InitialDelaySeconds: int32(startPeriod.Seconds()),
+	}, nil
+}
+
+// pullSecret returns the image pull secret of the images with credentials or nil
+func (e *kubernetesEnvironment) pullSecret() (*corev1.Secret, error) {
+	type auth struct {
+		Auth string `json:"auth"`
+	}
+	auths := map[string]auth{}
+	inputs := []*NewContainerInput{&e.input.NewContainerInput}
+	for _, service := range e.input.Services {
+		inputs = append(inputs, service)
+	}
+	for _, input := range inputs {
+		if input.Username == "" && input.Password == "" {
+			continue
+		}
+		named, err := reference.ParseNormalizedNamed(input.Image)
+		if err != nil {
+			return nil, err
+		}
+		auths[reference.Domain(named)] = auth{Auth: base64.StdEncoding.EncodeToString([]byte(input.Username + ":" + input.Password))}
+	}
+	if len(auths) == 0 {
+		return nil, nil
+	}
+	config, err := json.Marshal(map[string]interface{}{"auths": auths})
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      e.pullSecretName(),
+			Namespace: e.namespace,
+			Labels:    map[string]string{"app.kubernetes.io/managed-by": "act"},
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{corev1.DockerConfigJsonKey: config},
+	}, nil
+}
+
+func (e *kubernetesEnvironment) Pull(forcePull bool) common.Executor {
+	return func(_ context.Context) error {
+		// the images are pulled by the kubelet when the pod starts
+		e.forcePull = forcePull
+		return nil
+	}
+}
+
+func (e *kubernetesEnvironment) Create(capAdd []string, capDrop []string) common.Executor {
+	return func(ctx context.Context) error {
+		logger := common.Logger(ctx)
+		logger.Infof("%skubectl create pod %s/%s image=%s", kubernetesLogPrefix, e.namespace, e.podName, e.input.Image)
+		if common.Dryrun(ctx) {
+			return nil
+		}
+		if len(e.input.Binds) > 0 {
+			logger.Debugf("Binds are not supported by the kubernetes backend: %v", e.input.Binds)
+		}
+
+		pod, err := e.pod(capAdd, capDrop)
+		if err != nil {
+			return err
+		}
+		secret, err := e.pullSecret()
+		if err != nil {
+			return err
+		}
+		if secret != nil {
+			if _, err := e.client.CoreV1().Secrets(e.namespace).Create(ctx, secret, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+				return fmt.Errorf("failed to create the image pull secret: %w", err)
+			}
+			pod.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: secret.Name}}
+		}
+
+		_, err = e.client.CoreV1().Pods(e.namespace).Create(ctx, pod, metav1.CreateOptions{})
+		if apierrors.IsAlreadyExists(err) {
+			// the pod of a reused job environment
+			logger.Debugf("Found pod %s/%s", e.namespace, e.podName)
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create pod: %w", err)
+		}
+		return nil
+	}
+}
+
+// Start waits until the job container and the services of the pod are ready, at most
+// startTimeout to fail jobs of pods which are never scheduled or never become ready
+func (e *kubernetesEnvironment) Start(_ bool) common.Executor {
+	return func(ctx context.Context) error {
+		common.Logger(ctx).Infof("%skubectl wait pod %s/%s", kubernetesLogPrefix, e.namespace, e.podName)
+		if common.Dryrun(ctx) {
+			return nil
+		}
+		deadline := time.After(e.startTimeout)
+		for {
+			pod, err := e.client.CoreV1().Pods(e.namespace).Get(ctx, e.podName, metav1.GetOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to get pod: %w", err)
+			}
+			ready, err := kubernetesPodReady(pod)
+			if err != nil || ready {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-deadline:
+				return fmt.Errorf("pod %s is not ready after %s: %s", pod.Name, e.startTimeout, pod.Status.Phase)
+			case <-time.After(e.pollInterval):
+			}
+		}
+	}
+}
+
+// kubernetesWaitingErrors are the reasons of waiting containers which do not start without a change of the pod
+var kubernetesWaitingErrors = map[string]bool{
+	"ErrImagePull":               true,
+	"ImagePullBackOff":           true,
+	"InvalidImageName":           true,
+	"CrashLoopBackOff":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"RunContainerError":          true,
+}
+
+// kubernetesPodError returns an error if the pod stopped, can not be scheduled or one of
+// its containers terminated or can not start, containers are not restarted in the pod of a job
+func kubernetesPodError(pod *corev1.Pod) error {
+	switch pod.Status.Phase {
+	case corev1.PodFailed, corev1.PodSucceeded:
+		return fmt.Errorf("pod %s stopped: %s %s", pod.Name, pod.Status.Reason, pod.Status.Message)
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse && condition.Reason == corev1.PodReasonUnschedulable {
+			return fmt.Errorf("pod %s can not be scheduled: %s", pod.Name, condition.Message)
+		}
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if waiting := status.State.Waiting; waiting != nil && kubernetesWaitingErrors[waiting.Reason] {
+			return fmt.Errorf("container %s of pod %s: %s %s", status.Name, pod.Name, waiting.Reason, waiting.Message)
+		}
+		for _, terminated := range []*corev1.ContainerStateTerminated{status.State.Terminated, status.LastTerminationState.Terminated} {
+			if terminated != nil {
+				return fmt.Errorf("container %s of pod %s terminated with exit code %d: %s %s", status.Name, pod.Name, terminated.ExitCode, terminated.Reason, terminated.Message)
+			}
+		}
+	}
+	return nil
+}
+
+// kubernetesPodReady returns whether all containers of the running pod are ready and the
+// error of kubernetesPodError
+func kubernetesPodReady(pod *corev1.Pod) (bool, error) {
+	if err := kubernetesPodError(pod); err != nil {
+		return false, err
+	}
+	if pod.Status.Phase != corev1.PodRunning || len(pod.Status.ContainerStatuses) < len(pod.Spec.Containers) {
+		return false, nil
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if !status.Ready {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (e *kubernetesEnvironment) GetHealth(ctx context.Context) Health {
+	pod, err := e.client.CoreV1().Pods(e.namespace).Get(ctx, e.podName, metav1.GetOptions{})
+	if err != nil {
+		common.Logger(ctx).Errorf("failed to query pod health %s", err)
+		return HealthUnHealthy
+	}
+	ready, err := kubernetesPodReady(pod)
+	switch {
+	case err != nil:
+		return HealthUnHealthy
+	case ready:
+		return HealthHealthy
+	default:
+		return HealthStarting
+	}
+}
+
+// execCommand wraps the command to run it in the working directory with the env sourced
+// from stdin, pod exec supports neither and arguments are visible in the audit log
+func execCommand(command []string, workdir string) []string {
+	return append([]string{"/bin/sh", "-c", `cd "$0" && . /dev/stdin && exec "$@"`, workdir}, command...)
+}
+
+func (e *kubernetesEnvironment) Exec(command []string, env map[string]string, user, workdir string) common.Executor {
+	return func(ctx context.Context) error {
+		logger := common.Logger(ctx)
+		logger.Infof("%skubectl exec cmd=[%s] user=%s workdir=%s", kubernetesLogPrefix, strings.Join(command, " "), user, workdir)
+		if common.Dryrun(ctx) {
+			return nil
+		}
+		if err := checkExecUser(BackendKubernetes, user, "0", "root"); err != nil {
+			return err
+		}
+		if workdir == "" {
+			workdir = e.input.WorkingDir
+		} else if !path.IsAbs(workdir) {
+			workdir = path.Join(e.input.WorkingDir, workdir)
+		}
+		if workdir == "" {
+			workdir = "/"
+		}
+
+		err := e.exec(ctx, execCommand(command, workdir), bytes.NewReader(execEnvScript(env)), e.stdout, e.stderr)
+		var exitErr utilexec.ExitError
+		if errors.As(err, &exitErr) {
+			return execExitError(exitErr.ExitStatus())
+		}
+		return err
+	}
+}
+
+// extract extracts the tar stream to destPath in the job container
+func (e *kubernetesEnvironment) extract(ctx context.Context, destPath string, tarStream io.Reader) error {
+	cmd := []string{"/bin/sh", "-c", `mkdir -p "$0" && tar -xf - -C "$0"`, destPath}
+	stderr := &bytes.Buffer{}
+	if err := e.exec(ctx, cmd, tarStream, io.Discard, stderr); err != nil {
+		return fmt.Errorf("failed to copy content to pod: %w: %s", err, stderr.String())
+	}
+	return nil
+}
+
+func (e *kubernetesEnvironment) Copy(destPath string, files ...*FileEntry) common.Executor {
+	return func(ctx context.Context) error {
+		if common.Dryrun(ctx) {
+			return nil
+		}
+		buf, err := newFilesArchive(ctx, files...)
+		if err != nil {
+			return err
+		}
+		return e.extract(ctx, destPath, buf)
+	}
+}
+
+func (e *kubernetesEnvironment) CopyTarStream(ctx context.Context, destPath string, tarStream io.Reader) error {
+	if common.Dryrun(ctx) {
+		return nil
+	}
+	return e.extract(ctx, destPath, tarStream)
+}
+
+func (e *kubernetesEnvironment) CopyDir(destPath string, srcPath string, useGitIgnore bool) common.Executor {
+	return func(ctx context.Context) error {
+		logger := common.Logger(ctx)
+		logger.Infof("%skubectl cp src=%s dst=%s", kubernetesLogPrefix, srcPath, destPath)
+		if common.Dryrun(ctx) {
+			return nil
+		}
+
+		pr, pw := io.Pipe()
+		go func() {
+			pw.CloseWithError(writeDirArchive(ctx, pw, srcPath, strings.TrimPrefix(destPath, "/"), useGitIgnore))
+		}()
+		err := e.extract(ctx, "/", pr)
+		pr.Close()
+		return err
+	}
+}
+
+func (e *kubernetesEnvironment) GetContainerArchive(ctx context.Context, srcPath string) (io.ReadCloser, error) {
+	if common.Dryrun(ctx) {
+		return nil, fmt.Errorf("DRYRUN is not supported in GetContainerArchive")
+	}
+	srcPath = path.Clean(srcPath)
+	return newExecArchive(func(stdout io.Writer) error {
+		stderr := &bytes.Buffer{}
+		err := e.exec(ctx, []string{"tar", "-cf", "-", "-C", path.Dir(srcPath), path.Base(srcPath)}, nil, stdout, stderr)
+		if err != nil {
+			return fmt.Errorf("failed to copy content from pod: %w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return nil
+	})
+}
+
+func (e *kubernetesEnvironment) UpdateFromEnv(srcPath string, env *map[string]string) common.Executor {
+	return parseEnvFile(e, srcPath, env).IfNot(common.Dryrun)
+}
+
+// UpdateFromImageEnv adds the env of the running job container, the image is not inspected
+func (e *kubernetesEnvironment) UpdateFromImageEnv(env *map[string]string) common.Executor {
+	envMap := *env
+	return func(ctx context.Context) error {
+		stdout := &bytes.Buffer{}
+		if err := e.exec(ctx, []string{"env"}, nil, stdout, io.Discard); err != nil {
+			return fmt.Errorf("failed to read the env of the job container: %w", err)
+		}
+		mergeImageEnv(envMap, strings.Split(stdout.String(), "\n"))
+		return nil
+	}
+}
+
+func (e *kubernetesEnvironment) Remove() common.Executor {
+	return func(ctx context.Context) error {
+		common.Logger(ctx).Debugf("%skubectl delete pod %s/%s", kubernetesLogPrefix, e.namespace, e.podName)
+		if common.Dryrun(ctx) {
+			return nil
+		}
+		gracePeriod := int64(0)
+		err := e.client.CoreV1().Pods(e.namespace).Delete(ctx, e.podName, metav1.DeleteOptions{GracePeriodSeconds: &gracePeriod})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete pod: %w", err)
+		}
+		err = e.client.CoreV1().Secrets(e.namespace).Delete(ctx, e.pullSecretName(), metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete the image pull secret: %w", err)
+		}
+		return nil
+	}
+}
+
+func (e *kubernetesEnvironment) Close() common.Executor {
+	return func(_ context.Context) error {
+		return nil
+	}
+}
+
+func (e *kubernetesEnvironment) ReplaceLogWriter(stdout io.Writer, stderr io.Writer) (io.Writer, io.Writer) {
+	oldStdout, oldStderr := e.stdout, e.stderr
+	e.stdout, e.stderr = stdout, stderr
+	return oldStdout, oldStderr
+}
@@ -0,0 +1,17 @@
+//go:build !WITH_KUBERNETES
+
+package container
+
+import (
+	"context"
+	"fmt"
+)
+
+func init() {
+	RegisterBackend(BackendKubernetes, BackendFunc(newKubernetesEnvironment))
+	RegisterBackend(BackendKubernetesK8s, BackendFunc(newKubernetesEnvironment))
+}
+
+func newKubernetesEnvironment(_ context.Context, _ *BackendInput) (ExecutionsEnvironment, error) {
+	return nil, fmt.Errorf("the kubernetes backend is not included in this build of act, build act with -tags WITH_KUBERNETES")
+}
@@ -0,0 +1,234 @@
+//go:build WITH_KUBERNETES
+
+package container
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+type kubernetesExecCall struct {
+	cmd   []string
+	stdin []byte
+}
+
+func newTestKubernetesEnvironment(input *BackendInput) (*kubernetesEnvironment, *fake.Clientset, *[]kubernetesExecCall) {
+	client := fake.NewSimpleClientset()
+	e := newKubernetesEnvironmentWithClient(client, "ci", input)
+	e.pollInterval = time.Millisecond
+	calls := &[]kubernetesExecCall{}
+	e.exec = func(_ context.Context, cmd []string, stdin io.Reader, stdout, _ io.Writer) error {
+		call := kubernetesExecCall{cmd: cmd}
+		if stdin != nil {
+			call.stdin, _ = io.ReadAll(stdin)
+		}
+		*calls = append(*calls, call)
+		if cmd[0] == "env" {
+			_, _ = stdout.Write([]byte("PATH=/usr/bin\nHOME=/root\n"))
+		}
+		if len(cmd) > 4 && cmd[4] == "false" {
+			return utilexec.CodeExitError{Err: errors.New("command terminated with exit code 1"), Code: 1}
+		}
+		return nil
+	}
+	return e, client, calls
+}
+
+func TestKubernetesName(t *testing.T) {
+	assert.Equal(t, "act-build-job-1", kubernetesName("act-Build_Job 1", 63))
+	long := kubernetesName(strings.Repeat("a", 100), 63)
+	assert.Len(t, long, 63)
+	assert.NotEqual(t, long, kubernetesName(strings.Repeat("a", 101), 63))
+	assert.Len(t, kubernetesName("___", 63), 16)
+}
+
+func TestKubernetesPod(t *testing.T) {
+	ctx := context.Background()
+	e, client, _ := newTestKubernetesEnvironment(&BackendInput{
+		NewContainerInput: NewContainerInput{
+			Name:       "act-test-job",
+			Image:      "node:16",
+			Username:   "user",
+			Password:   "secret",
+			Entrypoint: []string{"tail", "-f", "/dev/null"},
+			WorkingDir: "/github/workspace",
+			Env:        []string{"CI=true"},
+			Mounts:     map[string]string{"act-toolcache": "/opt/hostedtoolcache"},
+		},
+		Services: map[string]*NewContainerInput{
+			"db": {
+				Image:   "postgres",
+				Env:     []string{"POSTGRES_PASSWORD=postgres"},
+				Options: "--health-cmd 'pg_isready -U postgres' --health-interval 10s --health-retries 5 --memory 1g",
+			},
+		},
+	})
+
+	require.NoError(t, e.Pull(true).Then(e.Create([]string{"SYS_ADMIN"}, nil))(ctx))
+
+	pod, err := client.CoreV1().Pods("ci").Get(ctx, "act-test-job", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, corev1.RestartPolicyNever, pod.Spec.RestartPolicy)
+	require.Len(t, pod.Spec.Containers, 2)
+
+	job := pod.Spec.Containers[0]
+	assert.Equal(t, "node:16", job.Image)
+	assert.Equal(t, corev1.PullAlways, job.ImagePullPolicy)
+	assert.Equal(t, []string{"tail", "-f", "/dev/null"}, job.Command)
+	assert.Equal(t, []corev1.EnvVar{{Name: "CI", Value: "true"}}, job.Env)
+	assert.Equal(t, []corev1.Capability{"SYS_ADMIN"}, job.SecurityContext.Capabilities.Add)
+	assert.Equal(t, []corev1.VolumeMount{{Name: "act-toolcache", MountPath: "/opt/hostedtoolcache"}}, job.VolumeMounts)
+	assert.Equal(t, "act-toolcache", pod.Spec.Volumes[0].Name)
+
+	db := pod.Spec.Containers[1]
+	assert.Equal(t, "db", db.Name)
+	require.NotNil(t, db.ReadinessProbe)
+	assert.Equal(t, []string{"/bin/sh", "-c", "pg_isready -U postgres"}, db.ReadinessProbe.Exec.Command)
+	assert.Equal(t, int32(10), db.ReadinessProbe.PeriodSeconds)
+	assert.Equal(t, int32(5), db.ReadinessProbe.FailureThreshold)
+	assert.Equal(t, []corev1.HostAlias{{IP: "127.0.0.1", Hostnames: []string{"db"}}}, pod.Spec.HostAliases)
+
+	require.Len(t, pod.Spec.ImagePullSecrets, 1)
+	secret, err := client.CoreV1().Secrets("ci").Get(ctx, pod.Spec.ImagePullSecrets[0].Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, string(secret.Data[corev1.DockerConfigJsonKey]), `"docker.io"`)
+
+	// a reused pod already exists
+	assert.NoError(t, e.Create(nil, nil)(ctx))
+
+	assert.NoError(t, e.Remove()(ctx))
+	pods, err := client.CoreV1().Pods("ci").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, pods.Items)
+	assert.NoError(t, e.Remove()(ctx))
+}
+
+func TestKubernetesStart(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	e, client, _ := newTestKubernetesEnvironment(&BackendInput{NewContainerInput: NewContainerInput{Name: "job", Image: "alpine"}})
+	require.NoError(t, e.Create(nil, nil)(ctx))
+	assert.Equal(t, HealthStarting, e.GetHealth(ctx))
+
+	done := make(chan error)
+	go func() {
+		done <- e.Start(false)(ctx)
+	}()
+	pod, err := client.CoreV1().Pods("ci").Get(ctx, "job", metav1.GetOptions{})
+	require.NoError(t, err)
+	pod.Status.Phase = corev1.PodRunning
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: kubernetesJobContainer, Ready: true}}
+	_, err = client.CoreV1().Pods("ci").UpdateStatus(ctx, pod, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.NoError(t, <-done)
+	assert.Equal(t, HealthHealthy, e.GetHealth(ctx))
+
+	pod.Status.Phase = corev1.PodPending
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:  kubernetesJobContainer,
+		State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ErrImagePull", Message: "not found"}},
+	}}
+	_, err = client.CoreV1().Pods("ci").UpdateStatus(ctx, pod, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.ErrorContains(t, e.Start(false)(ctx), "ErrImagePull not found")
+	assert.Equal(t, HealthUnHealthy, e.GetHealth(ctx))
+
+	pod.Status.Phase = corev1.PodRunning
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:                 kubernetesJobContainer,
+		State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+		LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"}},
+	}}
+	_, err = client.CoreV1().Pods("ci").UpdateStatus(ctx, pod, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.ErrorContains(t, e.Start(false)(ctx), "CrashLoopBackOff")
+
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:                 kubernetesJobContainer,
+		Ready:                true,
+		LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"}},
+	}}
+	_, err = client.CoreV1().Pods("ci").UpdateStatus(ctx, pod, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.ErrorContains(t, e.Start(false)(ctx), "terminated with exit code 137: OOMKilled")
+
+	pod.Status.Phase = corev1.PodPending
+	pod.Status.ContainerStatuses = nil
+	pod.Status.Conditions = []corev1.PodCondition{{
+		Type:    corev1.PodScheduled,
+		Status:  corev1.ConditionFalse,
+		Reason:  corev1.PodReasonUnschedulable,
+		Message: "0/3 nodes are available: 3 Insufficient memory.",
+	}}
+	_, err = client.CoreV1().Pods("ci").UpdateStatus(ctx, pod, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.ErrorContains(t, e.Start(false)(ctx), "can not be scheduled: 0/3 nodes are available")
+
+	pod.Status.Conditions = nil
+	_, err = client.CoreV1().Pods("ci").UpdateStatus(ctx, pod, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	e.startTimeout = 10 * time.Millisecond
+	assert.ErrorContains(t, e.Start(false)(ctx), "is not ready after 10ms: Pending")
+}
+
+func TestKubernetesExec(t *testing.T) {
+	ctx := context.Background()
+	e, _, calls := newTestKubernetesEnvironment(&BackendInput{NewContainerInput: NewContainerInput{Name: "job", WorkingDir: "/github/workspace"}})
+
+	assert.NoError(t, e.Exec([]string{"echo", "hi"}, map[string]string{"B": "2", "A": "1"}, "", "sub")(ctx))
+	assert.Equal(t, []string{"/bin/sh", "-c", `cd "$0" && . /dev/stdin && exec "$@"`, "/github/workspace/sub", "echo", "hi"}, (*calls)[0].cmd)
+	assert.Equal(t, "export A=1\nexport B=2\n", string((*calls)[0].stdin))
+
+	assert.EqualError(t, e.Exec([]string{"false"}, nil, "", "")(ctx), "exitcode '1': failure")
+	assert.NoError(t, e.Exec([]string{"id"}, nil, "0", "")(ctx))
+	assert.EqualError(t, e.Exec([]string{"id"}, nil, "1001", "")(ctx), "running commands as user 1001 is not supported by the kubernetes backend")
+
+	env := map[string]string{"PATH": "/opt/bin"}
+	assert.NoError(t, e.UpdateFromImageEnv(&env)(ctx))
+	assert.Equal(t, map[string]string{"PATH": "/opt/bin:/usr/bin", "HOME": "/root"}, env)
+}
+
+func TestKubernetesCopy(t *testing.T) {
+	ctx := context.Background()
+	e, _, calls := newTestKubernetesEnvironment(&BackendInput{NewContainerInput: NewContainerInput{Name: "job"}})
+
+	assert.NoError(t, e.Copy("/var/run/act", &FileEntry{Name: "event.json", Mode: 0o644, Body: "{}"})(ctx))
+	call := (*calls)[0]
+	assert.Equal(t, "/var/run/act", call.cmd[3])
+	tr := tar.NewReader(bytes.NewReader(call.stdin))
+	header, err := tr.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "event.json", header.Name)
+
+	src := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(src, "main.go"), []byte("package main"), 0o600))
+	assert.NoError(t, e.CopyDir("/github/workspace/", src+"/", false)(ctx))
+	call = (*calls)[1]
+	assert.Equal(t, "/", call.cmd[3])
+	names := []string{}
+	tr = tar.NewReader(bytes.NewReader(call.stdin))
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		names = append(names, header.Name)
+	}
+	assert.Contains(t, names, "github/workspace/main.go")
+}
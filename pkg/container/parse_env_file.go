@@ -67,3 +67,23 @@ func parseEnvFile(e Container, srcPath string, env *map[string]string) common.Ex
 		return s.Err()
 	}
 }
+
+// mergeImageEnv adds the KEY=VALUE pairs of the env of an image to env like the docker
+// container does, PATH is appended and the other keys are only set if empty
+func mergeImageEnv(env map[string]string, imageEnv []string) {
+	for _, kv := range imageEnv {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			continue
+		}
+		if k == "PATH" {
+			if env[k] == "" {
+				env[k] = v
+			} else {
+				env[k] += `:` + v
+			}
+		} else if env[k] == "" {
+			env[k] = v
+		}
+	}
+}
@@ -0,0 +1,415 @@
+//go:build linux
+
+package container
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/nektos/act/pkg/common"
+)
+
+// sandboxImage is the root filesystem and config of an image of the sandbox backend
+type sandboxImage struct {
+	Rootfs string
+	Config specs.ImageConfig
+}
+
+const (
+	dockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	sandboxWhiteout    = ".wh."
+	sandboxOpaque      = ".wh..wh..opq"
+)
+
+// loadSandboxImage returns the image of src, the root filesystem of the host, a directory
+// with a root filesystem or an image unpacked to imagesDir. OCI image layouts, docker save
+// archives and root filesystems are unpacked once unless force is set.
+func loadSandboxImage(ctx context.Context, src string, imagesDir string, platform string, force bool) (*sandboxImage, error) {
+	if src == "" || src == SandboxHostRoot {
+		return &sandboxImage{Rootfs: "/"}, nil
+	}
+	src, err := filepath.Abs(src)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(src)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() && !isSandboxImageLayout(src) {
+		return &sandboxImage{Rootfs: src}, nil
+	}
+
+	// the unpacked image is reused until the image changes
+	modTime := info.ModTime()
+	for _, name := range []string{"index.json", "manifest.json"} {
+		if fi, err := os.Stat(filepath.Join(src, name)); info.IsDir() && err == nil {
+			modTime = fi.ModTime()
+		}
+	}
+	digest := sha256.Sum256(fmt.Appendf(nil, "%s\x00%d\x00%d\x00%s", src, info.Size(), modTime.UnixNano(), platform))
+	dir := filepath.Join(imagesDir, hex.EncodeToString(digest[:8]))
+	image := &sandboxImage{Rootfs: filepath.Join(dir, "rootfs")}
+	if data, err := os.ReadFile(filepath.Join(dir, "config.json")); err == nil && !force {
+		if err := json.Unmarshal(data, &image.Config); err == nil {
+			return image, nil
+		}
+	}
+
+	common.Logger(ctx).Debugf("Unpacking %s to %s", src, dir)
+	if err := os.MkdirAll(imagesDir, 0o755); err != nil {
+		return nil, err
+	}
+	tmp, err := os.MkdirTemp(imagesDir, "unpack-")
+	if err != nil {
+		return nil, err
+	}
+	defer removeSandboxDir(tmp) //nolint:errcheck
+
+	rootfs := filepath.Join(tmp, "rootfs")
+	layout := src
+	if !info.IsDir() {
+		if err := extractSandboxArchive(src, rootfs, false); err != nil {
+			return nil, err
+		}
+		layout = ""
+		if isSandboxImageLayout(rootfs) {
+			layout = filepath.Join(tmp, "layout")
+			if err := os.Rename(rootfs, layout); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if layout != "" {
+		config, layers, err := readSandboxImageLayout(layout, platform)
+		if err != nil {
+			return nil, err
+		}
+		image.Config = config
+		if err := os.MkdirAll(rootfs, 0o755); err != nil {
+			return nil, err
+		}
+		for _, layer := range layers {
+			if err := extractSandboxArchive(layer, rootfs, true); err != nil {
+				return nil, fmt.Errorf("failed to unpack layer %s: %w", filepath.Base(layer), err)
+			}
+		}
+	}
+
+	data, err := json.Marshal(image.Config)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(tmp, "config.json"), data, 0o644); err != nil {
+		return nil, err
+	}
+	if err := removeSandboxDir(filepath.Join(tmp, "layout")); err != nil {
+		return nil, err
+	}
+	if err := removeSandboxDir(dir); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		return nil, err
+	}
+	return image, nil
+}
+
+// isSandboxImageLayout returns whether dir is an OCI image layout or a docker save archive
+func isSandboxImageLayout(dir string) bool {
+	for _, name := range []string{"oci-layout", "index.json", "manifest.json"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// readSandboxImageLayout returns the config and the files of the layers of the image of
+// the platform in an OCI image layout or a docker save archive
+func readSandboxImageLayout(dir string, platform string) (specs.ImageConfig, []string, error) {
+	file := func(name string) (string, error) {
+		if !filepath.IsLocal(name) {
+			return "", fmt.Errorf("invalid path '%s' in image layout", name)
+		}
+		return filepath.Join(dir, name), nil
+	}
+	readJSON := func(name string, v interface{}) error {
+		p, err := file(name)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(data, v)
+	}
+	blob := func(descriptor specs.Descriptor) (string, error) {
+		if err := descriptor.Digest.Validate(); err != nil {
+			return "", err
+		}
+		return path.Join("blobs", descriptor.Digest.Algorithm().String(), descriptor.Digest.Encoded()), nil
+	}
+
+	var configFile string
+	var layers []string
+	if _, err := os.Stat(filepath.Join(dir, "index.json")); err == nil {
+		index := specs.Index{}
+		if err := readJSON("index.json", &index); err != nil {
+			return specs.ImageConfig{}, nil, err
+		}
+		manifest, err := selectSandboxManifest(index, platform, blob, readJSON)
+		if err != nil {
+			return specs.ImageConfig{}, nil, err
+		}
+		if configFile, err = blob(manifest.Config); err != nil {
+			return specs.ImageConfig{}, nil, err
+		}
+		for _, layer := range manifest.Layers {
+			name, err := blob(layer)
+			if err != nil {
+				return specs.ImageConfig{}, nil, err
+			}
+			layers = append(layers, name)
+		}
+	} else {
+		manifests := []struct {
+			Config string
+			Layers []string
+		}{}
+		if err := readJSON("manifest.json", &manifests); err != nil {
+			return specs.ImageConfig{}, nil, err
+		}
+		if len(manifests) == 0 {
+			return specs.ImageConfig{}, nil, errors.New("manifest.json contains no image")
+		}
+		configFile, layers = manifests[0].Config, manifests[0].Layers
+	}
+
+	image := specs.Image{}
+	if err := readJSON(configFile, &image); err != nil {
+		return specs.ImageConfig{}, nil, err
+	}
+	files := make([]string, 0, len(layers))
+	for _, layer := range layers {
+		p, err := file(layer)
+		if err != nil {
+			return specs.ImageConfig{}, nil, err
+		}
+		files = append(files, p)
+	}
+	return image.Config, files, nil
+}
+
+// selectSandboxManifest returns the manifest of the platform in the index, nested indexes
+// of multi-platform images are searched too
+func selectSandboxManifest(index specs.Index, platform string, blob func(specs.Descriptor) (string, error), readJSON func(string, interface{}) error) (*specs.Manifest, error) {
+	goos, goarch, _ := strings.Cut(platform, "/")
+	if goos == "" {
+		goos = "linux"
+	}
+	if goarch == "" {
+		goarch = runtime.GOARCH
+	}
+	for _, descriptor := range index.Manifests {
+		if p := descriptor.Platform; p != nil && (p.OS != goos || p.Architecture != goarch) {
+			continue
+		}
+		name, err := blob(descriptor)
+		if err != nil {
+			return nil, err
+		}
+		switch descriptor.MediaType {
+		case specs.MediaTypeImageIndex, dockerManifestList:
+			nested := specs.Index{}
+			if err := readJSON(name, &nested); err != nil {
+				return nil, err
+			}
+			if manifest, err := selectSandboxManifest(nested, platform, blob, readJSON); err == nil {
+				return manifest, nil
+			}
+		default:
+			manifest := &specs.Manifest{}
+			if err := readJSON(name, manifest); err != nil {
+				return nil, err
+			}
+			return manifest, nil
+		}
+	}
+	return nil, fmt.Errorf("no image for platform %s/%s", goos, goarch)
+}
+
+// extractSandboxArchive extracts a tar archive compressed with gzip, zstd or not at all to dest
+func extractSandboxArchive(file string, dest string, whiteouts bool) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = bufio.NewReader(f)
+	magic, _ := r.(*bufio.Reader).Peek(4)
+	switch {
+	case bytes.HasPrefix(magic, []byte{0x1f, 0x8b}):
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	case bytes.HasPrefix(magic, []byte{0x28, 0xb5, 0x2f, 0xfd}):
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		r = zr
+	}
+
+	if err := os.MkdirAll(dest, 0o755); err != nil {
+		return err
+	}
+	root, err := os.OpenRoot(dest)
+	if err != nil {
+		return err
+	}
+	defer root.Close()
+	return extractSandboxTar(root, r, whiteouts)
+}
+
+// extractSandboxTar extracts the directories, regular files, symlinks and hard links of a tar
+// stream to root. With whiteouts the stream is a layer of an image deleting the files of the
+// layers below. Owners are not kept, the unprivileged user owns every file of the sandbox.
+func extractSandboxTar(root *os.Root, r io.Reader, whiteouts bool) error {
+	added := map[string]bool{}
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		name := path.Clean(strings.TrimPrefix(header.Name, "/"))
+		if name == "." {
+			continue
+		}
+		dir, base := path.Split(name)
+		dir = path.Clean(dir)
+		if whiteouts && strings.HasPrefix(base, sandboxWhiteout) {
+			if base == sandboxOpaque {
+				err = removeSandboxDirContent(root, dir, added)
+			} else {
+				err = root.RemoveAll(path.Join(dir, strings.TrimPrefix(base, sandboxWhiteout)))
+			}
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if err := root.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+		added[name] = true
+
+		mode := header.FileInfo().Mode().Perm()
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if info, err := root.Lstat(name); err == nil && !info.IsDir() {
+				if err := root.Remove(name); err != nil {
+					return err
+				}
+			}
+			// the owner keeps the permission to extract the files of the directory
+			if err := root.MkdirAll(name, mode|0o700); err != nil {
+				return err
+			}
+			if err := root.Chmod(name, mode|0o700); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := root.RemoveAll(name); err != nil {
+				return err
+			}
+			f, err := root.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_EXCL, mode)
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(f, tr); err != nil {
+				f.Close()
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+			if err := root.Chmod(name, mode); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := root.RemoveAll(name); err != nil {
+				return err
+			}
+			if err := root.Symlink(header.Linkname, name); err != nil {
+				return err
+			}
+			continue
+		case tar.TypeLink:
+			if err := root.RemoveAll(name); err != nil {
+				return err
+			}
+			if err := root.Link(path.Clean(strings.TrimPrefix(header.Linkname, "/")), name); err != nil {
+				return err
+			}
+			continue
+		default:
+			// devices and fifos can not be created without privileges
+			continue
+		}
+		if err := root.Chtimes(name, header.ModTime, header.ModTime); err != nil {
+			return err
+		}
+	}
+}
+
+// removeSandboxDirContent removes the files of dir of the layers below the current layer
+func removeSandboxDirContent(root *os.Root, dir string, added map[string]bool) error {
+	f, err := root.Open(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	names, err := f.Readdirnames(-1)
+	f.Close()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if p := path.Join(dir, name); !added[p] {
+			if err := root.RemoveAll(p); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+//go:build linux
+
+package container
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testTarEntry struct {
+	name     string
+	body     string
+	typeflag byte
+	linkname string
+}
+
+func testTar(t *testing.T, compress bool, entries ...testTarEntry) []byte {
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	for _, entry := range entries {
+		header := &tar.Header{Name: entry.name, Typeflag: entry.typeflag, Linkname: entry.linkname, Mode: 0o644, Size: int64(len(entry.body))}
+		if entry.typeflag == tar.TypeDir {
+			header.Mode = 0o755
+		}
+		require.NoError(t, tw.WriteHeader(header))
+		_, err := tw.Write([]byte(entry.body))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	if !compress {
+		return buf.Bytes()
+	}
+	gzBuf := &bytes.Buffer{}
+	gz := gzip.NewWriter(gzBuf)
+	_, err := gz.Write(buf.Bytes())
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	return gzBuf.Bytes()
+}
+
+// writeTestImageLayout writes an OCI image layout of the layers to dir
+func writeTestImageLayout(t *testing.T, dir string, config specs.Image, layers ...[]byte) {
+	writeBlob := func(mediaType string, data []byte) specs.Descriptor {
+		d := digest.FromBytes(data)
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "blobs", "sha256"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "blobs", "sha256", d.Encoded()), data, 0o644))
+		return specs.Descriptor{MediaType: mediaType, Digest: d, Size: int64(len(data))}
+	}
+	marshal := func(v interface{}) []byte {
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+		return data
+	}
+
+	manifest := specs.Manifest{Config: writeBlob(specs.MediaTypeImageConfig, marshal(config))}
+	for _, layer := range layers {
+		manifest.Layers = append(manifest.Layers, writeBlob(specs.MediaTypeImageLayerGzip, layer))
+	}
+	other := writeBlob(specs.MediaTypeImageManifest, marshal(specs.Manifest{}))
+	other.Platform = &specs.Platform{OS: "windows", Architecture: runtime.GOARCH}
+	current := writeBlob(specs.MediaTypeImageManifest, marshal(manifest))
+	current.Platform = &specs.Platform{OS: "linux", Architecture: runtime.GOARCH}
+	index := specs.Index{Manifests: []specs.Descriptor{other, current}}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "index.json"), marshal(index), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "oci-layout"), []byte(`{"imageLayoutVersion":"1.0.0"}`), 0o644))
+}
+
+func TestLoadSandboxImage(t *testing.T) {
+	ctx := context.Background()
+	layout := t.TempDir()
+	writeTestImageLayout(t, layout,
+		specs.Image{Config: specs.ImageConfig{Env: []string{"PATH=/usr/local/bin:/usr/bin"}}},
+		testTar(t, true,
+			testTarEntry{name: "etc/", typeflag: tar.TypeDir},
+			testTarEntry{name: "etc/os-release", body: "ID=test\n", typeflag: tar.TypeReg},
+			testTarEntry{name: "etc/removed", body: "removed", typeflag: tar.TypeReg},
+			testTarEntry{name: "opt/old/file", body: "old", typeflag: tar.TypeReg},
+			testTarEntry{name: "usr/bin/tool", body: "#!/bin/sh", typeflag: tar.TypeReg},
+			testTarEntry{name: "bin", typeflag: tar.TypeSymlink, linkname: "usr/bin"},
+		),
+		testTar(t, false,
+			testTarEntry{name: "etc/.wh.removed", typeflag: tar.TypeReg},
+			testTarEntry{name: "opt/new", body: "new", typeflag: tar.TypeReg},
+			testTarEntry{name: "opt/.wh..wh..opq", typeflag: tar.TypeReg},
+			testTarEntry{name: "usr/bin/tool2", typeflag: tar.TypeLink, linkname: "usr/bin/tool"},
+		),
+	)
+
+	imagesDir := t.TempDir()
+	image, err := loadSandboxImage(ctx, layout, imagesDir, "", false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"PATH=/usr/local/bin:/usr/bin"}, image.Config.Env)
+	assert.FileExists(t, filepath.Join(image.Rootfs, "etc", "os-release"))
+	assert.NoFileExists(t, filepath.Join(image.Rootfs, "etc", "removed"))
+	assert.NoDirExists(t, filepath.Join(image.Rootfs, "opt", "old"))
+	assert.FileExists(t, filepath.Join(image.Rootfs, "opt", "new"))
+	link, err := os.Readlink(filepath.Join(image.Rootfs, "bin"))
+	require.NoError(t, err)
+	assert.Equal(t, "usr/bin", link)
+	content, err := os.ReadFile(filepath.Join(image.Rootfs, "usr", "bin", "tool2"))
+	require.NoError(t, err)
+	assert.Equal(t, "#!/bin/sh", string(content))
+
+	// the unpacked image is reused
+	require.NoError(t, os.WriteFile(filepath.Join(image.Rootfs, "marker"), nil, 0o644))
+	reused, err := loadSandboxImage(ctx, layout, imagesDir, "", false)
+	require.NoError(t, err)
+	assert.Equal(t, image.Rootfs, reused.Rootfs)
+	assert.FileExists(t, filepath.Join(reused.Rootfs, "marker"))
+	forced, err := loadSandboxImage(ctx, layout, imagesDir, "", true)
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(forced.Rootfs, "marker"))
+
+	_, err = loadSandboxImage(ctx, layout, imagesDir, "linux/s390x", false)
+	assert.ErrorContains(t, err, "no image for platform linux/s390x")
+
+	// a tarball of a root filesystem and a directory with a root filesystem
+	rootfsTar := filepath.Join(t.TempDir(), "rootfs.tar.gz")
+	require.NoError(t, os.WriteFile(rootfsTar, testTar(t, true, testTarEntry{name: "etc/hostname", body: "sandbox", typeflag: tar.TypeReg}), 0o644))
+	image, err = loadSandboxImage(ctx, rootfsTar, imagesDir, "", false)
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(image.Rootfs, "etc", "hostname"))
+	image, err = loadSandboxImage(ctx, image.Rootfs, imagesDir, "", false)
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(image.Rootfs, "etc", "hostname"))
+
+	image, err = loadSandboxImage(ctx, SandboxHostRoot, imagesDir, "", false)
+	require.NoError(t, err)
+	assert.Equal(t, "/", image.Rootfs)
+}
+
+func TestExtractSandboxTarRejectsEscapes(t *testing.T) {
+	dest := t.TempDir()
+	root, err := os.OpenRoot(dest)
+	require.NoError(t, err)
+	defer root.Close()
+	err = extractSandboxTar(root, bytes.NewReader(testTar(t, false,
+		testTarEntry{name: "escape", typeflag: tar.TypeSymlink, linkname: "/"},
+		testTarEntry{name: "escape/etc/passwd", body: "root", typeflag: tar.TypeReg},
+	)), true)
+	assert.Error(t, err)
+}
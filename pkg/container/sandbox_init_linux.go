@@ -0,0 +1,397 @@
+//go:build linux
+
+package container
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	securejoin "github.com/cyphar/filepath-securejoin"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// sandboxInitName is the name act runs itself with as first process of a sandbox
+	sandboxInitName  = "act-sandbox-init"
+	sandboxConfigEnv = "ACT_SANDBOX_CONFIG"
+)
+
+// modes of the first process of a sandbox
+const (
+	sandboxModeCheck   = "check"   // set up the sandbox and exit
+	sandboxModeExec    = "exec"    // run Command
+	sandboxModeExtract = "extract" // extract the tar stream of stdin to Path
+	sandboxModeArchive = "archive" // write a tar archive of Path to stdout
+)
+
+// sandboxConfig is the sandbox of a command, it is passed to the first process of the sandbox
+type sandboxConfig struct {
+	Root           string         `json:"root"`  // mount point of the root filesystem
+	Lower          string         `json:"lower"` // read-only lower layer of the root filesystem, / of the host or an unpacked image
+	Upper          string         `json:"upper"`
+	Work           string         `json:"work"`
+	Hide           string         `json:"hide,omitempty"` // directory of the host hidden from the host root
+	Mounts         []sandboxMount `json:"mounts,omitempty"`
+	Hostname       string         `json:"hostname"`
+	IsolateNetwork bool           `json:"isolateNetwork,omitempty"`
+	Mode           string         `json:"mode"`
+	Command        []string       `json:"command,omitempty"`
+	Env            []string       `json:"env,omitempty"`
+	Dir            string         `json:"dir,omitempty"`
+	Path           string         `json:"path,omitempty"`
+	Clean          bool           `json:"clean,omitempty"` // remove Path before extracting
+}
+
+// sandboxMount is a directory or file of the host bound into the sandbox
+type sandboxMount struct {
+	Source   string `json:"source"`
+	Target   string `json:"target"`
+	ReadOnly bool   `json:"readOnly,omitempty"`
+}
+
+func init() {
+	if len(os.Args) > 0 && os.Args[0] == sandboxInitName {
+		runSandboxInit()
+	}
+}
+
+// runSandboxInit sets up the root filesystem of the sandbox in the new namespaces and
+// runs the command or copies files, errors of the setup are written to fd 3
+func runSandboxInit() {
+	setupErrors := os.NewFile(3, "setup-errors")
+	fail := func(err error) {
+		fmt.Fprintln(setupErrors, err)
+		os.Exit(125)
+	}
+
+	cfg := &sandboxConfig{}
+	if err := json.Unmarshal([]byte(os.Getenv(sandboxConfigEnv)), cfg); err != nil {
+		fail(fmt.Errorf("invalid sandbox config: %w", err))
+	}
+	switch cfg.Mode {
+	case sandboxModeCheck, sandboxModeExec, sandboxModeExtract, sandboxModeArchive:
+	default:
+		fail(fmt.Errorf("unknown sandbox mode '%s'", cfg.Mode))
+	}
+	if err := setupSandbox(cfg); err != nil {
+		fail(err)
+	}
+
+	var err error
+	switch cfg.Mode {
+	case sandboxModeCheck:
+		os.Exit(0)
+	case sandboxModeExec:
+		err = execSandboxCommand(cfg)
+		if errors.Is(err, exec.ErrNotFound) || errors.Is(err, fs.ErrNotExist) {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(127)
+		}
+		fail(err)
+	case sandboxModeExtract:
+		setupErrors.Close()
+		err = extractSandboxPath(cfg, os.Stdin)
+	case sandboxModeArchive:
+		setupErrors.Close()
+		err = archiveSandboxPath(cfg, os.Stdout)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// setupSandbox mounts the root filesystem, the special filesystems and the mounts of the
+// sandbox and makes the root filesystem the root of the process
+func setupSandbox(cfg *sandboxConfig) error {
+	if err := unix.Mount("", "/", "", unix.MS_REC|unix.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("failed to make the mounts private: %w", err)
+	}
+	if cfg.Lower == "/" {
+		if err := unix.Mount("tmpfs", cfg.Root, "tmpfs", 0, "mode=0755"); err != nil {
+			return fmt.Errorf("failed to mount the root filesystem: %w", err)
+		}
+		if err := overlaySandboxHostDir(cfg, "/", cfg.Root); err != nil {
+			return err
+		}
+	} else if err := mountSandboxOverlay(cfg.Lower, cfg.Upper, cfg.Work, cfg.Root); err != nil {
+		return err
+	}
+
+	if err := mountSandboxProc(cfg.Root); err != nil {
+		return err
+	}
+	mounts := []sandboxMount{{Source: "/dev", Target: "/dev"}, {Source: "/sys", Target: "/sys", ReadOnly: true}}
+	if cfg.Lower != "/" && !cfg.IsolateNetwork {
+		// the name resolution of the host, the host root has them already
+		for _, file := range []string{"/etc/resolv.conf", "/etc/hosts"} {
+			if _, err := os.Stat(file); err == nil {
+				mounts = append(mounts, sandboxMount{Source: file, Target: file, ReadOnly: true})
+			}
+		}
+	}
+	for _, mount := range append(mounts, cfg.Mounts...) {
+		if err := bindSandboxMount(cfg.Root, mount); err != nil {
+			return err
+		}
+	}
+
+	if cfg.IsolateNetwork {
+		if err := sandboxLoopbackUp(); err != nil {
+			return fmt.Errorf("failed to set up the loopback interface: %w", err)
+		}
+	}
+	if err := unix.Sethostname([]byte(cfg.Hostname)); err != nil {
+		return fmt.Errorf("failed to set the hostname: %w", err)
+	}
+	return pivotSandboxRoot(cfg.Root)
+}
+
+// overlaySandboxHostDir mounts overlays of the directories of hostDir at target or binds
+// them read-only if they can not be overlaid. The directories containing the hidden
+// directory are recreated to leave it out, their files are bound read-only.
+func overlaySandboxHostDir(cfg *sandboxConfig, hostDir string, target string) error {
+	entries, err := os.ReadDir(hostDir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		src := filepath.Join(hostDir, entry.Name())
+		dst := filepath.Join(target, entry.Name())
+		switch {
+		case src == cfg.Hide:
+			continue
+		case src == "/proc" || src == "/dev" || src == "/sys":
+			// mounted after the root filesystem
+			continue
+		case entry.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(src)
+			if err != nil {
+				return err
+			}
+			if err := os.Symlink(link, dst); err != nil {
+				return err
+			}
+		case !entry.IsDir():
+			// files next to the hidden directory are best effort, sockets of other users may not be bound
+			if f, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY, 0o644); err == nil {
+				f.Close()
+				if unix.Mount(src, dst, "", unix.MS_BIND, "") == nil && remountSandboxReadOnly(dst) != nil {
+					_ = unix.Unmount(dst, unix.MNT_DETACH)
+				}
+			}
+		case strings.HasPrefix(cfg.Hide, src+"/"):
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			if err := os.Mkdir(dst, info.Mode().Perm()|0o700); err != nil {
+				return err
+			}
+			if err := overlaySandboxHostDir(cfg, src, dst); err != nil {
+				return err
+			}
+		default:
+			if err := os.Mkdir(dst, 0o755); err != nil {
+				return err
+			}
+			if err := mountSandboxOverlay(src, filepath.Join(cfg.Upper, src), filepath.Join(cfg.Work, src), dst); err != nil {
+				// directories with mounts locked by the user namespace can not be a lower layer
+				if err := bindSandboxMount(dst, sandboxMount{Source: src, Target: "/", ReadOnly: true}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// mountSandboxOverlay mounts an overlay of the read-only lower directory at target
+func mountSandboxOverlay(lower, upper, work, target string) error {
+	for _, dir := range []string{upper, work} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	for _, dir := range []string{lower, upper, work} {
+		if strings.ContainsAny(dir, `,:\`) {
+			return fmt.Errorf("the path '%s' of an overlay contains one of the unsupported characters ,:\\", dir)
+		}
+	}
+	options := fmt.Sprintf("lowerdir=%s,upperdir=%s,workdir=%s", lower, upper, work)
+	if err := unix.Mount("overlay", target, "overlay", 0, options); err != nil {
+		return fmt.Errorf("failed to mount the overlay of %s: %w", lower, err)
+	}
+	return nil
+}
+
+// mountSandboxProc mounts the proc filesystem of the PID namespace, a partly masked
+// /proc of the host prevents that and is bound instead
+func mountSandboxProc(root string) error {
+	target, err := securejoin.SecureJoin(root, "/proc")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(target, 0o555); err != nil {
+		return err
+	}
+	if err := unix.Mount("proc", target, "proc", unix.MS_NOSUID|unix.MS_NODEV|unix.MS_NOEXEC, ""); err == nil {
+		return nil
+	}
+	if err := unix.Mount("/proc", target, "", unix.MS_BIND|unix.MS_REC, ""); err != nil {
+		return fmt.Errorf("failed to mount /proc: %w", err)
+	}
+	return nil
+}
+
+// bindSandboxMount binds the source of the host to the target in root, symlinks of the
+// target are resolved in root
+func bindSandboxMount(root string, mount sandboxMount) error {
+	info, err := os.Stat(mount.Source)
+	if err != nil {
+		return err
+	}
+	target, err := securejoin.SecureJoin(root, mount.Target)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		if err := os.MkdirAll(target, 0o755); err != nil {
+			return err
+		}
+	} else {
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return err
+		}
+		f.Close()
+	}
+	if err := unix.Mount(mount.Source, target, "", unix.MS_BIND|unix.MS_REC, ""); err != nil {
+		return fmt.Errorf("failed to bind %s to %s: %w", mount.Source, mount.Target, err)
+	}
+	if mount.ReadOnly {
+		if err := remountSandboxReadOnly(target); err != nil {
+			return fmt.Errorf("failed to bind %s read-only: %w", mount.Source, err)
+		}
+	}
+	return nil
+}
+
+// remountSandboxReadOnly makes a bind mount read-only, the flags of the mount locked
+// by the user namespace are kept
+func remountSandboxReadOnly(target string) error {
+	var st unix.Statfs_t
+	if err := unix.Statfs(target, &st); err != nil {
+		return err
+	}
+	flags := uintptr(unix.MS_BIND | unix.MS_REMOUNT | unix.MS_RDONLY)
+	for stFlag, msFlag := range map[int64]uintptr{
+		unix.ST_NOSUID:     unix.MS_NOSUID,
+		unix.ST_NODEV:      unix.MS_NODEV,
+		unix.ST_NOEXEC:     unix.MS_NOEXEC,
+		unix.ST_NOATIME:    unix.MS_NOATIME,
+		unix.ST_NODIRATIME: unix.MS_NODIRATIME,
+		unix.ST_RELATIME:   unix.MS_RELATIME,
+	} {
+		if int64(st.Flags)&stFlag != 0 {
+			flags |= msFlag
+		}
+	}
+	return unix.Mount("", target, "", flags, "")
+}
+
+// sandboxLoopbackUp brings up the loopback interface of a new network namespace
+func sandboxLoopbackUp() error {
+	fd, err := unix.Socket(unix.AF_INET, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+	ifr, err := unix.NewIfreq("lo")
+	if err != nil {
+		return err
+	}
+	ifr.SetUint16(unix.IFF_UP | unix.IFF_LOOPBACK | unix.IFF_RUNNING)
+	return unix.IoctlIfreq(fd, unix.SIOCSIFFLAGS, ifr)
+}
+
+// pivotSandboxRoot makes root the root of the mount namespace and detaches the old root
+func pivotSandboxRoot(root string) error {
+	if err := os.Chdir(root); err != nil {
+		return err
+	}
+	if err := unix.PivotRoot(".", "."); err != nil {
+		return fmt.Errorf("failed to pivot the root: %w", err)
+	}
+	if err := unix.Unmount(".", unix.MNT_DETACH); err != nil {
+		return fmt.Errorf("failed to detach the old root: %w", err)
+	}
+	return os.Chdir("/")
+}
+
+// execSandboxCommand replaces the first process of the sandbox with the command
+func execSandboxCommand(cfg *sandboxConfig) error {
+	if len(cfg.Command) == 0 {
+		return errors.New("no command to run")
+	}
+	// like the working directory of a docker container
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return err
+	}
+	if err := os.Chdir(cfg.Dir); err != nil {
+		return err
+	}
+	os.Clearenv()
+	for _, kv := range cfg.Env {
+		k, v, _ := strings.Cut(kv, "=")
+		os.Setenv(k, v)
+	}
+	file, err := exec.LookPath(cfg.Command[0])
+	if err != nil {
+		return err
+	}
+	syscall.CloseOnExec(3)
+	return syscall.Exec(file, cfg.Command, cfg.Env)
+}
+
+// extractSandboxPath extracts the tar stream to the path of the config
+func extractSandboxPath(cfg *sandboxConfig, tarStream io.Reader) error {
+	if cfg.Clean {
+		if err := os.RemoveAll(cfg.Path); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(cfg.Path, 0o755); err != nil {
+		return err
+	}
+	root, err := os.OpenRoot(cfg.Path)
+	if err != nil {
+		return err
+	}
+	defer root.Close()
+	return extractSandboxTar(root, tarStream, false)
+}
+
+// archiveSandboxPath writes a tar archive of the path of the config like the host environment
+func archiveSandboxPath(cfg *sandboxConfig, w io.Writer) error {
+	archive, err := (&HostEnvironment{}).GetContainerArchive(context.Background(), cfg.Path)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+	_, err = io.Copy(w, archive)
+	return err
+}
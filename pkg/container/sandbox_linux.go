@@ -0,0 +1,428 @@
+//go:build linux
+
+package container
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/kballard/go-shellquote"
+	"github.com/spf13/pflag"
+
+	"github.com/nektos/act/pkg/common"
+)
+
+// BackendSandbox runs jobs in linux namespaces of an unprivileged user without docker
+const BackendSandbox = "sandbox"
+
+// SandboxHostRoot is the image of the sandbox backend using the root filesystem of the host
+const SandboxHostRoot = "host"
+
+const sandboxLogPrefix = "  \U0001F9F1  "
+
+func init() {
+	RegisterBackend(BackendSandbox, BackendFunc(newSandboxEnvironment))
+}
+
+// sandboxEnvironment runs every command of a job in new user, mount, PID, UTS, IPC and
+// optionally network namespaces. The root filesystem is an overlay with the unpacked image
+// or the root filesystem of the host as read-only lower layer, the changes of the job are
+// written to an upper layer of the job. Processes do not outlive the command they belong to.
+type sandboxEnvironment struct {
+	LinuxContainerEnvironmentExtensions
+	input          *BackendInput
+	dir            string // the layers and root mount point of the job
+	sandboxDir     string // the images, volumes and jobs of the sandbox backend
+	image          *sandboxImage
+	isolateNetwork bool
+	stdout         io.Writer
+	stderr         io.Writer
+}
+
+// newSandboxEnvironment returns the sandbox of a job, the image is an OCI image layout, a
+// tarball of an OCI image layout, of docker save or of a root filesystem, a directory with
+// a root filesystem or host. The network is isolated with the container option --network none.
+func newSandboxEnvironment(_ context.Context, input *BackendInput) (ExecutionsEnvironment, error) {
+	if len(input.Services) > 0 {
+		return nil, errors.New("the sandbox backend does not support services")
+	}
+	isolateNetwork, err := sandboxIsolateNetwork(input.Options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the container options: %w", err)
+	}
+	sandboxDir := filepath.Join(input.CacheDir, "sandbox")
+	return &sandboxEnvironment{
+		input:          input,
+		dir:            filepath.Join(sandboxDir, "jobs", input.Name),
+		sandboxDir:     sandboxDir,
+		isolateNetwork: isolateNetwork,
+		stdout:         input.Stdout,
+		stderr:         input.Stderr,
+	}, nil
+}
+
+// sandboxIsolateNetwork returns whether the options of the job container disable the network
+func sandboxIsolateNetwork(options string) (bool, error) {
+	args, err := shellquote.Split(options)
+	if err != nil {
+		return false, err
+	}
+	flags := pflag.NewFlagSet("options", pflag.ContinueOnError)
+	flags.ParseErrorsAllowlist.UnknownFlags = true
+	network := flags.String("network", "", "")
+	net := flags.String("net", "", "")
+	if err := flags.Parse(args); err != nil {
+		return false, err
+	}
+	return *network == "none" || *net == "none", nil
+}
+
+// Pull unpacks the image, unpacked images are reused unless forcePull is set
+func (e *sandboxEnvironment) Pull(forcePull bool) common.Executor {
+	return func(ctx context.Context) error {
+		common.Logger(ctx).Infof("%ssandbox unpack image=%s", sandboxLogPrefix, e.input.Image)
+		if common.Dryrun(ctx) {
+			return nil
+		}
+		image, err := loadSandboxImage(ctx, e.input.Image, filepath.Join(e.sandboxDir, "images"), e.input.Platform, forcePull)
+		if err != nil {
+			return fmt.Errorf("failed to unpack image %s: %w", e.input.Image, err)
+		}
+		e.image = image
+		return nil
+	}
+}
+
+func (e *sandboxEnvironment) Create(_ []string, _ []string) common.Executor {
+	return func(ctx context.Context) error {
+		common.Logger(ctx).Infof("%ssandbox create %s", sandboxLogPrefix, e.dir)
+		if common.Dryrun(ctx) {
+			return nil
+		}
+		for _, dir := range []string{"upper", "work", "root"} {
+			if err := os.MkdirAll(filepath.Join(e.dir, dir), 0o755); err != nil {
+				return err
+			}
+		}
+		for name := range e.input.Mounts {
+			if err := os.MkdirAll(e.volumeDir(name), 0o755); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// Start checks that the namespaces and the root filesystem of the sandbox can be set up
+func (e *sandboxEnvironment) Start(_ bool) common.Executor {
+	return func(ctx context.Context) error {
+		common.Logger(ctx).Infof("%ssandbox start %s", sandboxLogPrefix, e.input.Name)
+		if common.Dryrun(ctx) {
+			return nil
+		}
+		cfg := e.config(sandboxModeCheck)
+		cfg.Dir = e.input.WorkingDir
+		if err := e.run(e.command(ctx, cfg)); err != nil {
+			return fmt.Errorf("%w, the sandbox backend requires unprivileged user namespaces and overlay mounts", err)
+		}
+		return nil
+	}
+}
+
+func (e *sandboxEnvironment) volumeDir(name string) string {
+	return filepath.Join(e.sandboxDir, "volumes", name)
+}
+
+// config returns the sandbox config of the job without a command
+func (e *sandboxEnvironment) config(mode string) *sandboxConfig {
+	cfg := &sandboxConfig{
+		Root:           filepath.Join(e.dir, "root"),
+		Lower:          e.image.Rootfs,
+		Upper:          filepath.Join(e.dir, "upper"),
+		Work:           filepath.Join(e.dir, "work"),
+		Hostname:       trimToLen(e.input.Name, 63),
+		IsolateNetwork: e.isolateNetwork,
+		Mode:           mode,
+	}
+	if cfg.Lower == "/" {
+		cfg.Hide = e.sandboxDir
+	}
+
+	names := make([]string, 0, len(e.input.Mounts))
+	for name := range e.input.Mounts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cfg.Mounts = append(cfg.Mounts, sandboxMount{Source: e.volumeDir(name), Target: e.input.Mounts[name]})
+	}
+	for _, bind := range e.input.Binds {
+		parts := strings.Split(bind, ":")
+		if len(parts) < 2 {
+			continue
+		}
+		if _, err := os.Stat(parts[0]); err != nil {
+			// like the docker socket of a host without docker
+			continue
+		}
+		cfg.Mounts = append(cfg.Mounts, sandboxMount{
+			Source:   parts[0],
+			Target:   parts[1],
+			ReadOnly: len(parts) > 2 && strings.Contains(parts[2], "ro"),
+		})
+	}
+	return cfg
+}
+
+// command returns the command starting the sandbox, the first process of the sandbox is
+// act itself setting up the mounts before it runs the command
+func (e *sandboxEnvironment) command(ctx context.Context, cfg *sandboxConfig) *exec.Cmd {
+	data, _ := json.Marshal(cfg)
+	cmd := exec.CommandContext(ctx, "/proc/self/exe")
+	cmd.Args = []string{sandboxInitName}
+	cmd.Env = []string{sandboxConfigEnv + "=" + string(data)}
+	cloneflags := syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS | syscall.CLONE_NEWPID | syscall.CLONE_NEWUTS | syscall.CLONE_NEWIPC
+	if cfg.IsolateNetwork {
+		cloneflags |= syscall.CLONE_NEWNET
+	}
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags:                 uintptr(cloneflags),
+		UidMappings:                []syscall.SysProcIDMap{{ContainerID: 0, HostID: os.Getuid(), Size: 1}},
+		GidMappings:                []syscall.SysProcIDMap{{ContainerID: 0, HostID: os.Getgid(), Size: 1}},
+		GidMappingsEnableSetgroups: false,
+	}
+	return cmd
+}
+
+// run runs the sandbox command and returns the errors of setting up the sandbox, the first
+// process of the sandbox writes them to the pipe of fd 3
+func (e *sandboxEnvironment) run(cmd *exec.Cmd) error {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	cmd.ExtraFiles = []*os.File{w}
+	err = cmd.Start()
+	w.Close()
+	if err != nil {
+		r.Close()
+		return fmt.Errorf("failed to start the sandbox: %w", err)
+	}
+	setupErr, _ := io.ReadAll(r)
+	r.Close()
+	err = cmd.Wait()
+	if len(setupErr) > 0 {
+		return fmt.Errorf("failed to set up the sandbox: %s", strings.TrimSpace(string(setupErr)))
+	}
+	return err
+}
+
+// env returns the env of the commands, the env of the image and the job container
+// overridden by the env of the command
+func (e *sandboxEnvironment) env(env map[string]string) []string {
+	merged := map[string]string{}
+	for _, kv := range append(append([]string{}, e.image.Config.Env...), e.input.Env...) {
+		k, v, _ := strings.Cut(kv, "=")
+		merged[k] = v
+	}
+	for k, v := range env {
+		merged[k] = v
+	}
+	if merged["PATH"] == "" {
+		merged["PATH"] = e.DefaultPathVariable()
+	}
+	keys := make([]string, 0, len(merged))
+	for k := range merged {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	result := make([]string, 0, len(keys))
+	for _, k := range keys {
+		result = append(result, k+"="+merged[k])
+	}
+	return result
+}
+
+func (e *sandboxEnvironment) Exec(command []string, env map[string]string, user, workdir string) common.Executor {
+	return func(ctx context.Context) error {
+		logger := common.Logger(ctx)
+		logger.Infof("%ssandbox exec cmd=[%s] user=%s workdir=%s", sandboxLogPrefix, strings.Join(command, " "), user, workdir)
+		if common.Dryrun(ctx) {
+			return nil
+		}
+		if err := checkExecUser(BackendSandbox, user, "0", "root"); err != nil {
+			return err
+		}
+		if workdir == "" {
+			workdir = e.input.WorkingDir
+		} else if !path.IsAbs(workdir) {
+			workdir = path.Join(e.input.WorkingDir, workdir)
+		}
+		if workdir == "" {
+			workdir = "/"
+		}
+
+		cfg := e.config(sandboxModeExec)
+		cfg.Command = command
+		cfg.Env = e.env(env)
+		cfg.Dir = workdir
+		cmd := e.command(ctx, cfg)
+		cmd.Stdout = e.stdout
+		cmd.Stderr = e.stderr
+		err := e.run(cmd)
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return execExitError(exitErr.ExitCode())
+		}
+		return err
+	}
+}
+
+// extract extracts the tar stream to destPath in the sandbox, the content of destPath is
+// removed first if clean is set
+func (e *sandboxEnvironment) extract(ctx context.Context, destPath string, tarStream io.Reader, clean bool) error {
+	cfg := e.config(sandboxModeExtract)
+	cfg.Path = destPath
+	cfg.Clean = clean
+	cmd := e.command(ctx, cfg)
+	stderr := &bytes.Buffer{}
+	cmd.Stdin = tarStream
+	cmd.Stderr = stderr
+	if err := e.run(cmd); err != nil {
+		return fmt.Errorf("failed to copy content to the sandbox: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+func (e *sandboxEnvironment) Copy(destPath string, files ...*FileEntry) common.Executor {
+	return func(ctx context.Context) error {
+		if common.Dryrun(ctx) {
+			return nil
+		}
+		buf, err := newFilesArchive(ctx, files...)
+		if err != nil {
+			return err
+		}
+		return e.extract(ctx, destPath, buf, false)
+	}
+}
+
+func (e *sandboxEnvironment) CopyTarStream(ctx context.Context, destPath string, tarStream io.Reader) error {
+	if common.Dryrun(ctx) {
+		return nil
+	}
+	return e.extract(ctx, destPath, tarStream, true)
+}
+
+func (e *sandboxEnvironment) CopyDir(destPath string, srcPath string, useGitIgnore bool) common.Executor {
+	return func(ctx context.Context) error {
+		common.Logger(ctx).Infof("%ssandbox cp src=%s dst=%s", sandboxLogPrefix, srcPath, destPath)
+		if common.Dryrun(ctx) {
+			return nil
+		}
+		pr, pw := io.Pipe()
+		go func() {
+			pw.CloseWithError(writeDirArchive(ctx, pw, srcPath, "", useGitIgnore))
+		}()
+		err := e.extract(ctx, destPath, pr, false)
+		pr.Close()
+		return err
+	}
+}
+
+func (e *sandboxEnvironment) GetContainerArchive(ctx context.Context, srcPath string) (io.ReadCloser, error) {
+	if common.Dryrun(ctx) {
+		return nil, fmt.Errorf("DRYRUN is not supported in GetContainerArchive")
+	}
+	cfg := e.config(sandboxModeArchive)
+	cfg.Path = path.Clean(srcPath)
+	return newExecArchive(func(stdout io.Writer) error {
+		stderr := &bytes.Buffer{}
+		cmd := e.command(ctx, cfg)
+		cmd.Stdout = stdout
+		cmd.Stderr = stderr
+		if err := e.run(cmd); err != nil {
+			return fmt.Errorf("failed to copy content from the sandbox: %w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return nil
+	})
+}
+
+func (e *sandboxEnvironment) UpdateFromEnv(srcPath string, env *map[string]string) common.Executor {
+	return parseEnvFile(e, srcPath, env).IfNot(common.Dryrun)
+}
+
+// UpdateFromImageEnv adds the env of the image, the host root has no env of its own
+func (e *sandboxEnvironment) UpdateFromImageEnv(env *map[string]string) common.Executor {
+	envMap := *env
+	return func(_ context.Context) error {
+		if e.image == nil {
+			return nil
+		}
+		mergeImageEnv(envMap, e.image.Config.Env)
+		return nil
+	}
+}
+
+// Remove removes the layers of the job and the volumes named after the job
+func (e *sandboxEnvironment) Remove() common.Executor {
+	return func(ctx context.Context) error {
+		common.Logger(ctx).Debugf("%ssandbox rm %s", sandboxLogPrefix, e.dir)
+		if common.Dryrun(ctx) {
+			return nil
+		}
+		if err := removeSandboxDir(e.dir); err != nil {
+			return err
+		}
+		for name := range e.input.Mounts {
+			if strings.HasPrefix(name, e.input.Name) {
+				if err := removeSandboxDir(e.volumeDir(name)); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// removeSandboxDir removes dir, also if the job left directories without write permission
+func removeSandboxDir(dir string) error {
+	if err := os.RemoveAll(dir); err == nil {
+		return nil
+	}
+	_ = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err == nil && d.IsDir() {
+			_ = os.Chmod(p, 0o700)
+		}
+		return nil
+	})
+	return os.RemoveAll(dir)
+}
+
+func (e *sandboxEnvironment) Close() common.Executor {
+	return func(_ context.Context) error {
+		return nil
+	}
+}
+
+func (e *sandboxEnvironment) GetHealth(_ context.Context) Health {
+	return HealthHealthy
+}
+
+func (e *sandboxEnvironment) ReplaceLogWriter(stdout io.Writer, stderr io.Writer) (io.Writer, io.Writer) {
+	oldStdout, oldStderr := e.stdout, e.stderr
+	e.stdout, e.stderr = stdout, stderr
+	return oldStdout, oldStderr
+}
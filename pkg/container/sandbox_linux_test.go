@@ -0,0 +1,83 @@
+//go:build linux
+
+package container
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSandboxIsolateNetwork(t *testing.T) {
+	for options, isolate := range map[string]bool{
+		"":                             false,
+		"--network none":               true,
+		"--net=none --cpus 2":          true,
+		"--network host --init":        false,
+		"--memory 1g --network=bridge": false,
+	} {
+		got, err := sandboxIsolateNetwork(options)
+		assert.NoError(t, err)
+		assert.Equal(t, isolate, got, options)
+	}
+}
+
+func TestSandboxEnvironment(t *testing.T) {
+	ctx := context.Background()
+	workspace := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(workspace, "input.txt"), []byte("input\n"), 0o600))
+	stdout := &bytes.Buffer{}
+	backend, err := GetBackend(BackendSandbox)
+	require.NoError(t, err)
+	env, err := backend.NewEnvironment(ctx, &BackendInput{
+		NewContainerInput: NewContainerInput{
+			Name:       "act-sandbox-test",
+			Image:      SandboxHostRoot,
+			WorkingDir: workspace,
+			Env:        []string{"RUNNER_OS=Linux"},
+			Mounts:     map[string]string{"act-sandbox-test-env": "/var/run/act"},
+			Stdout:     stdout,
+			Stderr:     stdout,
+		},
+		Workdir:  workspace,
+		CacheDir: t.TempDir(),
+	})
+	require.NoError(t, err)
+	sandbox := env.(*sandboxEnvironment)
+
+	require.NoError(t, env.Pull(false).Then(env.Create(nil, nil))(ctx))
+	if err := env.Start(false)(ctx); err != nil {
+		t.Skipf("the sandbox is not supported on this machine: %v", err)
+	}
+
+	assert.NoError(t, env.Copy("/var/run/act/", &FileEntry{Name: "workflow/event.json", Mode: 0o644, Body: "{}"})(ctx))
+	assert.FileExists(t, filepath.Join(sandbox.volumeDir("act-sandbox-test-env"), "workflow", "event.json"))
+
+	// the command is the first process of its PID namespace and writes to the upper layer
+	assert.NoError(t, env.Exec([]string{"sh", "-c", `echo $$ $RUNNER_OS; cat input.txt > output.txt; echo $FOO >> output.txt`}, map[string]string{"FOO": "bar"}, "", "")(ctx))
+	assert.Equal(t, "1 Linux\n", stdout.String())
+	assert.NoFileExists(t, filepath.Join(workspace, "output.txt"))
+
+	archive, err := env.GetContainerArchive(ctx, filepath.Join(workspace, "output.txt"))
+	require.NoError(t, err)
+	content, err := io.ReadAll(archive)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "input\nbar\n")
+	_, err = env.GetContainerArchive(ctx, filepath.Join(workspace, "missing.txt"))
+	assert.Error(t, err)
+
+	// the cache of act is hidden from the job
+	assert.Error(t, env.Exec([]string{"ls", sandbox.sandboxDir}, nil, "", "")(ctx))
+	assert.EqualError(t, env.Exec([]string{"false"}, nil, "", "")(ctx), "exitcode '1': failure")
+	assert.ErrorContains(t, env.Exec([]string{"act-missing-command"}, nil, "", "")(ctx), "exitcode '127'")
+
+	assert.NoError(t, env.Remove()(ctx))
+	assert.NoDirExists(t, sandbox.dir)
+	assert.NoDirExists(t, sandbox.volumeDir("act-sandbox-test-env"))
+}
@@ -0,0 +1,517 @@
+package container
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"os/user"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/kballard/go-shellquote"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"golang.org/x/term"
+
+	"github.com/nektos/act/pkg/common"
+)
+
+// BackendSSH runs jobs on a remote machine over SSH, it is selected with -P <platform>=ssh://[user@]host[:port][/dir]
+const BackendSSH = "ssh"
+
+const sshLogPrefix = "  \U0001F5A7  "
+
+// sshEnvironment runs a job on a remote linux machine like a self-hosted runner. The files of
+// the job are stored in a directory of the job below the remote directory of act, the working
+// directory of act is mapped to the workspace in the directory of the job. Files are copied
+// with tar over SSH sessions.
+type sshEnvironment struct {
+	LinuxContainerEnvironmentExtensions
+	input  *BackendInput
+	target *url.URL
+	addr   string
+	config *ssh.ClientConfig
+	client *ssh.Client
+	base   string // the remote directory of act, $HOME/.cache/act by default
+	dir    string // the remote directory of the job
+	arch   string
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// newSSHEnvironment returns the environment of the ssh URL of the image. The query parameters
+// identity and known-hosts set the private key and the known hosts file, insecure=true skips
+// the verification of the host key. Keys of the SSH agent and the default keys of ~/.ssh are
+// tried too.
+func newSSHEnvironment(_ context.Context, input *BackendInput) (ExecutionsEnvironment, error) {
+	if len(input.Services) > 0 {
+		return nil, errors.New("the ssh backend does not support services")
+	}
+	target, err := url.Parse(input.Image)
+	if err != nil {
+		return nil, err
+	}
+	if target.Scheme != "ssh" || target.Hostname() == "" {
+		return nil, fmt.Errorf("invalid ssh target '%s', expected ssh://[user@]host[:port][/dir]", target.Redacted())
+	}
+	username := target.User.Username()
+	if username == "" {
+		current, err := user.Current()
+		if err != nil {
+			return nil, err
+		}
+		username = current.Username
+	}
+	port := target.Port()
+	if port == "" {
+		port = "22"
+	}
+
+	hostKeyCallback, err := sshHostKeyCallback(target)
+	if err != nil {
+		return nil, err
+	}
+	return &sshEnvironment{
+		input:  input,
+		target: target,
+		addr:   net.JoinHostPort(target.Hostname(), port),
+		config: &ssh.ClientConfig{
+			User:            username,
+			HostKeyCallback: hostKeyCallback,
+		},
+		base:   target.Path,
+		stdout: input.Stdout,
+		stderr: input.Stderr,
+	}, nil
+}
+
+// sshAuthMethods returns the password of the URL, the identity file of the URL, the keys of
+// the SSH agent and the unencrypted default keys of ~/.ssh. The keys of the agent sign with
+// the returned connection to the agent, it is closed after the handshake.
+func sshAuthMethods(target *url.URL) ([]ssh.AuthMethod, io.Closer, error) {
+	methods := []ssh.AuthMethod{}
+	if password, ok := target.User.Password(); ok {
+		methods = append(methods, ssh.Password(password))
+	}
+
+	signers := []ssh.Signer{}
+	if identity := target.Query().Get("identity"); identity != "" {
+		key, err := os.ReadFile(identity)
+		if err != nil {
+			return nil, nil, err
+		}
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse the identity %s: %w", identity, err)
+		}
+		signers = append(signers, signer)
+	}
+	var agentConn net.Conn
+	if socket := os.Getenv("SSH_AUTH_SOCK"); socket != "" {
+		if conn, err := net.Dial("unix", socket); err == nil {
+			if agentSigners, err := agent.NewClient(conn).Signers(); err == nil && len(agentSigners) > 0 {
+				signers = append(signers, agentSigners...)
+				agentConn = conn
+			} else {
+				conn.Close()
+			}
+		}
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		for _, name := range []string{"id_ed25519", "id_ecdsa", "id_rsa"} {
+			key, err := os.ReadFile(filepath.Join(home, ".ssh", name))
+			if err != nil {
+				continue
+			}
+			// keys with a passphrase are only used through the SSH agent
+			if signer, err := ssh.ParsePrivateKey(key); err == nil {
+				signers = append(signers, signer)
+			}
+		}
+	}
+	if len(signers) > 0 {
+		methods = append(methods, ssh.PublicKeys(signers...))
+	}
+	return methods, agentConn, nil
+}
+
+// sshHostKeyCallback verifies the host key with the known hosts file of the URL or ~/.ssh/known_hosts
+func sshHostKeyCallback(target *url.URL) (ssh.HostKeyCallback, error) {
+	query := target.Query()
+	if query.Get("insecure") == "true" {
+		return ssh.InsecureIgnoreHostKey(), nil //nolint:gosec
+	}
+	knownHosts := query.Get("known-hosts")
+	if knownHosts == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		knownHosts = filepath.Join(home, ".ssh", "known_hosts")
+	}
+	callback, err := knownhosts.New(knownHosts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to verify the host key of %s: %w, add the host to the known hosts or use ?insecure=true", target.Hostname(), err)
+	}
+	return callback, nil
+}
+
+func (e *sshEnvironment) workspace() string {
+	return path.Join(e.dir, "workspace")
+}
+
+func (e *sshEnvironment) tempDir() string {
+	return path.Join(e.dir, "tmp")
+}
+
+func (e *sshEnvironment) toolCache() string {
+	return path.Join(e.base, "toolcache")
+}
+
+func (e *sshEnvironment) Pull(_ bool) common.Executor {
+	return func(_ context.Context) error {
+		return nil
+	}
+}
+
+// Create connects to the remote machine and creates the directories of the job
+func (e *sshEnvironment) Create(_ []string, _ []string) common.Executor {
+	return func(ctx context.Context) error {
+		logger := common.Logger(ctx)
+		logger.Infof("%sssh %s@%s", sshLogPrefix, e.config.User, e.addr)
+		if common.Dryrun(ctx) {
+			return nil
+		}
+		if len(e.input.Binds) > 0 || len(e.input.Mounts) > 0 {
+			logger.Debugf("Binds and mounts are not supported by the ssh backend: %v %v", e.input.Binds, e.input.Mounts)
+		}
+		if e.client == nil {
+			auth, agentConn, err := sshAuthMethods(e.target)
+			if err != nil {
+				return err
+			}
+			if agentConn != nil {
+				defer agentConn.Close()
+			}
+			config := *e.config
+			config.Auth = auth
+			conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", e.addr)
+			if err != nil {
+				return fmt.Errorf("failed to connect to %s: %w", e.addr, err)
+			}
+			c, chans, reqs, err := ssh.NewClientConn(conn, e.addr, &config)
+			if err != nil {
+				conn.Close()
+				return fmt.Errorf("failed to connect to %s: %w", e.addr, err)
+			}
+			e.client = ssh.NewClient(c, chans, reqs)
+		}
+
+		stdout := &bytes.Buffer{}
+		stderr := &bytes.Buffer{}
+		if err := e.run(ctx, `printf '%s\n' "$HOME" && uname -m`, nil, stdout, stderr); err != nil {
+			return fmt.Errorf("failed to query the remote machine: %w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		home, machine, _ := strings.Cut(strings.TrimSpace(stdout.String()), "\n")
+		if e.base == "" {
+			e.base = path.Join(home, ".cache", "act")
+		}
+		e.dir = path.Join(e.base, e.input.Name)
+		e.arch = sshRunnerArch(strings.TrimSpace(machine))
+
+		mkdir := shellquote.Join("mkdir", "-p", e.workspace(), e.GetActPath(), e.tempDir(), e.toolCache())
+		if err := e.run(ctx, mkdir, nil, io.Discard, stderr); err != nil {
+			return fmt.Errorf("failed to create the directories of the job: %w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return nil
+	}
+}
+
+// sshRunnerArch returns runner.arch of the machine hardware name of uname
+func sshRunnerArch(machine string) string {
+	if strings.HasPrefix(machine, "armv") {
+		return "ARM"
+	}
+	return goArchToActionArch(machine)
+}
+
+func (e *sshEnvironment) Start(_ bool) common.Executor {
+	return func(_ context.Context) error {
+		return nil
+	}
+}
+
+// run runs the shell command in a session, the session is killed if ctx is done
+func (e *sshEnvironment) run(ctx context.Context, cmd string, stdin io.Reader, stdout, stderr io.Writer) error {
+	if e.client == nil {
+		return errors.New("not connected to the remote machine")
+	}
+	session, err := e.client.NewSession()
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+	session.Stdin = stdin
+	session.Stdout = stdout
+	session.Stderr = stderr
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = session.Signal(ssh.SIGKILL)
+			session.Close()
+		case <-done:
+		}
+	}()
+	return session.Run(cmd)
+}
+
+// sshCommand returns the shell command running the command in the working directory with the
+// env sourced from the env file, the file is removed once it is sourced
+func sshCommand(command []string, envFile string, workdir string) string {
+	return ". " + shellquote.Join(envFile) + " && " + shellquote.Join("rm", "-f", envFile) + " && " +
+		shellquote.Join("cd", workdir) + " && exec " + shellquote.Join(command...)
+}
+
+func (e *sshEnvironment) Exec(command []string, env map[string]string, user, workdir string) common.Executor {
+	return func(ctx context.Context) error {
+		logger := common.Logger(ctx)
+		logger.Infof("%sssh exec cmd=[%s] user=%s workdir=%s", sshLogPrefix, strings.Join(command, " "), user, workdir)
+		if common.Dryrun(ctx) {
+			return nil
+		}
+		if err := checkExecUser(BackendSSH, user, e.config.User); err != nil {
+			return err
+		}
+		if workdir == "" {
+			workdir = e.workspace()
+		} else if !path.IsAbs(workdir) {
+			workdir = path.Join(e.workspace(), workdir)
+		}
+
+		// the env of the job container with the directories of the remote machine
+		execEnv := map[string]string{}
+		for _, kv := range e.input.Env {
+			k, v, _ := strings.Cut(kv, "=")
+			execEnv[k] = v
+		}
+		execEnv["RUNNER_TEMP"] = e.tempDir()
+		execEnv["RUNNER_TOOL_CACHE"] = e.toolCache()
+		for k, v := range env {
+			execEnv[k] = v
+		}
+
+		if e.client == nil {
+			return errors.New("not connected to the remote machine")
+		}
+		// the env is written to a file only the user can read, the command line of the
+		// remote command is visible to everyone on the machine
+		randBytes := make([]byte, 8)
+		if _, err := rand.Read(randBytes); err != nil {
+			return err
+		}
+		envFile := path.Join(e.GetActPath(), "env-"+hex.EncodeToString(randBytes))
+		stderr := &bytes.Buffer{}
+		if err := e.run(ctx, "umask 077 && cat > "+shellquote.Join(envFile), bytes.NewReader(execEnvScript(execEnv)), io.Discard, stderr); err != nil {
+			return fmt.Errorf("failed to write the env to the remote machine: %w: %s", err, stderr.String())
+		}
+
+		session, err := e.client.NewSession()
+		if err != nil {
+			return err
+		}
+		defer session.Close()
+		// a terminal like docker exec, stdout and stderr are merged by the PTY
+		if term.IsTerminal(int(os.Stdout.Fd())) {
+			if err := session.RequestPty("xterm", 40, 120, ssh.TerminalModes{ssh.ECHO: 0}); err != nil {
+				logger.Debugf("Failed to request a PTY: %v", err)
+			}
+		}
+		// stdout and stderr are copied concurrently and usually share the writer of the step log
+		mu := &sync.Mutex{}
+		session.Stdout = &sshLockedWriter{mu: mu, w: e.stdout}
+		session.Stderr = &sshLockedWriter{mu: mu, w: e.stderr}
+
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			select {
+			case <-ctx.Done():
+				_ = session.Signal(ssh.SIGKILL)
+				session.Close()
+			case <-done:
+			}
+		}()
+		err = session.Run(sshCommand(command, envFile, workdir))
+		var exitErr *ssh.ExitError
+		if errors.As(err, &exitErr) {
+			return execExitError(exitErr.ExitStatus())
+		}
+		return err
+	}
+}
+
+type sshLockedWriter struct {
+	mu *sync.Mutex
+	w  io.Writer
+}
+
+func (w *sshLockedWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.w == nil {
+		return len(p), nil
+	}
+	return w.w.Write(p)
+}
+
+// extract extracts the tar stream to destPath on the remote machine
+func (e *sshEnvironment) extract(ctx context.Context, destPath string, tarStream io.Reader) error {
+	cmd := shellquote.Join("mkdir", "-p", destPath) + " && " + shellquote.Join("tar", "-xf", "-", "-C", destPath)
+	stderr := &bytes.Buffer{}
+	if err := e.run(ctx, cmd, tarStream, io.Discard, stderr); err != nil {
+		return fmt.Errorf("failed to copy content to the remote machine: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+func (e *sshEnvironment) Copy(destPath string, files ...*FileEntry) common.Executor {
+	return func(ctx context.Context) error {
+		if common.Dryrun(ctx) {
+			return nil
+		}
+		buf, err := newFilesArchive(ctx, files...)
+		if err != nil {
+			return err
+		}
+		return e.extract(ctx, destPath, buf)
+	}
+}
+
+func (e *sshEnvironment) CopyTarStream(ctx context.Context, destPath string, tarStream io.Reader) error {
+	if common.Dryrun(ctx) {
+		return nil
+	}
+	return e.extract(ctx, destPath, tarStream)
+}
+
+func (e *sshEnvironment) CopyDir(destPath string, srcPath string, useGitIgnore bool) common.Executor {
+	return func(ctx context.Context) error {
+		common.Logger(ctx).Infof("%sssh cp src=%s dst=%s", sshLogPrefix, srcPath, destPath)
+		if common.Dryrun(ctx) {
+			return nil
+		}
+		pr, pw := io.Pipe()
+		go func() {
+			pw.CloseWithError(writeDirArchive(ctx, pw, srcPath, "", useGitIgnore))
+		}()
+		err := e.extract(ctx, destPath, pr)
+		pr.Close()
+		return err
+	}
+}
+
+func (e *sshEnvironment) GetContainerArchive(ctx context.Context, srcPath string) (io.ReadCloser, error) {
+	if common.Dryrun(ctx) {
+		return nil, fmt.Errorf("DRYRUN is not supported in GetContainerArchive")
+	}
+	srcPath = path.Clean(srcPath)
+	// GNU tar writes an empty archive for missing files, test the path before
+	cmd := "{ " + shellquote.Join("test", "-e", srcPath) + " || " + shellquote.Join("test", "-L", srcPath) + "; } && " +
+		shellquote.Join("tar", "-cf", "-", "-C", path.Dir(srcPath), path.Base(srcPath))
+	return newExecArchive(func(stdout io.Writer) error {
+		stderr := &bytes.Buffer{}
+		if err := e.run(ctx, cmd, nil, stdout, stderr); err != nil {
+			return fmt.Errorf("failed to copy content from the remote machine: %w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return nil
+	})
+}
+
+func (e *sshEnvironment) UpdateFromEnv(srcPath string, env *map[string]string) common.Executor {
+	return parseEnvFile(e, srcPath, env).IfNot(common.Dryrun)
+}
+
+// UpdateFromImageEnv adds the env of the remote shell like the env of an image
+func (e *sshEnvironment) UpdateFromImageEnv(env *map[string]string) common.Executor {
+	envMap := *env
+	return func(ctx context.Context) error {
+		stdout := &bytes.Buffer{}
+		if err := e.run(ctx, "env", nil, stdout, io.Discard); err != nil {
+			return fmt.Errorf("failed to read the env of the remote machine: %w", err)
+		}
+		mergeImageEnv(envMap, strings.Split(stdout.String(), "\n"))
+		return nil
+	}
+}
+
+// Remove removes the directory of the job from the remote machine
+func (e *sshEnvironment) Remove() common.Executor {
+	return func(ctx context.Context) error {
+		common.Logger(ctx).Debugf("%sssh rm -rf %s", sshLogPrefix, e.dir)
+		if common.Dryrun(ctx) || e.client == nil || e.dir == "" {
+			return nil
+		}
+		stderr := &bytes.Buffer{}
+		if err := e.run(ctx, shellquote.Join("rm", "-rf", e.dir), nil, io.Discard, stderr); err != nil {
+			return fmt.Errorf("failed to remove the directory of the job: %w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return nil
+	}
+}
+
+func (e *sshEnvironment) Close() common.Executor {
+	return func(_ context.Context) error {
+		if e.client == nil {
+			return nil
+		}
+		err := e.client.Close()
+		e.client = nil
+		return err
+	}
+}
+
+// ToContainerPath maps the working directory of act to the workspace on the remote machine
+func (e *sshEnvironment) ToContainerPath(p string) string {
+	if rel, err := filepath.Rel(e.input.Workdir, p); err == nil && filepath.IsLocal(rel) {
+		return path.Join(e.workspace(), filepath.ToSlash(rel))
+	} else if err == nil && rel == "." {
+		return e.workspace()
+	}
+	return p
+}
+
+func (e *sshEnvironment) GetActPath() string {
+	return path.Join(e.dir, "act")
+}
+
+func (e *sshEnvironment) GetRunnerContext(_ context.Context) map[string]interface{} {
+	return map[string]interface{}{
+		"os":         "Linux",
+		"arch":       e.arch,
+		"temp":       e.tempDir(),
+		"tool_cache": e.toolCache(),
+	}
+}
+
+func (e *sshEnvironment) GetHealth(_ context.Context) Health {
+	return HealthHealthy
+}
+
+func (e *sshEnvironment) ReplaceLogWriter(stdout io.Writer, stderr io.Writer) (io.Writer, io.Writer) {
+	oldStdout, oldStderr := e.stdout, e.stderr
+	e.stdout, e.stderr = stdout, stderr
+	return oldStdout, oldStderr
+}
@@ -0,0 +1,216 @@
+package container
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// startTestSSHServer starts an SSH server running the commands of exec requests with sh
+func startTestSSHServer(t *testing.T) string {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(key)
+	require.NoError(t, err)
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if conn.User() == "act" && string(password) == "secret" {
+				return nil, nil
+			}
+			return nil, errors.New("access denied")
+		},
+	}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveTestSSHConn(conn, config)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func serveTestSSHConn(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unsupported channel")
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+		go serveTestSSHSession(channel, requests)
+	}
+}
+
+func serveTestSSHSession(channel ssh.Channel, requests <-chan *ssh.Request) {
+	defer channel.Close()
+	for req := range requests {
+		switch req.Type {
+		case "pty-req", "env":
+			_ = req.Reply(true, nil)
+		case "exec":
+			var payload struct{ Command string }
+			if err := ssh.Unmarshal(req.Payload, &payload); err != nil {
+				_ = req.Reply(false, nil)
+				continue
+			}
+			_ = req.Reply(true, nil)
+			cmd := exec.Command("sh", "-c", payload.Command)
+			cmd.Stdin = channel
+			cmd.Stdout = channel
+			cmd.Stderr = channel.Stderr()
+			status := make([]byte, 4)
+			if err := cmd.Run(); err != nil {
+				var exitErr *exec.ExitError
+				if !errors.As(err, &exitErr) {
+					return
+				}
+				binary.BigEndian.PutUint32(status, uint32(exitErr.ExitCode()))
+			}
+			_ = channel.CloseWrite()
+			_, _ = channel.SendRequest("exit-status", false, status)
+			return
+		default:
+			_ = req.Reply(false, nil)
+		}
+	}
+}
+
+func TestSSHEnvironmentRejectsInvalidTargets(t *testing.T) {
+	ctx := context.Background()
+	_, err := newSSHEnvironment(ctx, &BackendInput{NewContainerInput: NewContainerInput{Image: "ssh://act:secret@/dir"}})
+	assert.ErrorContains(t, err, "invalid ssh target 'ssh://act:xxxxx@/dir'")
+	_, err = newSSHEnvironment(ctx, &BackendInput{
+		NewContainerInput: NewContainerInput{Image: "ssh://builder@10.0.0.5?insecure=true"},
+		Services:          map[string]*NewContainerInput{"redis": {Image: "redis"}},
+	})
+	assert.ErrorContains(t, err, "does not support services")
+}
+
+func TestSSHEnvironment(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test server runs the commands with sh")
+	}
+	ctx := context.Background()
+	addr := startTestSSHServer(t)
+	base := t.TempDir()
+	workspace := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(workspace, "input.txt"), []byte("input\n"), 0o600))
+
+	stdout := &bytes.Buffer{}
+	backend, err := GetBackend(BackendSSH)
+	require.NoError(t, err)
+	env, err := backend.NewEnvironment(ctx, &BackendInput{
+		NewContainerInput: NewContainerInput{
+			Name:   "act-ssh-test",
+			Image:  "ssh://act:secret@" + addr + filepath.ToSlash(base) + "?insecure=true",
+			Env:    []string{"RUNNER_OS=Linux"},
+			Stdout: stdout,
+			Stderr: stdout,
+		},
+		Workdir: workspace,
+	})
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, env.Close()(ctx)) }()
+	remote := env.(*sshEnvironment)
+
+	require.NoError(t, env.Pull(false).Then(env.Create(nil, nil)).Then(env.Start(false))(ctx))
+	jobDir := filepath.Join(base, "act-ssh-test")
+	assert.DirExists(t, filepath.Join(jobDir, "workspace"))
+	assert.Equal(t, remote.workspace(), env.ToContainerPath(workspace))
+	assert.Equal(t, remote.workspace()+"/sub/file", env.ToContainerPath(filepath.Join(workspace, "sub", "file")))
+	assert.Equal(t, "/etc/hosts", env.ToContainerPath("/etc/hosts"))
+	assert.Equal(t, remote.tempDir(), env.GetRunnerContext(ctx)["temp"])
+
+	require.NoError(t, env.CopyDir(env.ToContainerPath(workspace)+"/", workspace+"/", false)(ctx))
+	assert.FileExists(t, filepath.Join(jobDir, "workspace", "input.txt"))
+	require.NoError(t, env.Copy(env.GetActPath()+"/", &FileEntry{Name: "workflow/event.json", Mode: 0o644, Body: "{}"})(ctx))
+	assert.FileExists(t, filepath.Join(jobDir, "act", "workflow", "event.json"))
+
+	assert.NoError(t, env.Exec([]string{"sh", "-c", `echo "$RUNNER_OS $FOO"; cat input.txt > output.txt; echo "$RUNNER_TEMP" >> output.txt`}, map[string]string{"FOO": "it's"}, "", "")(ctx))
+	assert.Equal(t, "Linux it's\n", stdout.String())
+	envFiles, err := filepath.Glob(filepath.Join(jobDir, "act", "env-*"))
+	require.NoError(t, err)
+	assert.Empty(t, envFiles)
+
+	archive, err := env.GetContainerArchive(ctx, env.ToContainerPath(filepath.Join(workspace, "output.txt")))
+	require.NoError(t, err)
+	content, err := io.ReadAll(archive)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "input\n"+remote.tempDir()+"\n")
+	_, err = env.GetContainerArchive(ctx, env.ToContainerPath(filepath.Join(workspace, "missing.txt")))
+	assert.Error(t, err)
+
+	envMap := map[string]string{"PATH": "/opt/bin"}
+	require.NoError(t, env.UpdateFromImageEnv(&envMap)(ctx))
+	assert.NotEqual(t, "/opt/bin", envMap["PATH"])
+
+	assert.EqualError(t, env.Exec([]string{"false"}, nil, "", "")(ctx), "exitcode '1': failure")
+	assert.ErrorContains(t, env.Exec([]string{"act-missing-command"}, nil, "", "")(ctx), "exitcode '127'")
+
+	assert.NoError(t, env.Remove()(ctx))
+	assert.NoDirExists(t, jobDir)
+}
+
+func TestSSHAuthMethodsClosesAgent(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test agent listens on a unix socket")
+	}
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keyring := agent.NewKeyring()
+	require.NoError(t, keyring.Add(agent.AddedKey{PrivateKey: key}))
+
+	socket := filepath.Join(t.TempDir(), "agent.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	served := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			served <- err
+			return
+		}
+		served <- agent.ServeAgent(keyring, conn)
+	}()
+	t.Setenv("SSH_AUTH_SOCK", socket)
+	t.Setenv("HOME", t.TempDir())
+
+	methods, agentConn, err := sshAuthMethods(&url.URL{Scheme: "ssh", Host: "10.0.0.5"})
+	require.NoError(t, err)
+	assert.Len(t, methods, 1)
+	require.NotNil(t, agentConn)
+	require.NoError(t, agentConn.Close())
+	assert.ErrorIs(t, <-served, io.EOF, "the agent connection is closed")
+}
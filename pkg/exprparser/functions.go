@@ -252,16 +252,23 @@ func (impl *interperterImpl) getNeedsTransitive(job *model.Job) []string {
 	return needs
 }
 
+// needResult returns the result of a needed job, the external needs take precedence over the workflow jobs
+func (impl *interperterImpl) needResult(jobID string) string {
+	if needs, ok := impl.config.ExternalNeeds[jobID]; ok {
+		return needs.Result
+	}
+	return impl.config.Run.Workflow.Jobs[jobID].Result
+}
+
 func (impl *interperterImpl) always() (bool, error) {
 	return true, nil
 }
 
 func (impl *interperterImpl) jobSuccess() (bool, error) {
-	jobs := impl.config.Run.Workflow.Jobs
 	jobNeeds := impl.getNeedsTransitive(impl.config.Run.Job())
 
 	for _, needs := range jobNeeds {
-		if jobs[needs].Result != "success" {
+		if impl.needResult(needs) != "success" {
 			return false, nil
 		}
 	}
@@ -274,11 +281,10 @@ func (impl *interperterImpl) stepSuccess() (bool, error) {
 }
 
 func (impl *interperterImpl) jobFailure() (bool, error) {
-	jobs := impl.config.Run.Workflow.Jobs
 	jobNeeds := impl.getNeedsTransitive(impl.config.Run.Job())
 
 	for _, needs := range jobNeeds {
-		if jobs[needs].Result == "failure" {
+		if impl.needResult(needs) == "failure" {
 			return true, nil
 		}
 	}
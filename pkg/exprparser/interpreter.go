@@ -25,6 +25,9 @@ type EvaluationEnvironment struct {
 	Needs     map[string]Needs
 	Inputs    map[string]interface{}
 	HashFiles func([]reflect.Value) (interface{}, error)
+	// Contexts contains additional contexts by name for expressions evaluated
+	// outside of a job, they take precedence over the contexts above
+	Contexts map[string]interface{}
 }
 
 type Needs struct {
@@ -36,6 +39,8 @@ type Config struct {
 	Run        *model.Run
 	WorkingDir string
 	Context    string
+	// ExternalNeeds are the results and outputs of needed jobs, which are not run, by job ID
+	ExternalNeeds map[string]Needs
 }
 
 type DefaultStatusCheck int
@@ -151,6 +156,10 @@ func (impl *interperterImpl) evaluateNode(exprNode actionlint.ExprNode) (interfa
 }
 
 func (impl *interperterImpl) evaluateVariable(variableNode *actionlint.VariableNode) (interface{}, error) {
+	if value, ok := impl.env.Contexts[strings.ToLower(variableNode.Name)]; ok {
+		return value, nil
+	}
+
 	switch strings.ToLower(variableNode.Name) {
 	case "github":
 		return impl.env.Github, nil
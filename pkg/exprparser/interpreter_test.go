@@ -633,3 +633,33 @@ func TestContexts(t *testing.T) {
 		})
 	}
 }
+
+func TestAdditionalContexts(t *testing.T) {
+	env := &EvaluationEnvironment{
+		Job: &model.JobContext{Status: "success"},
+		Contexts: map[string]interface{}{
+			"job": map[string]interface{}{
+				"runs-on": []interface{}{"self-hosted", "linux"},
+			},
+			"workflow": "ci.yml",
+		},
+	}
+
+	table := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"workflow", "ci.yml"},
+		{"workflow == 'ci.yml'", true},
+		{"contains(job.runs-on, 'linux')", true},
+		{"job.status", nil},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.input, func(t *testing.T) {
+			output, err := NewInterpeter(env, Config{}).Evaluate(tt.input, DefaultStatusCheckNone)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, output)
+		})
+	}
+}
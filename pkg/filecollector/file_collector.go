@@ -3,12 +3,15 @@ package filecollector
 import (
 	"archive/tar"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"io/fs"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	git "github.com/go-git/go-git/v5"
@@ -82,6 +85,114 @@ func (cc *CopyCollector) WriteFile(fpath string, fi fs.FileInfo, linkName string
 	return nil
 }
 
+// ManifestEntry is the state of a file written by a SyncCollector
+type ManifestEntry struct {
+	Mode     uint32 `json:"mode"`
+	Size     int64  `json:"size"`
+	ModTime  int64  `json:"mtime"` // unix nanoseconds
+	LinkName string `json:"link,omitempty"`
+	Hash     string `json:"sha256,omitempty"`
+}
+
+// SyncCollector passes only the files added or changed since Previous was recorded
+// to Handler and records the state of all files in Current. Files with the mode,
+// size and modification time of Previous are unchanged, files with only another
+// modification time are compared by the hash of their content.
+type SyncCollector struct {
+	Previous map[string]ManifestEntry
+	Current  map[string]ManifestEntry
+	Handler  Handler
+	Changed  int
+}
+
+func (sc *SyncCollector) WriteFile(fpath string, fi fs.FileInfo, linkName string, f io.Reader) error {
+	if sc.Current == nil {
+		sc.Current = map[string]ManifestEntry{}
+	}
+	entry := ManifestEntry{
+		Mode:     uint32(fi.Mode()),
+		Size:     fi.Size(),
+		ModTime:  fi.ModTime().UnixNano(),
+		LinkName: linkName,
+	}
+	previous, exists := sc.Previous[fpath]
+	sameMetadata := exists && previous.Mode == entry.Mode && previous.Size == entry.Size && previous.LinkName == entry.LinkName
+	if sameMetadata && previous.ModTime == entry.ModTime {
+		sc.Current[fpath] = previous
+		return nil
+	}
+
+	// this is a symlink no reader provided
+	if f == nil {
+		sc.Current[fpath] = entry
+		sc.Changed++
+		return sc.Handler.WriteFile(fpath, fi, linkName, nil)
+	}
+
+	if seeker, ok := f.(io.ReadSeeker); ok && sameMetadata && previous.Hash != "" {
+		hash := sha256.New()
+		if _, err := io.Copy(hash, seeker); err != nil {
+			return err
+		}
+		entry.Hash = hex.EncodeToString(hash.Sum(nil))
+		if entry.Hash == previous.Hash {
+			sc.Current[fpath] = entry
+			return nil
+		}
+		if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+	}
+
+	hash := sha256.New()
+	if err := sc.Handler.WriteFile(fpath, fi, linkName, io.TeeReader(f, hash)); err != nil {
+		return err
+	}
+	entry.Hash = hex.EncodeToString(hash.Sum(nil))
+	sc.Current[fpath] = entry
+	sc.Changed++
+	return nil
+}
+
+// Removed returns the sorted paths of the files of Previous, which were not written again
+func (sc *SyncCollector) Removed() []string {
+	removed := []string{}
+	for fpath := range sc.Previous {
+		if _, ok := sc.Current[fpath]; !ok {
+			removed = append(removed, fpath)
+		}
+	}
+	sort.Strings(removed)
+	return removed
+}
+
+// RemovedDirs returns the sorted topmost directories of the removed files, which
+// contain none of the files written again
+func (sc *SyncCollector) RemovedDirs() []string {
+	kept := map[string]bool{}
+	for fpath := range sc.Current {
+		for dir := path.Dir(fpath); dir != "."; dir = path.Dir(dir) {
+			kept[dir] = true
+		}
+	}
+	dirs := map[string]bool{}
+	for _, fpath := range sc.Removed() {
+		var topmost string
+		for dir := path.Dir(fpath); dir != "." && !kept[dir]; dir = path.Dir(dir) {
+			topmost = dir
+		}
+		if topmost != "" {
+			dirs[topmost] = true
+		}
+	}
+	removed := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		removed = append(removed, dir)
+	}
+	sort.Strings(removed)
+	return removed
+}
+
 type FileCollector struct {
 	Ignorer   gitignore.Matcher
 	SrcPath   string
@@ -4,9 +4,12 @@ import (
 	"archive/tar"
 	"context"
 	"io"
+	"io/fs"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-git/go-billy/v5"
 	"github.com/go-git/go-billy/v5/memfs"
@@ -170,3 +173,78 @@ func TestSymlinks(t *testing.T) {
 	assert.Equal(t, ".env", files["test.env"].Linkname)
 	assert.ErrorIs(t, err, io.EOF, "tar must be read cleanly to EOF")
 }
+
+type recordingHandler struct {
+	files map[string]string
+}
+
+func (rh *recordingHandler) WriteFile(fpath string, _ fs.FileInfo, linkName string, f io.Reader) error {
+	if f == nil {
+		rh.files[fpath] = "-> " + linkName
+		return nil
+	}
+	content, err := io.ReadAll(f)
+	rh.files[fpath] = string(content)
+	return err
+}
+
+func TestSyncCollector(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string, modTime time.Time) {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+		assert.NoError(t, os.Chtimes(filepath.Join(dir, name), modTime, modTime))
+	}
+	collect := func(previous map[string]ManifestEntry) (*SyncCollector, map[string]string) {
+		handler := &recordingHandler{files: map[string]string{}}
+		sc := &SyncCollector{Previous: previous, Handler: handler}
+		fc := &FileCollector{
+			Fs:        &DefaultFs{},
+			SrcPath:   dir,
+			SrcPrefix: dir + string(filepath.Separator),
+			Handler:   sc,
+		}
+		assert.NoError(t, filepath.Walk(dir, fc.CollectFiles(context.Background(), []string{})))
+		return sc, handler.files
+	}
+
+	past := time.Now().Add(-time.Hour)
+	write("unchanged.txt", "same", past)
+	write("touched.txt", "same", past)
+	write("modified.txt", "old", past)
+	write("removed.txt", "gone", past)
+	assert.NoError(t, os.Symlink("unchanged.txt", filepath.Join(dir, "link")))
+
+	sc, files := collect(nil)
+	assert.Len(t, files, 5)
+	assert.Equal(t, 5, sc.Changed)
+	assert.Empty(t, sc.Removed())
+
+	write("touched.txt", "same", time.Now())
+	write("modified.txt", "new", time.Now())
+	write("added.txt", "added", past)
+	assert.NoError(t, os.Remove(filepath.Join(dir, "removed.txt")))
+
+	sc, files = collect(sc.Current)
+	assert.Equal(t, map[string]string{"modified.txt": "new", "added.txt": "added"}, files)
+	assert.Equal(t, 2, sc.Changed)
+	assert.Equal(t, []string{"removed.txt"}, sc.Removed())
+	assert.Len(t, sc.Current, 5)
+}
+
+func TestSyncCollectorRemovedDirs(t *testing.T) {
+	sc := &SyncCollector{
+		Previous: map[string]ManifestEntry{
+			"src/main.go":           {},
+			"src/gone/a.go":         {},
+			"src/gone/nested/b.go":  {},
+			"docs/old/readme.md":    {},
+			"docs/old/img/logo.png": {},
+			"top.txt":               {},
+		},
+		Current: map[string]ManifestEntry{
+			"src/main.go": {},
+			"docs/new.md": {},
+		},
+	}
+	assert.Equal(t, []string{"docs/old", "src/gone"}, sc.RemovedDirs())
+}
@@ -83,6 +83,15 @@ type ActionRuns struct {
 	PostEntrypoint string            `yaml:"post-entrypoint"`
 	Args           []string          `yaml:"args"`
 	Steps          []Step            `yaml:"steps"`
+	// ActBuild configures the build of the Dockerfile of a docker action
+	ActBuild *ActionRunsActBuild `yaml:"x-act-build"`
+}
+
+// ActionRunsActBuild are the build args, target stage and secrets of the Dockerfile of a docker action
+type ActionRunsActBuild struct {
+	Args    map[string]string `yaml:"args"`
+	Target  string            `yaml:"target"`
+	Secrets []string          `yaml:"secrets"`
 }
 
 // Action describes a metadata file for GitHub actions. The metadata filename must be either action.yml or action.yaml. The data in the metadata file defines the inputs, outputs and main entrypoint for your action.
@@ -17,10 +17,14 @@ import (
 type WorkflowPlanner interface {
 	PlanEvent(eventName string) (*Plan, error)
 	PlanJob(jobName string) (*Plan, error)
+	PlanJobs(filter JobFilter, skipNeeds bool) (*Plan, error)
 	PlanAll() (*Plan, error)
 	GetEvents() []string
 }
 
+// JobFilter reports whether a job of a workflow is selected for a plan
+type JobFilter func(w *Workflow, jobID string) (bool, error)
+
 // Plan contains a list of stages to run in series
 type Plan struct {
 	Stages []*Stage
@@ -248,6 +252,46 @@ func (wp *workflowPlanner) PlanJob(jobName string) (*Plan, error) {
 	return plan, lastErr
 }
 
+// PlanJobs builds a new run for all jobs selected by the filter and, unless
+// skipNeeds is set, all jobs they need
+func (wp *workflowPlanner) PlanJobs(filter JobFilter, skipNeeds bool) (*Plan, error) {
+	plan := new(Plan)
+	var lastErr error
+
+	for _, w := range wp.workflows {
+		jobIDs := make([]string, 0)
+		for _, jobID := range w.GetJobIDs() {
+			selected, err := filter(w, jobID)
+			if err != nil {
+				return nil, err
+			}
+			if selected {
+				jobIDs = append(jobIDs, jobID)
+			}
+		}
+		if len(jobIDs) == 0 {
+			continue
+		}
+		sort.Strings(jobIDs)
+		log.Debugf("selected jobs of workflow %s: %v", w.File, jobIDs)
+
+		var stages []*Stage
+		var err error
+		if skipNeeds {
+			stages, err = createStagesWithoutNeeds(w, jobIDs...)
+		} else {
+			stages, err = createStages(w, jobIDs...)
+		}
+		if err != nil {
+			log.Warn(err)
+			lastErr = err
+		} else {
+			plan.mergeStages(stages)
+		}
+	}
+	return plan, lastErr
+}
+
 // PlanAll builds a new run to execute in parallel all
 func (wp *workflowPlanner) PlanAll() (*Plan, error) {
 	plan := new(Plan)
@@ -357,7 +401,31 @@ func createStages(w *Workflow, jobIDs ...string) ([]*Stage, error) {
 		jobIDs = newJobIDs
 	}
 
-	// next, build an execution graph
+	return buildStages(w, jobDependencies)
+}
+
+// createStagesWithoutNeeds builds the stages of the given jobs only, needs
+// outside of the given jobs are considered to be satisfied
+func createStagesWithoutNeeds(w *Workflow, jobIDs ...string) ([]*Stage, error) {
+	jobDependencies := make(map[string][]string)
+	for _, jID := range jobIDs {
+		if w.GetJob(jID) != nil {
+			jobDependencies[jID] = nil
+		}
+	}
+	for jID := range jobDependencies {
+		for _, need := range w.GetJob(jID).Needs() {
+			if _, ok := jobDependencies[need]; ok {
+				jobDependencies[jID] = append(jobDependencies[jID], need)
+			}
+		}
+	}
+
+	return buildStages(w, jobDependencies)
+}
+
+// buildStages builds an execution graph of the jobs and their dependencies
+func buildStages(w *Workflow, jobDependencies map[string][]string) ([]*Stage, error) {
 	stages := make([]*Stage, 0)
 	for len(jobDependencies) > 0 {
 		stage := new(Stage)
@@ -2,6 +2,7 @@ package model
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 
 	log "github.com/sirupsen/logrus"
@@ -56,3 +57,63 @@ func TestWorkflow(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotNil(t, result)
 }
+
+func TestCreateStagesWithoutNeeds(t *testing.T) {
+	workflow, err := ReadWorkflow(strings.NewReader(`
+name: needs
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo build
+  test:
+    runs-on: ubuntu-latest
+    needs: build
+    steps:
+      - run: echo test
+  deploy:
+    runs-on: ubuntu-latest
+    needs: [build, test]
+    steps:
+      - run: echo deploy
+`), false)
+	assert.NoError(t, err)
+
+	stages, err := createStages(workflow, "deploy")
+	assert.NoError(t, err)
+	assert.Len(t, stages, 3)
+
+	stages, err = createStagesWithoutNeeds(workflow, "deploy")
+	assert.NoError(t, err)
+	assert.Len(t, stages, 1)
+	assert.Equal(t, []string{"deploy"}, stages[0].GetJobIDs())
+
+	stages, err = createStagesWithoutNeeds(workflow, "deploy", "test")
+	assert.NoError(t, err)
+	assert.Len(t, stages, 2)
+	assert.Equal(t, []string{"test"}, stages[0].GetJobIDs())
+	assert.Equal(t, []string{"deploy"}, stages[1].GetJobIDs())
+}
+
+func TestPlanJobs(t *testing.T) {
+	planner, err := NewWorkflowPlanner("testdata/strategy", true, false)
+	assert.NoError(t, err)
+
+	plan, err := planner.PlanJobs(func(_ *Workflow, jobID string) (bool, error) {
+		return strings.HasPrefix(jobID, "strategy-"), nil
+	}, false)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, plan.Stages)
+	for _, stage := range plan.Stages {
+		for _, run := range stage.Runs {
+			assert.True(t, strings.HasPrefix(run.JobID, "strategy-"))
+		}
+	}
+
+	plan, err = planner.PlanJobs(func(_ *Workflow, _ string) (bool, error) {
+		return false, nil
+	}, false)
+	assert.NoError(t, err)
+	assert.Empty(t, plan.Stages)
+}
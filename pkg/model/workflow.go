@@ -6,6 +6,7 @@ import (
 	"io"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -151,6 +152,68 @@ func (w *Workflow) WorkflowDispatchConfig() *WorkflowDispatch {
 	return nil
 }
 
+// Convert checks a workflow_dispatch input value against the declared type and returns
+// the typed value as it is exposed in the inputs context
+func (i WorkflowDispatchInput) Convert(value string) (interface{}, error) {
+	switch i.Type {
+	case "boolean":
+		switch value {
+		case "true":
+			return true, nil
+		case "false", "":
+			return false, nil
+		}
+		return nil, fmt.Errorf("'%s' is not a valid boolean, expected 'true' or 'false'", value)
+	case "number":
+		if value == "" {
+			return value, nil
+		}
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not a valid number", value)
+		}
+		return number, nil
+	case "choice":
+		if value == "" {
+			return value, nil
+		}
+		for _, option := range i.Options {
+			if option == value {
+				return value, nil
+			}
+		}
+		return nil, fmt.Errorf("'%s' is not a valid choice, expected one of: %s", value, strings.Join(i.Options, ", "))
+	}
+	return value, nil
+}
+
+// ValidateInputs checks the passed inputs against the declared workflow_dispatch inputs,
+// every missing required input and every value that does not match its type is reported
+func (w *WorkflowDispatch) ValidateInputs(inputs map[string]string) error {
+	names := make([]string, 0, len(w.Inputs))
+	for name := range w.Inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		input := w.Inputs[name]
+		value, ok := inputs[name]
+		if !ok {
+			value = input.Default
+		}
+		if input.Required && value == "" {
+			errs = append(errs, fmt.Errorf("input '%s' is required", name))
+			continue
+		}
+		if _, err := input.Convert(value); err != nil {
+			errs = append(errs, fmt.Errorf("input '%s': %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 type WorkflowCallInput struct {
 	Description string    `yaml:"description"`
 	Required    bool      `yaml:"required"`
@@ -158,14 +221,110 @@ type WorkflowCallInput struct {
 	Type        string    `yaml:"type"`
 }
 
+// Convert coerces a value passed by the calling job to the declared input type
+func (i WorkflowCallInput) Convert(value interface{}) (interface{}, error) {
+	switch i.Type {
+	case "boolean":
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			if v == "true" || v == "false" {
+				return v == "true", nil
+			}
+		}
+		return nil, fmt.Errorf("expected a boolean but got '%v'", value)
+	case "number":
+		switch v := value.(type) {
+		case int:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		case float64:
+			return v, nil
+		case string:
+			if number, err := strconv.ParseFloat(v, 64); err == nil {
+				return number, nil
+			}
+		}
+		return nil, fmt.Errorf("expected a number but got '%v'", value)
+	case "string":
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case bool:
+			return strconv.FormatBool(v), nil
+		case int:
+			return strconv.Itoa(v), nil
+		case int64:
+			return strconv.FormatInt(v, 10), nil
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		}
+		return nil, fmt.Errorf("expected a string but got '%v'", value)
+	}
+	return value, nil
+}
+
 type WorkflowCallOutput struct {
 	Description string `yaml:"description"`
 	Value       string `yaml:"value"`
 }
 
+type WorkflowCallSecret struct {
+	Description string `yaml:"description"`
+	Required    bool   `yaml:"required"`
+}
+
 type WorkflowCall struct {
 	Inputs  map[string]WorkflowCallInput  `yaml:"inputs"`
 	Outputs map[string]WorkflowCallOutput `yaml:"outputs"`
+	Secrets map[string]WorkflowCallSecret `yaml:"secrets"`
+}
+
+// Validate checks the inputs and secrets passed to a workflow_call workflow against its declaration,
+// undeclared inputs, missing required inputs or secrets and inputs not matching their type are reported
+func (w *WorkflowCall) Validate(inputs map[string]interface{}, secrets map[string]string) error {
+	var errs []error
+	for name := range inputs {
+		if _, ok := w.Inputs[name]; !ok {
+			errs = append(errs, fmt.Errorf("Invalid input, %s is not defined in the referenced workflow", name))
+		}
+	}
+
+	for name, input := range w.Inputs {
+		value := inputs[name]
+		if value == nil {
+			if input.Required {
+				errs = append(errs, fmt.Errorf("Input %s is required, but not provided while calling", name))
+			}
+			continue
+		}
+		if _, err := input.Convert(value); err != nil {
+			errs = append(errs, fmt.Errorf("Input %s: %w", name, err))
+		}
+	}
+
+	for name, secret := range w.Secrets {
+		if secret.Required && !HasSecret(secrets, name) {
+			errs = append(errs, fmt.Errorf("Secret %s is required, but not provided while calling", name))
+		}
+	}
+
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].Error() < errs[j].Error()
+	})
+	return errors.Join(errs...)
+}
+
+// HasSecret reports whether secrets contains name, secret names are case insensitive
+func HasSecret[V any](secrets map[string]V, name string) bool {
+	for k := range secrets {
+		if strings.EqualFold(k, name) {
+			return true
+		}
+	}
+	return false
 }
 
 type WorkflowCallResult struct {
@@ -580,6 +739,13 @@ type Step struct {
 	With               map[string]string `yaml:"with"`
 	RawContinueOnError string            `yaml:"continue-on-error"`
 	TimeoutMinutes     string            `yaml:"timeout-minutes"`
+	// ActInputs declares additional inputs of a run step, which are part of its key for act --memoize
+	ActInputs *StepActInputs `yaml:"x-act-inputs"`
+}
+
+// StepActInputs are the additional inputs of a run step, Files are hashFiles patterns
+type StepActInputs struct {
+	Files []string `yaml:"files"`
 }
 
 // String gets the name of step
@@ -612,3 +612,63 @@ on: push #*trigger
 		assert.Equal(t, "actions/checkout@v5", job.Steps[0].Uses)
 	}
 }
+
+func TestWorkflowDispatch_ValidateInputs(t *testing.T) {
+	yaml := `
+name: dispatch
+on:
+  workflow_dispatch:
+    inputs:
+      logLevel:
+        required: true
+        default: warning
+        type: choice
+        options:
+        - info
+        - warning
+      dryrun:
+        type: boolean
+      count:
+        type: number
+        default: 3
+      environment:
+        required: true
+        type: environment
+
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+    - run: echo
+`
+	workflow, err := ReadWorkflow(strings.NewReader(yaml), false)
+	assert.NoError(t, err, "read workflow should succeed")
+	config := workflow.WorkflowDispatchConfig()
+	assert.NotNil(t, config)
+
+	assert.NoError(t, config.ValidateInputs(map[string]string{"environment": "production"}))
+	assert.NoError(t, config.ValidateInputs(map[string]string{"environment": "production", "logLevel": "info", "dryrun": "true", "count": "1.5"}))
+
+	err = config.ValidateInputs(map[string]string{})
+	assert.ErrorContains(t, err, "input 'environment' is required")
+
+	err = config.ValidateInputs(map[string]string{"environment": "production", "logLevel": "inf", "dryrun": "yes", "count": "many"})
+	assert.ErrorContains(t, err, "input 'logLevel': 'inf' is not a valid choice, expected one of: info, warning")
+	assert.ErrorContains(t, err, "input 'dryrun': 'yes' is not a valid boolean")
+	assert.ErrorContains(t, err, "input 'count': 'many' is not a valid number")
+
+	value, err := config.Inputs["count"].Convert(config.Inputs["count"].Default)
+	assert.NoError(t, err)
+	assert.Equal(t, 3.0, value)
+
+	value, err = config.Inputs["dryrun"].Convert("")
+	assert.NoError(t, err)
+	assert.Equal(t, false, value)
+}
+
+func TestHasSecret(t *testing.T) {
+	secrets := map[string]string{"MY_TOKEN": "secret"}
+	assert.True(t, HasSecret(secrets, "MY_TOKEN"))
+	assert.True(t, HasSecret(secrets, "my_token"))
+	assert.False(t, HasSecret(secrets, "token"))
+}
@@ -2,7 +2,10 @@ package runner
 
 import (
 	"context"
+	"crypto/sha256"
 	"embed"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -12,7 +15,9 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/kballard/go-shellquote"
 
@@ -251,65 +256,42 @@ func execAsDocker(ctx context.Context, step actionStep, actionName, basedir, sub
 	action := step.getActionModel()
 
 	var prepImage common.Executor
+	var build *container.NewDockerBuildExecutorInput
 	var image string
 	forcePull := false
 	if strings.HasPrefix(action.Runs.Image, "docker://") {
 		image = strings.TrimPrefix(action.Runs.Image, "docker://")
 		// Apply forcePull only for prebuild docker images
-		forcePull = rc.Config.ForcePull
+		forcePull = rc.forcePull(image)
 	} else {
-		// "-dockeraction" enshures that "./", "./test " won't get converted to "act-:latest", "act-test-:latest" which are invalid docker image names
-		image = fmt.Sprintf("%s-dockeraction:%s", regexp.MustCompile("[^a-zA-Z0-9]").ReplaceAllString(actionName, "-"), "latest")
-		image = fmt.Sprintf("act-%s", strings.TrimLeft(image, "-"))
-		image = strings.ToLower(image)
 		contextDir, fileName := path.Split(path.Join(subpath, action.Runs.Image))
-
-		anyArchExists, err := container.ImageExistsLocally(ctx, image, "any")
-		if err != nil {
-			return err
-		}
-
-		correctArchExists, err := container.ImageExistsLocally(ctx, image, rc.Config.ContainerArchitecture)
+		// the image is tagged with the hash of the build context, so it is rebuilt exactly when the context changes
+		buildContext, err := dockerActionBuildContext(ctx, step, localAction, filepath.Join(basedir, contextDir), contextDir, fileName)
 		if err != nil {
 			return err
 		}
-
-		if anyArchExists && !correctArchExists {
-			wasRemoved, err := container.RemoveImage(ctx, image, true, true)
+		buildInput := dockerActionBuildInput(ctx, step)
+		repository := rc.dockerActionRepository(actionName)
+		image = dockerActionImage(repository, dockerActionBuildHash(buildContext.Hash, buildInput))
+		buildInput.ContextDir = filepath.Join(basedir, contextDir)
+		buildInput.Dockerfile = fileName
+		buildInput.ImageTag = image
+		buildInput.BuildContext = buildContext
+		buildInput.Platform = rc.Config.ContainerArchitecture
+
+		if !rc.dockerStepContainers(ctx) {
+			// the backend of the job builds the image of the step container
+			logger.Debugf("image '%s' will be built by the backend of the job from context '%s'", image, contextDir)
+			buildInput.Secrets, err = dockerActionBuildSecrets(step)
 			if err != nil {
 				return err
 			}
-			if !wasRemoved {
-				return fmt.Errorf("failed to remove image '%s'", image)
-			}
-		}
-
-		if !correctArchExists || rc.Config.ForceRebuild {
-			logger.Debugf("image '%s' for architecture '%s' will be built from context '%s", image, rc.Config.ContainerArchitecture, contextDir)
-			var buildContext io.ReadCloser
-			if localAction {
-				buildContext, err = rc.JobContainer.GetContainerArchive(ctx, contextDir+"/.")
-				if err != nil {
-					return err
-				}
-				defer buildContext.Close()
-			} else if rc.Config.ActionCache != nil {
-				rstep := step.(*stepActionRemote)
-				buildContext, err = rc.Config.ActionCache.GetTarArchive(ctx, rstep.cacheDir, rstep.resolvedSha, contextDir)
-				if err != nil {
-					return err
-				}
-				defer buildContext.Close()
-			}
-			prepImage = container.NewDockerBuildExecutor(container.NewDockerBuildExecutorInput{
-				ContextDir:   filepath.Join(basedir, contextDir),
-				Dockerfile:   fileName,
-				ImageTag:     image,
-				BuildContext: buildContext,
-				Platform:     rc.Config.ContainerArchitecture,
-			})
+			build = &buildInput
 		} else {
-			logger.Debugf("image '%s' for architecture '%s' already exists", image, rc.Config.ContainerArchitecture)
+			if prepImage, err = dockerActionPrepImage(ctx, step, buildInput); err != nil {
+				return err
+			}
+			rc.dockerActionImages.add(repository, image)
 		}
 	}
 	eval := rc.NewStepExpressionEvaluator(ctx, step)
@@ -343,7 +325,10 @@ func execAsDocker(ctx context.Context, step actionStep, actionName, basedir, sub
 			entrypoint = nil
 		}
 	}
-	stepContainer := newStepContainer(ctx, step, image, cmd, entrypoint)
+	stepContainer, err := newStepContainer(ctx, step, image, cmd, entrypoint, build)
+	if err != nil {
+		return err
+	}
 	return common.NewPipelineExecutor(
 		prepImage,
 		stepContainer.Pull(forcePull),
@@ -355,6 +340,45 @@ func execAsDocker(ctx context.Context, step actionStep, actionName, basedir, sub
 	).Finally(stepContainer.Close())(ctx)
 }
 
+// dockerActionPrepImage returns the executor building the image of a docker action with
+// the docker daemon or nil if the image of the build context exists for the architecture
+func dockerActionPrepImage(ctx context.Context, step actionStep, buildInput container.NewDockerBuildExecutorInput) (common.Executor, error) {
+	logger := common.Logger(ctx)
+	rc := step.getRunContext()
+	image := buildInput.ImageTag
+
+	anyArchExists, err := container.ImageExistsLocally(ctx, image, "any")
+	if err != nil {
+		return nil, err
+	}
+
+	correctArchExists, err := container.ImageExistsLocally(ctx, image, rc.Config.ContainerArchitecture)
+	if err != nil {
+		return nil, err
+	}
+
+	if anyArchExists && !correctArchExists {
+		wasRemoved, err := container.RemoveImage(ctx, image, true, true)
+		if err != nil {
+			return nil, err
+		}
+		if !wasRemoved {
+			return nil, fmt.Errorf("failed to remove image '%s'", image)
+		}
+	}
+
+	if correctArchExists && (!rc.Config.ForceRebuild || rc.preparedImages[image]) {
+		logger.Debugf("image '%s' for architecture '%s' already exists", image, rc.Config.ContainerArchitecture)
+		return nil, nil
+	}
+	logger.Debugf("image '%s' for architecture '%s' will be built from context '%s", image, rc.Config.ContainerArchitecture, buildInput.ContextDir)
+	buildInput.Secrets, err = dockerActionBuildSecrets(step)
+	if err != nil {
+		return nil, err
+	}
+	return container.NewDockerBuildExecutor(buildInput), nil
+}
+
 func evalDockerArgs(ctx context.Context, step step, action *model.Action, cmd *[]string) {
 	rc := step.getRunContext()
 	stepModel := step.getStepModel()
@@ -384,7 +408,7 @@ func evalDockerArgs(ctx context.Context, step step, action *model.Action, cmd *[
 	}
 }
 
-func newStepContainer(ctx context.Context, step step, image string, cmd []string, entrypoint []string) container.Container {
+func newStepContainer(ctx context.Context, step step, image string, cmd []string, entrypoint []string, build *container.NewDockerBuildExecutorInput) (container.Container, error) {
 	rc := step.getRunContext()
 	stepModel := step.getStepModel()
 	rawLogger := common.Logger(ctx).WithField("raw_output", true)
@@ -416,7 +440,7 @@ func newStepContainer(ctx context.Context, step step, image string, cmd []string
 	} else {
 		workdir = rc.JobContainer.ToContainerPath(rc.Config.Workdir)
 	}
-	stepContainer := container.NewContainer(&container.NewContainerInput{
+	return rc.createStepContainer(ctx, stepModel, &container.NewContainerInput{
 		Cmd:         cmd,
 		Entrypoint:  entrypoint,
 		WorkingDir:  workdir,
@@ -434,8 +458,7 @@ func newStepContainer(ctx context.Context, step step, image string, cmd []string
 		UsernsMode:  rc.Config.UsernsMode,
 		Platform:    rc.Config.ContainerArchitecture,
 		Options:     rc.Config.ContainerOptions,
-	})
-	return stepContainer
+	}, build)
 }
 
 func populateEnvsFromSavedState(env *map[string]string, step actionStep, rc *RunContext) {
@@ -480,6 +503,145 @@ func getContainerActionPaths(step *model.Step, actionDir string, rc *RunContext)
 	return actionName, containerActionDir
 }
 
+// dockerActionRepository returns the repository of the images built from the Dockerfile of an action.
+// The repositories of local actions end with the hash of the working directory, the actions of
+// different repositories share their names.
+func (rc *RunContext) dockerActionRepository(actionName string) string {
+	// "-dockeraction" enshures that "./", "./test " won't get converted to "act-:latest", "act-test-:latest" which are invalid docker image names
+	repository := fmt.Sprintf("%s-dockeraction", regexp.MustCompile("[^a-zA-Z0-9]").ReplaceAllString(actionName, "-"))
+	repository = fmt.Sprintf("act-%s", strings.TrimLeft(repository, "-"))
+	if strings.HasPrefix(actionName, "./") {
+		workdir, err := filepath.Abs(rc.Config.Workdir)
+		if err != nil {
+			workdir = rc.Config.Workdir
+		}
+		digest := sha256.Sum256([]byte(workdir))
+		repository = fmt.Sprintf("%s-%s", repository, hex.EncodeToString(digest[:4]))
+	}
+	return strings.ToLower(repository)
+}
+
+// dockerActionImage returns the image built from the Dockerfile of an action, the
+// tag is the beginning of the hash of the build context
+func dockerActionImage(repository string, buildContextHash string) string {
+	return fmt.Sprintf("%s:%.16s", repository, buildContextHash)
+}
+
+// dockerActionBuildContext reads the build context of a Dockerfile action from the
+// job container for local actions, from the action cache or from the action
+// directory on the host
+func dockerActionBuildContext(ctx context.Context, step actionStep, localAction bool, hostContextDir, contextDir, dockerfile string) (*container.BuildContext, error) {
+	rc := step.getRunContext()
+	open := func() (io.ReadCloser, error) {
+		return container.NewBuildContextArchive(ctx, hostContextDir, dockerfile)
+	}
+	if localAction {
+		open = func() (io.ReadCloser, error) {
+			return rc.JobContainer.GetContainerArchive(ctx, contextDir+"/.")
+		}
+	} else if rc.Config.ActionCache != nil {
+		rstep := step.(*stepActionRemote)
+		open = func() (io.ReadCloser, error) {
+			return rc.Config.ActionCache.GetTarArchive(ctx, rstep.cacheDir, rstep.resolvedSha, contextDir)
+		}
+	}
+	return container.NewBuildContext(open, dockerfile)
+}
+
+// dockerActionBuildInput returns the build args and target of x-act-build of the
+// action, the build args are interpolated with the inputs of the step
+func dockerActionBuildInput(ctx context.Context, step actionStep) container.NewDockerBuildExecutorInput {
+	input := container.NewDockerBuildExecutorInput{}
+	build := step.getActionModel().Runs.ActBuild
+	if build == nil {
+		return input
+	}
+	rc := step.getRunContext()
+
+	eval := rc.NewStepExpressionEvaluator(ctx, step)
+	input.BuildArgs = map[string]string{}
+	for k, v := range build.Args {
+		input.BuildArgs[k] = eval.Interpolate(ctx, v)
+	}
+	input.Target = build.Target
+	return input
+}
+
+// dockerActionBuildSecrets returns the secrets of x-act-build of the action, they are
+// only needed to build the image, an existing image is used without them
+func dockerActionBuildSecrets(step actionStep) (map[string][]byte, error) {
+	build := step.getActionModel().Runs.ActBuild
+	if build == nil {
+		return nil, nil
+	}
+	rc := step.getRunContext()
+	secrets := map[string][]byte{}
+	for _, name := range build.Secrets {
+		value, ok := rc.Config.Secrets[name]
+		if !ok {
+			return nil, fmt.Errorf("secret '%s' of the build of action '%s' is not set", name, step.getStepModel().Uses)
+		}
+		secrets[name] = []byte(value)
+	}
+	return secrets, nil
+}
+
+// dockerActionBuildHash returns the hash of the build context combined with the
+// build args and target, the secrets are never part of the image
+func dockerActionBuildHash(buildContextHash string, input container.NewDockerBuildExecutorInput) string {
+	if len(input.BuildArgs) == 0 && input.Target == "" {
+		return buildContextHash
+	}
+	// json.Marshal sorts the keys of maps
+	options, _ := json.Marshal(map[string]interface{}{
+		"context": buildContextHash,
+		"args":    input.BuildArgs,
+		"target":  input.Target,
+	})
+	digest := sha256.Sum256(options)
+	return hex.EncodeToString(digest[:])
+}
+
+// dockerActionImages are the images of docker actions used by a plan. The other tags of
+// their repositories are images of earlier build contexts or build args, they are removed
+// once the plan finished as parallel jobs may still use the images of their build args.
+type dockerActionImages struct {
+	mu     sync.Mutex
+	images map[string][]string // images by repository
+}
+
+func (d *dockerActionImages) add(repository, image string) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.images == nil {
+		d.images = map[string][]string{}
+	}
+	if !slices.Contains(d.images[repository], image) {
+		d.images[repository] = append(d.images[repository], image)
+	}
+}
+
+// removeStale removes the tags of the repositories, which are not used by the plan. Docker
+// refuses to remove the images of containers, they are left to the next run.
+func (d *dockerActionImages) removeStale() common.Executor {
+	return func(ctx context.Context) error {
+		if common.Dryrun(ctx) {
+			return nil
+		}
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		for repository, images := range d.images {
+			if err := container.RemoveOtherImageTags(ctx, repository, images...); err != nil {
+				common.Logger(ctx).Debugf("Unable to remove the previous images of %s: %v", repository, err)
+			}
+		}
+		return nil
+	}
+}
+
 func getOsSafeRelativePath(s, prefix string) string {
 	actionName := strings.TrimPrefix(s, prefix)
 	if runtime.GOOS == "windows" {
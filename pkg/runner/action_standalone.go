@@ -0,0 +1,108 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nektos/act/pkg/common"
+	"github.com/nektos/act/pkg/model"
+)
+
+const standaloneActionID = "action"
+
+// ActionResult contains the results of an action run by NewActionExecutor
+type ActionResult struct {
+	Outputs map[string]string `json:"outputs"`
+	State   map[string]string `json:"state"`
+	Main    *model.StepResult `json:"main"`
+	Post    *model.StepResult `json:"post,omitempty"`
+}
+
+// NewActionPlan creates a plan of a synthetic job with a single step using the given action
+func NewActionPlan(uses string, with map[string]string, runsOn string) (*model.Plan, error) {
+	step := &model.Step{
+		ID:   standaloneActionID,
+		Uses: uses,
+		With: with,
+	}
+	steps := []*model.Step{step}
+	switch step.Type() {
+	case model.StepTypeUsesActionLocal:
+		// local actions are read from the workspace, which is populated by the local checkout
+		steps = append([]*model.Step{{
+			ID:   "checkout",
+			Uses: "actions/checkout@v4",
+		}}, steps...)
+	case model.StepTypeUsesActionRemote, model.StepTypeUsesDockerURL:
+	default:
+		return nil, fmt.Errorf("'%s' is not an action", uses)
+	}
+
+	job := &model.Job{
+		Name:  uses,
+		Steps: steps,
+	}
+	if err := job.RawRunsOn.Encode(runsOn); err != nil {
+		return nil, err
+	}
+
+	workflow := &model.Workflow{
+		File: uses,
+		Name: standaloneActionID,
+		Jobs: map[string]*model.Job{
+			standaloneActionID: job,
+		},
+	}
+	if err := workflow.RawOn.Encode("workflow_dispatch"); err != nil {
+		return nil, err
+	}
+
+	return &model.Plan{
+		Stages: []*model.Stage{{
+			Runs: []*model.Run{{
+				Workflow: workflow,
+				JobID:    standaloneActionID,
+			}},
+		}},
+	}, nil
+}
+
+// NewActionExecutor runs the job of a plan created by NewActionPlan and stores the
+// outputs, state and step results of the action in result
+func NewActionExecutor(config *Config, plan *model.Plan, result *ActionResult) (common.Executor, error) {
+	runner := &runnerImpl{
+		config: config,
+	}
+	if _, err := runner.configure(); err != nil {
+		return nil, err
+	}
+
+	run := plan.Stages[0].Runs[0]
+
+	return func(ctx context.Context) error {
+		rc := runner.newRunContext(ctx, run, nil)
+		rc.JobName = rc.Name
+		executor, err := rc.Executor()
+		if err != nil {
+			return err
+		}
+
+		err = executor(common.WithJobErrorContainer(WithJobLogger(ctx, run.JobID, rc.String(), rc.Config, &rc.Masks, nil)))
+
+		result.Main = rc.StepResults[standaloneActionID]
+		result.Post = rc.PostStepResults[standaloneActionID]
+		result.State = rc.IntraActionState[standaloneActionID]
+		if result.State == nil {
+			result.State = map[string]string{}
+		}
+		result.Outputs = map[string]string{}
+		if result.Main != nil {
+			result.Outputs = result.Main.Outputs
+		}
+
+		if err == nil && run.Job().Result == "failure" {
+			err = fmt.Errorf("action '%s' failed", run.Job().Name)
+		}
+		return err
+	}, nil
+}
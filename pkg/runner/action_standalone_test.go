@@ -0,0 +1,55 @@
+package runner
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nektos/act/pkg/model"
+)
+
+func TestNewActionPlan(t *testing.T) {
+	plan, err := NewActionPlan("./actions/node20", map[string]string{"who-to-greet": "act"}, "ubuntu-latest")
+	assert.NoError(t, err)
+	job := plan.Stages[0].Runs[0].Job()
+	assert.Equal(t, []string{"ubuntu-latest"}, job.RunsOn())
+	assert.Len(t, job.Steps, 2)
+	assert.Equal(t, "actions/checkout@v4", job.Steps[0].Uses)
+	assert.Equal(t, "act", job.Steps[1].With["who-to-greet"])
+
+	plan, err = NewActionPlan("docker://alpine:3", nil, "ubuntu-latest")
+	assert.NoError(t, err)
+	assert.Len(t, plan.Stages[0].Runs[0].Job().Steps, 1)
+	assert.Equal(t, model.StepTypeUsesDockerURL, plan.Stages[0].Runs[0].Job().Steps[0].Type())
+
+	_, err = NewActionPlan("./.github/workflows/local-reusable-workflow.yml", nil, "ubuntu-latest")
+	assert.Error(t, err)
+}
+
+func TestNewActionExecutorHostEnvironment(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	if runtime.GOOS != "linux" {
+		t.Skip("host environment test requires linux")
+	}
+
+	plan, err := NewActionPlan("./actions/composite-fail-with-output", nil, "ubuntu-latest")
+	assert.NoError(t, err)
+
+	result := &ActionResult{}
+	executor, err := NewActionExecutor(&Config{
+		Workdir:   workdir,
+		EventName: "workflow_dispatch",
+		Platforms: map[string]string{"ubuntu-latest": "-self-hosted"},
+		Secrets:   map[string]string{},
+	}, plan, result)
+	assert.NoError(t, err)
+
+	err = executor(context.Background())
+	assert.ErrorContains(t, err, "failed")
+	assert.Equal(t, "my-customoutput-green", result.Outputs["customoutput"])
+	assert.Equal(t, model.StepStatusFailure, result.Main.Conclusion)
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/nektos/act/pkg/container"
 	"github.com/nektos/act/pkg/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -79,6 +80,37 @@ runs:
 				},
 			},
 		},
+		{
+			name:     "readActBuild",
+			step:     &model.Step{},
+			filename: "action.yml",
+			fileContent: strings.ReplaceAll(`
+name: 'name'
+runs:
+	using: 'docker'
+	image: 'Dockerfile'
+	x-act-build:
+		args:
+			VERSION: ${{ inputs.version }}
+		target: release
+		secrets:
+			- NPM_TOKEN
+`, "\t", "  "),
+			expected: &model.Action{
+				Name: "name",
+				Runs: model.ActionRuns{
+					Using:  "docker",
+					Image:  "Dockerfile",
+					PreIf:  "always()",
+					PostIf: "always()",
+					ActBuild: &model.ActionRunsActBuild{
+						Args:    map[string]string{"VERSION": "${{ inputs.version }}"},
+						Target:  "release",
+						Secrets: []string{"NPM_TOKEN"},
+					},
+				},
+			},
+		},
 		{
 			name: "readWithArgs",
 			step: &model.Step{
@@ -249,3 +281,78 @@ func TestActionRunner(t *testing.T) {
 		})
 	}
 }
+
+func TestDockerActionBuildInput(t *testing.T) {
+	ctx := context.Background()
+	newStep := func(secrets map[string]string) *stepActionLocal {
+		rc := &RunContext{
+			Config:      &Config{Secrets: secrets},
+			StepResults: map[string]*model.StepResult{},
+			Run: &model.Run{
+				JobID: "build",
+				Workflow: &model.Workflow{
+					Jobs: map[string]*model.Job{"build": {}},
+				},
+			},
+		}
+		rc.ExprEval = rc.NewExpressionEvaluator(ctx)
+		return &stepActionLocal{
+			RunContext: rc,
+			Step:       &model.Step{Uses: "./build"},
+			action: &model.Action{
+				Inputs: map[string]model.Input{"version": {}},
+				Runs: model.ActionRuns{
+					Using: "docker",
+					Image: "Dockerfile",
+					ActBuild: &model.ActionRunsActBuild{
+						Args:    map[string]string{"VERSION": "${{ inputs.version }}"},
+						Target:  "release",
+						Secrets: []string{"NPM_TOKEN"},
+					},
+				},
+			},
+			env: map[string]string{"INPUT_VERSION": "1.2.3"},
+		}
+	}
+
+	step := newStep(map[string]string{"NPM_TOKEN": "secret"})
+	input := dockerActionBuildInput(ctx, step)
+	assert.Equal(t, map[string]string{"VERSION": "1.2.3"}, input.BuildArgs)
+	assert.Equal(t, "release", input.Target)
+	var err error
+	input.Secrets, err = dockerActionBuildSecrets(step)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"NPM_TOKEN": []byte("secret")}, input.Secrets)
+
+	assert.Equal(t, "abc", dockerActionBuildHash("abc", container.NewDockerBuildExecutorInput{}))
+	hash := dockerActionBuildHash("abc", input)
+	assert.NotEqual(t, "abc", hash)
+	input.BuildArgs["VERSION"] = "1.2.4"
+	assert.NotEqual(t, hash, dockerActionBuildHash("abc", input))
+	input.Secrets["NPM_TOKEN"] = []byte("other")
+	input.BuildArgs["VERSION"] = "1.2.3"
+	assert.Equal(t, hash, dockerActionBuildHash("abc", input), "secrets are not part of the image")
+
+	// a missing secret only fails the build, the image may exist already
+	step = newStep(map[string]string{})
+	assert.Equal(t, hash, dockerActionBuildHash("abc", dockerActionBuildInput(ctx, step)))
+	_, err = dockerActionBuildSecrets(step)
+	assert.Error(t, err)
+}
+
+func TestDockerActionRepository(t *testing.T) {
+	rc := &RunContext{Config: &Config{Workdir: "/src/app"}}
+	other := &RunContext{Config: &Config{Workdir: "/src/lib"}}
+
+	assert.Equal(t, "act-org-build-docker-v1-docker-dockeraction", rc.dockerActionRepository("org-build-docker@v1/docker"))
+	assert.Equal(t, rc.dockerActionRepository("org-build-docker@v1/docker"), other.dockerActionRepository("org-build-docker@v1/docker"))
+	assert.True(t, strings.HasPrefix(rc.dockerActionRepository("./"), "act-dockeraction-"))
+	assert.NotEqual(t, rc.dockerActionRepository("./"), other.dockerActionRepository("./"), "local actions of different repositories have their own images")
+
+	images := &dockerActionImages{}
+	images.add("act-build-dockeraction", "act-build-dockeraction:1")
+	images.add("act-build-dockeraction", "act-build-dockeraction:2")
+	images.add("act-build-dockeraction", "act-build-dockeraction:1")
+	assert.Equal(t, []string{"act-build-dockeraction:1", "act-build-dockeraction:2"}, images.images["act-build-dockeraction"])
+	(*dockerActionImages)(nil).add("act-build-dockeraction", "act-build-dockeraction:3")
+}
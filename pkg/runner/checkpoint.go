@@ -0,0 +1,213 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/nektos/act/pkg/common"
+	"github.com/nektos/act/pkg/container"
+	"github.com/nektos/act/pkg/exprparser"
+	"github.com/nektos/act/pkg/model"
+)
+
+// jobCheckpoint is the state of a job after a step, the filesystem of the job
+// container is committed to Image and the workspace volume is saved to Workspace
+type jobCheckpoint struct {
+	Image       string                       `json:"image"`
+	Run         string                       `json:"run"`
+	StepID      string                       `json:"step-id"`
+	Workspace   string                       `json:"workspace,omitempty"`
+	Env         map[string]string            `json:"env"`
+	GlobalEnv   map[string]string            `json:"global-env"`
+	ExtraPath   []string                     `json:"path"`
+	StepResults map[string]*model.StepResult `json:"steps"`
+	Needs       map[string]exprparser.Needs  `json:"needs"`
+}
+
+// checkpointDir returns the directory of the checkpoints of the job, the latest
+// checkpoint of each step is kept
+func (rc *RunContext) checkpointDir() string {
+	return filepath.Join(rc.ActionCacheDir(), "checkpoints", rc.jobContainerName())
+}
+
+func (rc *RunContext) checkpointPath(number int) string {
+	return filepath.Join(rc.checkpointDir(), fmt.Sprintf("%d.json", number))
+}
+
+// readCheckpoint returns the checkpoint taken after the step with the 1-based number or nil if there is none
+func (rc *RunContext) readCheckpoint(number int) (*jobCheckpoint, error) {
+	content, err := os.ReadFile(rc.checkpointPath(number))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	checkpoint := &jobCheckpoint{}
+	if err := json.Unmarshal(content, checkpoint); err != nil {
+		return nil, fmt.Errorf("unable to read checkpoint %s: %w", rc.checkpointPath(number), err)
+	}
+	return checkpoint, nil
+}
+
+// checkpoint commits the job container and saves the workspace, env, path and
+// step results after the step with the 0-based index
+func (rc *RunContext) checkpoint(ctx context.Context, index int, stepModel *model.Step) error {
+	logger := common.Logger(ctx)
+	checkpointer, ok := rc.JobContainer.(container.Checkpointer)
+	if !ok {
+		logger.Debugf("Skipping checkpoint of step '%s', the job container does not support checkpoints", stepModel.ID)
+		return nil
+	}
+
+	number := index + 1
+	previous, err := rc.readCheckpoint(number)
+	if err != nil {
+		logger.Debugf("%v", err)
+	}
+
+	if err := os.MkdirAll(rc.checkpointDir(), 0o700); err != nil {
+		return err
+	}
+	checkpoint := &jobCheckpoint{
+		Image:       fmt.Sprintf("act-checkpoint/%s:%s-%d", strings.ToLower(rc.jobContainerName()), rc.checkpointRun, number),
+		Run:         rc.checkpointRun,
+		StepID:      stepModel.ID,
+		Env:         rc.Env,
+		GlobalEnv:   rc.GlobalEnv,
+		ExtraPath:   rc.ExtraPath,
+		StepResults: rc.StepResults,
+		Needs:       getEvaluatorNeeds(rc),
+	}
+
+	if err := checkpointer.Commit(checkpoint.Image)(ctx); err != nil {
+		return err
+	}
+	if !rc.Config.BindWorkdir {
+		checkpoint.Workspace = filepath.Join(rc.checkpointDir(), fmt.Sprintf("%d-workspace.tar", number))
+		if err := rc.saveCheckpointWorkspace(ctx, checkpoint.Workspace); err != nil {
+			return err
+		}
+	}
+
+	content, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(rc.checkpointPath(number), content, 0o600); err != nil {
+		return err
+	}
+	logger.Infof("  \U0001F4BE  Checkpoint %s", checkpoint.Image)
+
+	if previous != nil && previous.Image != checkpoint.Image {
+		if _, err := container.RemoveImage(ctx, previous.Image, false, false); err != nil {
+			logger.Debugf("Unable to remove the previous checkpoint image %s: %v", previous.Image, err)
+		}
+	}
+	return nil
+}
+
+func (rc *RunContext) saveCheckpointWorkspace(ctx context.Context, dest string) error {
+	archive, err := rc.JobContainer.GetContainerArchive(ctx, rc.JobContainer.ToContainerPath(rc.Config.Workdir))
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+
+	f, err := os.OpenFile(dest, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, archive); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// restoreCheckpointWorkspace replaces the content of the workspace volume with the saved workspace of the checkpoint
+func (rc *RunContext) restoreCheckpointWorkspace() common.Executor {
+	return func(ctx context.Context) error {
+		if rc.resume == nil || rc.resume.Workspace == "" {
+			return nil
+		}
+		workspace := rc.JobContainer.ToContainerPath(rc.Config.Workdir)
+		if err := rc.JobContainer.Exec([]string{"find", workspace, "-mindepth", "1", "-delete"}, nil, "0", "")(ctx); err != nil {
+			return err
+		}
+
+		f, err := os.Open(rc.resume.Workspace)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return rc.JobContainer.CopyTarStream(ctx, path.Dir(workspace), f)
+	}
+}
+
+// findResumeStep returns the 0-based index of the step matching the id, name or 1-based number in from
+func findResumeStep(steps []*model.Step, from string) (int, error) {
+	for i, step := range steps {
+		if step != nil && (step.ID == from || step.Name == from) {
+			return i, nil
+		}
+	}
+	if number, err := strconv.Atoi(from); err == nil && number >= 1 && number <= len(steps) {
+		return number - 1, nil
+	}
+	return 0, fmt.Errorf("unable to resume, the job has no step '%s'", from)
+}
+
+// setupResume loads the checkpoint of the step before the step to resume from, restores
+// the needs of the job and returns the index of the first step to run
+func (rc *RunContext) setupResume(steps []*model.Step) (int, error) {
+	index, err := findResumeStep(steps, rc.Config.ResumeFrom)
+	if err != nil {
+		return 0, err
+	}
+	if index == 0 {
+		return 0, fmt.Errorf("unable to resume from the first step '%s', run the job without resume instead", rc.Config.ResumeFrom)
+	}
+	checkpoint, err := rc.readCheckpoint(index)
+	if err != nil {
+		return 0, err
+	}
+	if checkpoint == nil {
+		return 0, fmt.Errorf("no checkpoint of step %d of job '%s' found, run the job with --checkpoint first", index, rc.JobName)
+	}
+	rc.resume = checkpoint
+	// the needs are part of the job if and env, which are evaluated before the first step
+	if rc.externalNeeds == nil {
+		rc.externalNeeds = map[string]exprparser.Needs{}
+	}
+	for id, needs := range checkpoint.Needs {
+		rc.externalNeeds[id] = needs
+	}
+	return index, nil
+}
+
+// restoreCheckpointState restores the env, path and step results of the checkpoint
+func (rc *RunContext) restoreCheckpointState(ctx context.Context) error {
+	checkpoint := rc.resume
+	common.Logger(ctx).Infof("\u23EA  Resuming after step '%s' from %s", checkpoint.StepID, checkpoint.Image)
+
+	if rc.Env == nil {
+		rc.Env = map[string]string{}
+	}
+	for k, v := range checkpoint.Env {
+		rc.Env[k] = v
+	}
+	rc.GlobalEnv = checkpoint.GlobalEnv
+	rc.ExtraPath = checkpoint.ExtraPath
+	for id, result := range checkpoint.StepResults {
+		rc.StepResults[id] = result
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+
+	"github.com/nektos/act/pkg/exprparser"
+	"github.com/nektos/act/pkg/model"
+)
+
+func TestFindResumeStep(t *testing.T) {
+	steps := []*model.Step{
+		{ID: "checkout"},
+		{Name: "Install dependencies"},
+		{ID: "test", Name: "Run tests"},
+	}
+
+	tables := []struct {
+		from     string
+		expected int
+		err      bool
+	}{
+		{"checkout", 0, false},
+		{"Install dependencies", 1, false},
+		{"test", 2, false},
+		{"Run tests", 2, false},
+		{"2", 1, false},
+		{"0", 0, true},
+		{"4", 0, true},
+		{"deploy", 0, true},
+	}
+
+	for _, table := range tables {
+		t.Run(table.from, func(t *testing.T) {
+			index, err := findResumeStep(steps, table.from)
+			if table.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, table.expected, index)
+		})
+	}
+}
+
+func TestResumeCheckpoint(t *testing.T) {
+	workflow := &model.Workflow{
+		Name: "CI",
+		Jobs: map[string]*model.Job{
+			"setup": {},
+			"build": {RawNeeds: yaml.Node{Kind: yaml.ScalarNode, Value: "setup"}},
+		},
+	}
+	newRunContext := func(from string) *RunContext {
+		return &RunContext{
+			Name:        "build",
+			Config:      &Config{ActionCacheDir: t.TempDir(), ResumeFrom: from},
+			StepResults: map[string]*model.StepResult{},
+			Run:         &model.Run{JobID: "build", Workflow: workflow},
+		}
+	}
+	steps := []*model.Step{{ID: "checkout"}, {ID: "deps"}, {ID: "test"}}
+
+	rc := newRunContext("checkout")
+	_, err := rc.setupResume(steps)
+	assert.Error(t, err, "the first step has no checkpoint to resume from")
+
+	rc = newRunContext("test")
+	_, err = rc.setupResume(steps)
+	assert.Error(t, err, "the job was not run with checkpoints")
+
+	checkpoint := &jobCheckpoint{
+		Image:       "act-checkpoint/act-ci-build:20240101000000-2",
+		StepID:      "deps",
+		Env:         map[string]string{"DEPS": "ok"},
+		GlobalEnv:   map[string]string{"GLOBAL": "1"},
+		ExtraPath:   []string{"/opt/deps/bin"},
+		StepResults: map[string]*model.StepResult{"deps": {Outputs: map[string]string{"version": "1.2.3"}}},
+		Needs:       map[string]exprparser.Needs{"setup": {Result: "success", Outputs: map[string]string{"key": "value"}}},
+	}
+	content, err := json.Marshal(checkpoint)
+	assert.NoError(t, err)
+	assert.NoError(t, os.MkdirAll(rc.checkpointDir(), 0o755))
+	assert.NoError(t, os.WriteFile(rc.checkpointPath(2), content, 0o644))
+
+	index, err := rc.setupResume(steps)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, index)
+	assert.Equal(t, checkpoint.Image, rc.resume.Image)
+	assert.Equal(t, "value", rc.NewExpressionEvaluator(context.Background()).Interpolate(context.Background(), "${{ needs.setup.outputs.key }}"),
+		"the needs are restored before the job if and env are evaluated")
+
+	assert.NoError(t, rc.restoreCheckpointState(context.Background()))
+	assert.Equal(t, "ok", rc.Env["DEPS"])
+	assert.Equal(t, map[string]string{"GLOBAL": "1"}, rc.GlobalEnv)
+	assert.Equal(t, []string{"/opt/deps/bin"}, rc.ExtraPath)
+	assert.Equal(t, "1.2.3", rc.StepResults["deps"].Outputs["version"])
+	assert.Empty(t, workflow.GetJob("setup").Result)
+}
@@ -0,0 +1,217 @@
+package runner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/nektos/act/pkg/common"
+	"github.com/nektos/act/pkg/container"
+)
+
+const containerPoolPrefix = "act-pool-"
+
+var errPoolContainerClaimed = errors.New("the pooled container is used by another process")
+
+// containerPool keeps the job containers of finished jobs running, a job takes
+// an idle container with the same key instead of creating a new one. The names
+// of the pooled containers only depend on the key and a slot number, so the
+// containers of earlier runs are reused as well. A container is claimed across
+// processes with a lock of its file in the container-pool directory of the action cache.
+type containerPool struct {
+	mu        sync.Mutex
+	inUse     map[string]bool
+	nodeTools map[string]string
+}
+
+// pooledContainer is a job container taken from the pool
+type pooledContainer struct {
+	name     string
+	nodeTool string
+	lock     *os.File
+}
+
+var jobContainerPool = newContainerPool()
+
+func newContainerPool() *containerPool {
+	return &containerPool{
+		inUse:     map[string]bool{},
+		nodeTools: map[string]string{},
+	}
+}
+
+// acquire returns the first container of the key, which is not used by another job of this or another process
+func (p *containerPool) acquire(lockDir string, key string) (*pooledContainer, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for slot := 0; ; slot++ {
+		name := fmt.Sprintf("%s%s-%d", containerPoolPrefix, key, slot)
+		if p.inUse[name] {
+			continue
+		}
+		lock, err := claimPoolContainer(lockDir, name)
+		if errors.Is(err, errPoolContainerClaimed) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		p.inUse[name] = true
+		return &pooledContainer{name: name, nodeTool: p.nodeTools[name], lock: lock}, nil
+	}
+}
+
+// release returns the container to the pool, the node path found by the job is kept for the next job
+func (p *containerPool) release(c *pooledContainer, nodeTool string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.inUse, c.name)
+	c.lock.Close()
+	if nodeTool != "" {
+		p.nodeTools[c.name] = nodeTool
+	}
+}
+
+// claimPoolContainer locks the file of the pooled container, the returned file holds the lock until it is closed
+func claimPoolContainer(lockDir string, name string) (*os.File, error) {
+	if err := os.MkdirAll(lockDir, 0o700); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(filepath.Join(lockDir, name+".lock"), os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	locked, err := tryLockFile(f)
+	if err != nil || !locked {
+		f.Close()
+		if err == nil {
+			err = errPoolContainerClaimed
+		}
+		return nil, err
+	}
+	return f, nil
+}
+
+// containerPoolLockDir returns the directory of the lock files of the pooled containers
+func containerPoolLockDir(actionCacheDir string) string {
+	return filepath.Join(actionCacheDir, "container-pool")
+}
+
+// RemoveContainerPool removes the pooled job containers of --container-pool and their
+// volumes, containers used by a job of a running process are kept
+func RemoveContainerPool(ctx context.Context, actionCacheDir string) error {
+	logger := common.Logger(ctx)
+	names, err := container.ListContainerNames(ctx, containerPoolPrefix)
+	if err != nil {
+		return err
+	}
+	lockDir := containerPoolLockDir(actionCacheDir)
+	for _, name := range names {
+		lock, err := claimPoolContainer(lockDir, name)
+		if errors.Is(err, errPoolContainerClaimed) {
+			logger.Infof("Keeping pooled container %s, it is used by a running job", name)
+			continue
+		} else if err != nil {
+			return err
+		}
+		err = container.NewContainer(&container.NewContainerInput{Name: name}).Remove().
+			Then(container.NewDockerVolumeRemoveExecutor(name, false)).
+			Then(container.NewDockerVolumeRemoveExecutor(name+"-env", false))(ctx)
+		// the lock file is kept, another process may have opened it already
+		lock.Close()
+		if err != nil {
+			return fmt.Errorf("failed to remove pooled container %s: %w", name, err)
+		}
+		logger.Infof("Removed pooled container %s", name)
+	}
+	return nil
+}
+
+// containerPoolKey returns the pool key of the job container or an empty string
+// if the job has to run in a container of its own. Containers of jobs with
+// services, resumed from a checkpoint or with an overlay workspace are never pooled.
+func (rc *RunContext) containerPoolKey(ctx context.Context, image string, binds []string, network string) string {
+	if !rc.Config.ContainerPool || rc.resume != nil || rc.overlay != nil || len(rc.Run.Job().Services) > 0 {
+		return ""
+	}
+
+	var volumes []string
+	if c := rc.Run.Job().Container(); c != nil {
+		volumes = append(volumes, c.Volumes...)
+	}
+	sortedBinds := append([]string{}, binds...)
+	sort.Strings(sortedBinds)
+	capAdd := append([]string{}, rc.Config.ContainerCapAdd...)
+	sort.Strings(capAdd)
+	capDrop := append([]string{}, rc.Config.ContainerCapDrop...)
+	sort.Strings(capDrop)
+
+	config, err := json.Marshal(map[string]interface{}{
+		"image":      image,
+		"options":    rc.options(ctx),
+		"platform":   rc.Config.ContainerArchitecture,
+		"network":    network,
+		"privileged": rc.Config.Privileged,
+		"userns":     rc.Config.UsernsMode,
+		"cap-add":    capAdd,
+		"cap-drop":   capDrop,
+		"binds":      sortedBinds,
+		"volumes":    volumes,
+		"workdir":    rc.Config.Workdir,
+	})
+	if err != nil {
+		common.Logger(ctx).Debugf("Unable to pool the job container: %v", err)
+		return ""
+	}
+	digest := sha256.Sum256(config)
+	return hex.EncodeToString(digest[:6])
+}
+
+// pooledContainerImageID returns the ID of the image of a pooled container
+var pooledContainerImageID = container.ContainerImageID
+
+// removeOutdatedPooledContainer removes the pooled container if the image of the job was
+// pulled or built again since the container was created, the key only knows the image name
+func (rc *RunContext) removeOutdatedPooledContainer(image string) common.Executor {
+	return func(ctx context.Context) error {
+		if rc.pooled == nil || common.Dryrun(ctx) {
+			return nil
+		}
+		containerImage, err := pooledContainerImageID(ctx, rc.pooled.name)
+		if err != nil || containerImage == "" {
+			return err
+		}
+		imageID, err := containerImageID(ctx, image)
+		if err != nil || imageID == containerImage {
+			return err
+		}
+		common.Logger(ctx).Infof("Recreating pooled container %s, its image %s changed", rc.pooled.name, image)
+		return rc.JobContainer.Remove()(ctx)
+	}
+}
+
+// resetPooledContainer removes the workspace and the act state left behind by the previous job of the container
+func (rc *RunContext) resetPooledContainer() common.Executor {
+	return func(ctx context.Context) error {
+		if rc.pooled == nil {
+			return nil
+		}
+		paths := []string{rc.JobContainer.GetActPath()}
+		// a bound working directory belongs to the user and is never cleaned
+		if !rc.Config.BindWorkdir {
+			paths = append(paths, rc.JobContainer.ToContainerPath(rc.Config.Workdir))
+		}
+		for _, p := range paths {
+			if err := rc.JobContainer.Exec([]string{"find", p, "-mindepth", "1", "-delete"}, nil, "0", "")(ctx); err != nil {
+				return fmt.Errorf("failed to reset pooled container %s: %w", rc.pooled.name, err)
+			}
+		}
+		return nil
+	}
+}
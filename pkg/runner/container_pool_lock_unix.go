@@ -0,0 +1,20 @@
+//go:build unix
+
+package runner
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// tryLockFile takes an exclusive lock of the file without waiting for it, the
+// lock is held until the file is closed or the process exits
+func tryLockFile(f *os.File) (bool, error) {
+	err := unix.FcntlFlock(f.Fd(), unix.F_SETLK, &unix.Flock_t{Type: unix.F_WRLCK})
+	if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EACCES) {
+		return false, nil
+	}
+	return err == nil, err
+}
@@ -0,0 +1,131 @@
+package runner
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nektos/act/pkg/model"
+)
+
+func TestContainerPool(t *testing.T) {
+	pool := newContainerPool()
+	lockDir := t.TempDir()
+	acquire := func(key string) *pooledContainer {
+		c, err := pool.acquire(lockDir, key)
+		require.NoError(t, err)
+		return c
+	}
+
+	first := acquire("abc")
+	second := acquire("abc")
+	other := acquire("def")
+	assert.Equal(t, "act-pool-abc-0", first.name)
+	assert.Equal(t, "act-pool-abc-1", second.name)
+	assert.Equal(t, "act-pool-def-0", other.name)
+	assert.Empty(t, first.nodeTool)
+
+	pool.release(first, "/usr/local/bin/node")
+	reused := acquire("abc")
+	assert.Equal(t, "act-pool-abc-0", reused.name)
+	assert.Equal(t, "/usr/local/bin/node", reused.nodeTool)
+}
+
+// TestContainerPoolHelperProcess claims a pooled container for another process until its stdin is closed
+func TestContainerPoolHelperProcess(t *testing.T) {
+	lockDir := os.Getenv("ACT_TEST_POOL_LOCK_DIR")
+	if lockDir == "" {
+		t.Skip("helper process of TestContainerPoolAcrossProcesses")
+	}
+	lock, err := claimPoolContainer(lockDir, "act-pool-abc-0")
+	require.NoError(t, err)
+	defer lock.Close()
+	fmt.Println("claimed")
+	_, _ = io.Copy(io.Discard, os.Stdin)
+}
+
+func TestContainerPoolAcrossProcesses(t *testing.T) {
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" && runtime.GOOS != "windows" {
+		t.Skip("pooled containers are claimed across processes with file locks")
+	}
+	lockDir := t.TempDir()
+	cmd := exec.Command(os.Args[0], "-test.run=^TestContainerPoolHelperProcess$")
+	cmd.Env = append(os.Environ(), "ACT_TEST_POOL_LOCK_DIR="+lockDir)
+	stdin, err := cmd.StdinPipe()
+	require.NoError(t, err)
+	stdout, err := cmd.StdoutPipe()
+	require.NoError(t, err)
+	require.NoError(t, cmd.Start())
+	line, err := bufio.NewReader(stdout).ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "claimed\n", line)
+
+	pool := newContainerPool()
+	c, err := pool.acquire(lockDir, "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "act-pool-abc-1", c.name, "the container claimed by the other process is skipped")
+	pool.release(c, "")
+
+	require.NoError(t, stdin.Close())
+	require.NoError(t, cmd.Wait())
+	c, err = pool.acquire(lockDir, "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "act-pool-abc-0", c.name, "the claim ends with the other process")
+	pool.release(c, "")
+}
+
+func TestContainerPoolKey(t *testing.T) {
+	ctx := context.Background()
+	newRunContext := func(pool bool, job *model.Job) *RunContext {
+		return &RunContext{
+			Config: &Config{ContainerPool: pool, Workdir: "/src"},
+			Run: &model.Run{
+				JobID:    "build",
+				Workflow: &model.Workflow{Jobs: map[string]*model.Job{"build": job}},
+			},
+		}
+	}
+
+	assert.Empty(t, newRunContext(false, &model.Job{}).containerPoolKey(ctx, "node:20", nil, "host"))
+	assert.Empty(t, newRunContext(true, &model.Job{Services: map[string]*model.ContainerSpec{"db": {Image: "postgres"}}}).containerPoolKey(ctx, "node:20", nil, "host"))
+
+	key := newRunContext(true, &model.Job{}).containerPoolKey(ctx, "node:20", []string{"/a:/a", "/b:/b"}, "host")
+	assert.NotEmpty(t, key)
+	assert.Equal(t, key, newRunContext(true, &model.Job{}).containerPoolKey(ctx, "node:20", []string{"/b:/b", "/a:/a"}, "host"))
+	assert.NotEqual(t, key, newRunContext(true, &model.Job{}).containerPoolKey(ctx, "node:22", []string{"/a:/a", "/b:/b"}, "host"))
+	assert.NotEqual(t, key, newRunContext(true, &model.Job{}).containerPoolKey(ctx, "node:20", []string{"/a:/a", "/b:/b"}, "bridge"))
+}
+
+func TestRemoveOutdatedPooledContainer(t *testing.T) {
+	ctx := context.Background()
+	imageIDs := map[string]string{"node:20": "sha256:1"}
+	containerImages := map[string]string{"act-pool-abc-0": "sha256:1"}
+	origContainerImageID, origPooledContainerImageID := containerImageID, pooledContainerImageID
+	containerImageID = func(_ context.Context, image string) (string, error) {
+		return imageIDs[image], nil
+	}
+	pooledContainerImageID = func(_ context.Context, name string) (string, error) {
+		return containerImages[name], nil
+	}
+	defer func() {
+		containerImageID, pooledContainerImageID = origContainerImageID, origPooledContainerImageID
+	}()
+
+	cm := &containerMock{}
+	rc := &RunContext{JobContainer: cm, pooled: &pooledContainer{name: "act-pool-abc-0"}}
+	assert.NoError(t, rc.removeOutdatedPooledContainer("node:20")(ctx))
+	cm.AssertNotCalled(t, "Remove")
+
+	imageIDs["node:20"] = "sha256:2"
+	cm.On("Remove").Return(func(context.Context) error { return nil })
+	assert.NoError(t, rc.removeOutdatedPooledContainer("node:20")(ctx))
+	cm.AssertCalled(t, "Remove")
+}
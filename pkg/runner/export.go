@@ -0,0 +1,239 @@
+package runner
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/nektos/act/pkg/common"
+)
+
+// JobExport is a path of a job container copied to the host after the job
+type JobExport struct {
+	Job  string // id of the job
+	Path string // path in the job container, relative to the workspace unless absolute
+	Dest string // directory on the host the path is extracted to
+}
+
+// ParseJobExport parses an export of --export in the form <job>:<container-path>=<host-dir>
+func ParseJobExport(spec string) (*JobExport, error) {
+	job, rest, ok := strings.Cut(spec, ":")
+	if !ok || job == "" {
+		return nil, fmt.Errorf("invalid export '%s', expected <job>:<container-path>=<host-dir>", spec)
+	}
+	containerPath, dest, ok := strings.Cut(rest, "=")
+	if !ok || containerPath == "" || dest == "" {
+		return nil, fmt.Errorf("invalid export '%s', expected <job>:<container-path>=<host-dir>", spec)
+	}
+	return &JobExport{Job: job, Path: containerPath, Dest: dest}, nil
+}
+
+// exportJobFiles copies the workspace of --export-workspace and the paths of --export
+// out of the job container after the job, before the container is removed
+func (rc *RunContext) exportJobFiles() common.Executor {
+	return func(ctx context.Context) error {
+		if rc.JobContainer == nil || rc.Config.ExportWorkspaceDir == "" && len(rc.Config.Exports) == 0 {
+			return nil
+		}
+		logger := common.Logger(ctx)
+		workspace := rc.JobContainer.ToContainerPath(rc.Config.Workdir)
+
+		// a bound workspace is the working directory and the upper layer of an overlay is exported when the overlay is removed
+		if rc.Config.ExportWorkspaceDir != "" && !rc.Config.BindWorkdir && rc.Config.WorkspaceMode != WorkspaceModeOverlay {
+			dest := rc.workspaceExportPath()
+			if err := rc.exportContainerPath(ctx, workspace, dest, true); err != nil {
+				return fmt.Errorf("failed to export the workspace of job '%s': %w", rc.String(), err)
+			}
+			logger.Infof("\U0001F4E4  Exported the workspace to %s", dest)
+		}
+
+		for _, export := range rc.Config.Exports {
+			if export.Job != rc.Run.JobID {
+				continue
+			}
+			containerPath := export.Path
+			if !path.IsAbs(containerPath) {
+				containerPath = path.Join(workspace, containerPath)
+			}
+			dest := export.Dest
+			if len(rc.Matrix) > 0 {
+				// every combination of a matrix job gets a directory of its own
+				dest = filepath.Join(dest, safeFilename(rc.Name))
+			}
+			if err := rc.exportContainerPath(ctx, containerPath, dest, false); err != nil {
+				return fmt.Errorf("failed to export %s of job '%s': %w", containerPath, rc.String(), err)
+			}
+			logger.Infof("\U0001F4E4  Exported %s to %s", containerPath, dest)
+		}
+		return nil
+	}
+}
+
+// exportContainerPath extracts the path of the job container to the directory dest, the
+// content of a directory is extracted to dest itself if stripRoot is set
+func (rc *RunContext) exportContainerPath(ctx context.Context, containerPath string, dest string, stripRoot bool) error {
+	if common.Dryrun(ctx) {
+		return nil
+	}
+	archive, err := rc.JobContainer.GetContainerArchive(ctx, containerPath)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+
+	if stripRoot {
+		if err := os.RemoveAll(dest); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(dest, 0o755); err != nil {
+		return err
+	}
+	return extractArchive(archive, dest, path.Base(containerPath), stripRoot)
+}
+
+// extractArchive extracts the directories, regular files, symlinks and hard links of the
+// archive of the path named root to dest with their permissions and modification times,
+// entries outside of dest are rejected. The entries of docker archives start with root,
+// the entries of host environment archives of directories are relative to the directory.
+func extractArchive(archive io.Reader, dest string, root string, stripRoot bool) error {
+	type dirEntry struct {
+		path   string
+		header *tar.Header
+	}
+	dirs := []dirEntry{}
+	prefixed := false
+	entryName := func(name string) string {
+		name = path.Clean(strings.TrimPrefix(name, "/"))
+		switch {
+		case prefixed && stripRoot:
+			_, name, _ = strings.Cut(name, "/")
+		case !prefixed && !stripRoot:
+			name = path.Join(root, name)
+		}
+		return name
+	}
+
+	tr := tar.NewReader(archive)
+	for first := true; ; first = false {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		if first {
+			name := path.Clean(strings.TrimPrefix(header.Name, "/"))
+			prefixed = name == root || strings.HasPrefix(name, root+"/")
+		}
+		name := entryName(header.Name)
+		if name == "" || name == "." {
+			continue
+		}
+		if !filepath.IsLocal(filepath.FromSlash(name)) {
+			return fmt.Errorf("archive entry '%s' is outside of the export directory", header.Name)
+		}
+		target := filepath.Join(dest, filepath.FromSlash(name))
+		if err := checkExportParent(dest, target); err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		mode := os.FileMode(header.Mode).Perm()
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+			dirs = append(dirs, dirEntry{path: target, header: header})
+			continue
+		case tar.TypeReg:
+			if err := os.RemoveAll(target); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_EXCL, mode)
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(f, tr); err != nil {
+				f.Close()
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := os.RemoveAll(target); err != nil {
+				return err
+			}
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return err
+			}
+			continue
+		case tar.TypeLink:
+			linkName := entryName(header.Linkname)
+			if !filepath.IsLocal(filepath.FromSlash(linkName)) {
+				return fmt.Errorf("archive entry '%s' links outside of the export directory", header.Name)
+			}
+			if err := os.RemoveAll(target); err != nil {
+				return err
+			}
+			if err := os.Link(filepath.Join(dest, filepath.FromSlash(linkName)), target); err != nil {
+				return err
+			}
+			continue
+		default:
+			continue
+		}
+		if err := os.Chmod(target, mode); err != nil {
+			return err
+		}
+		if err := os.Chtimes(target, header.ModTime, header.ModTime); err != nil {
+			return err
+		}
+	}
+
+	// the files of a directory change its modification time and read-only directories
+	// would reject their files, so directories are updated last
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if err := os.Chmod(dirs[i].path, os.FileMode(dirs[i].header.Mode).Perm()); err != nil {
+			return err
+		}
+		if err := os.Chtimes(dirs[i].path, dirs[i].header.ModTime, dirs[i].header.ModTime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkExportParent rejects a target, whose closest existing parent directory is a symlink leaving dest
+func checkExportParent(dest string, target string) error {
+	parent := filepath.Dir(target)
+	for {
+		if _, err := os.Lstat(parent); err == nil || parent == dest {
+			break
+		}
+		parent = filepath.Dir(parent)
+	}
+	parent, err := filepath.EvalSymlinks(parent)
+	if err != nil {
+		return err
+	}
+	root, err := filepath.EvalSymlinks(dest)
+	if err != nil {
+		return err
+	}
+	if rel, err := filepath.Rel(root, parent); err != nil || !filepath.IsLocal(rel) && rel != "." {
+		return fmt.Errorf("'%s' is outside of the export directory", target)
+	}
+	return nil
+}
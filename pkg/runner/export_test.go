@@ -0,0 +1,138 @@
+package runner
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nektos/act/pkg/model"
+)
+
+func TestParseJobExport(t *testing.T) {
+	export, err := ParseJobExport("build:dist/app=./out")
+	assert.NoError(t, err)
+	assert.Equal(t, &JobExport{Job: "build", Path: "dist/app", Dest: "./out"}, export)
+
+	for _, spec := range []string{"build", "build:dist", ":dist=out", "build:=out", "build:dist="} {
+		_, err := ParseJobExport(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func newTestArchive(t *testing.T, headers ...*tar.Header) *bytes.Buffer {
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	for _, header := range headers {
+		// regular files contain their own name
+		body := ""
+		if header.Typeflag == tar.TypeReg {
+			body = header.Name
+			header.Size = int64(len(body))
+		}
+		assert.NoError(t, tw.WriteHeader(header))
+		_, err := tw.Write([]byte(body))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+	return buf
+}
+
+func TestExtractArchive(t *testing.T) {
+	modTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	archive := newTestArchive(t,
+		&tar.Header{Name: "workspace/", Typeflag: tar.TypeDir, Mode: 0o755},
+		&tar.Header{Name: "workspace/bin/", Typeflag: tar.TypeDir, Mode: 0o555, ModTime: modTime},
+		&tar.Header{Name: "workspace/bin/run.sh", Typeflag: tar.TypeReg, Mode: 0o755, ModTime: modTime},
+		&tar.Header{Name: "workspace/run", Typeflag: tar.TypeSymlink, Linkname: "bin/run.sh"},
+		&tar.Header{Name: "workspace/copy.sh", Typeflag: tar.TypeLink, Linkname: "workspace/bin/run.sh"},
+	)
+	dest := t.TempDir()
+	assert.NoError(t, extractArchive(archive, dest, "workspace", true))
+
+	info, err := os.Stat(filepath.Join(dest, "bin", "run.sh"))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o755), info.Mode().Perm())
+	assert.True(t, modTime.Equal(info.ModTime()))
+	info, err = os.Stat(filepath.Join(dest, "bin"))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o555), info.Mode().Perm())
+	assert.True(t, modTime.Equal(info.ModTime()))
+
+	link, err := os.Readlink(filepath.Join(dest, "run"))
+	assert.NoError(t, err)
+	assert.Equal(t, "bin/run.sh", link)
+	content, err := os.ReadFile(filepath.Join(dest, "copy.sh"))
+	assert.NoError(t, err)
+	assert.Equal(t, "workspace/bin/run.sh", string(content))
+	assert.NoError(t, os.Chmod(filepath.Join(dest, "bin"), 0o755))
+}
+
+func TestExtractHostArchive(t *testing.T) {
+	// the archives of the host environment are relative to the directory
+	archive := newTestArchive(t, &tar.Header{Name: "app", Typeflag: tar.TypeReg, Mode: 0o644})
+	dest := t.TempDir()
+	assert.NoError(t, extractArchive(archive, dest, "dist", false))
+	assert.FileExists(t, filepath.Join(dest, "dist", "app"))
+}
+
+func TestExtractArchiveOutsideDest(t *testing.T) {
+	dest := t.TempDir()
+	assert.Error(t, extractArchive(newTestArchive(t, &tar.Header{Name: "../escape.txt", Typeflag: tar.TypeReg, Mode: 0o644}), dest, "workspace", true))
+
+	outside := t.TempDir()
+	assert.Error(t, extractArchive(newTestArchive(t,
+		&tar.Header{Name: "out", Typeflag: tar.TypeSymlink, Linkname: outside},
+		&tar.Header{Name: "out/escape.txt", Typeflag: tar.TypeReg, Mode: 0o644},
+	), dest, "out", false))
+	assert.NoFileExists(t, filepath.Join(outside, "escape.txt"))
+}
+
+func TestExportJobFiles(t *testing.T) {
+	ctx := context.Background()
+	dest := t.TempDir()
+	cm := &containerMock{}
+	cm.On("GetContainerArchive", ctx, "/src/dist").Return(io.NopCloser(newTestArchive(t,
+		&tar.Header{Name: "dist/", Typeflag: tar.TypeDir, Mode: 0o755},
+		&tar.Header{Name: "dist/app", Typeflag: tar.TypeReg, Mode: 0o644},
+	)), nil)
+
+	rc := &RunContext{
+		Name: "build",
+		Config: &Config{
+			Workdir: "/src",
+			Exports: []*JobExport{
+				{Job: "build", Path: "dist", Dest: dest},
+				{Job: "test", Path: "coverage", Dest: dest},
+			},
+		},
+		Run:          &model.Run{JobID: "build", Workflow: &model.Workflow{Name: "ci"}},
+		JobContainer: cm,
+	}
+	assert.NoError(t, rc.exportJobFiles()(ctx))
+	assert.FileExists(t, filepath.Join(dest, "dist", "app"))
+	cm.AssertExpectations(t)
+}
+
+func TestExportOverlayWorkspace(t *testing.T) {
+	ctx := context.Background()
+	exportDir := t.TempDir()
+	// the upper layer of the overlay is moved to the export directory when the overlay is removed
+	cm := &containerMock{}
+	rc := &RunContext{
+		Name:         "build",
+		Config:       &Config{Workdir: "/src", WorkspaceMode: WorkspaceModeOverlay, ExportWorkspaceDir: exportDir},
+		Run:          &model.Run{JobID: "build", Workflow: &model.Workflow{Name: "ci"}},
+		JobContainer: cm,
+	}
+	assert.NoError(t, rc.exportJobFiles()(ctx))
+	assert.NoDirExists(t, filepath.Join(exportDir, "ci-build"))
+	cm.AssertNotCalled(t, "GetContainerArchive", mock.Anything, mock.Anything)
+}
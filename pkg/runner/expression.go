@@ -1,19 +1,14 @@
 package runner
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"path"
 	"reflect"
 	"regexp"
 	"strings"
 	"time"
 
-	_ "embed"
-
 	"github.com/nektos/act/pkg/common"
-	"github.com/nektos/act/pkg/container"
 	"github.com/nektos/act/pkg/exprparser"
 	"github.com/nektos/act/pkg/model"
 	"gopkg.in/yaml.v3"
@@ -45,19 +40,12 @@ func (rc *RunContext) NewExpressionEvaluatorWithEnv(ctx context.Context, env map
 		}
 
 		jobs := rc.Run.Workflow.Jobs
-		jobNeeds := rc.Run.Job().Needs()
-
-		for _, needs := range jobNeeds {
-			using[needs] = exprparser.Needs{
-				Outputs: jobs[needs].Outputs,
-				Result:  jobs[needs].Result,
-			}
-		}
+		using = getEvaluatorNeeds(rc)
 
 		// only setup jobs context in case of workflow_call
 		// and existing expression evaluator (this means, jobs are at
 		// least ready to run)
-		if rc.caller != nil && rc.ExprEval != nil {
+		if (rc.caller != nil || rc.Config.EventName == "workflow_call") && rc.ExprEval != nil {
 			workflowCallResult = map[string]*model.WorkflowCallResult{}
 
 			for jobName, job := range jobs {
@@ -96,16 +84,14 @@ func (rc *RunContext) NewExpressionEvaluatorWithEnv(ctx context.Context, env map
 	}
 	return expressionEvaluator{
 		interpreter: exprparser.NewInterpeter(ee, exprparser.Config{
-			Run:        rc.Run,
-			WorkingDir: rc.Config.Workdir,
-			Context:    "job",
+			Run:           rc.Run,
+			WorkingDir:    rc.Config.Workdir,
+			Context:       "job",
+			ExternalNeeds: rc.externalNeeds,
 		}),
 	}
 }
 
-//go:embed hashfiles/index.js
-var hashfiles string
-
 // NewStepExpressionEvaluator creates a new evaluator
 func (rc *RunContext) NewStepExpressionEvaluator(ctx context.Context, step step) ExpressionEvaluator {
 	return rc.NewStepExpressionEvaluatorExt(ctx, step, false)
@@ -129,16 +115,7 @@ func (rc *RunContext) newStepExpressionEvaluator(ctx context.Context, step step,
 		strategy["max-parallel"] = job.Strategy.MaxParallel
 	}
 
-	jobs := rc.Run.Workflow.Jobs
-	jobNeeds := rc.Run.Job().Needs()
-
-	using := make(map[string]exprparser.Needs)
-	for _, needs := range jobNeeds {
-		using[needs] = exprparser.Needs{
-			Outputs: jobs[needs].Outputs,
-			Result:  jobs[needs].Result,
-		}
-	}
+	using := getEvaluatorNeeds(rc)
 
 	ee := &exprparser.EvaluationEnvironment{
 		Github:   step.getGithubContext(ctx),
@@ -169,65 +146,65 @@ func (rc *RunContext) newStepExpressionEvaluator(ctx context.Context, step step,
 
 func getHashFilesFunction(ctx context.Context, rc *RunContext) func(v []reflect.Value) (interface{}, error) {
 	hashFiles := func(v []reflect.Value) (interface{}, error) {
-		if rc.JobContainer != nil {
-			timeed, cancel := context.WithTimeout(ctx, time.Minute)
-			defer cancel()
-			name := "workflow/hashfiles/index.js"
-			hout := &bytes.Buffer{}
-			herr := &bytes.Buffer{}
-			patterns := []string{}
-			followSymlink := false
-
-			for i, p := range v {
-				s := p.String()
-				if i == 0 {
-					if strings.HasPrefix(s, "--") {
-						if strings.EqualFold(s, "--follow-symbolic-links") {
-							followSymlink = true
-							continue
-						}
-						return "", fmt.Errorf("Invalid glob option %s, available option: '--follow-symbolic-links'", s)
+		if rc.JobContainer == nil {
+			return "", nil
+		}
+		patterns := []string{}
+		followSymlink := false
+
+		for i, p := range v {
+			s := p.String()
+			if i == 0 {
+				if strings.HasPrefix(s, "--") {
+					if strings.EqualFold(s, "--follow-symbolic-links") {
+						followSymlink = true
+						continue
 					}
+					return "", fmt.Errorf("Invalid glob option %s, available option: '--follow-symbolic-links'", s)
 				}
-				patterns = append(patterns, s)
-			}
-			env := map[string]string{}
-			for k, v := range rc.Env {
-				env[k] = v
-			}
-			env["patterns"] = strings.Join(patterns, "\n")
-			if followSymlink {
-				env["followSymbolicLinks"] = "true"
 			}
+			patterns = append(patterns, s)
+		}
 
-			stdout, stderr := rc.JobContainer.ReplaceLogWriter(hout, herr)
-			_ = rc.JobContainer.Copy(rc.JobContainer.GetActPath(), &container.FileEntry{
-				Name: name,
-				Mode: 0o644,
-				Body: hashfiles,
-			}).
-				Then(rc.execJobContainer([]string{rc.GetNodeToolFullPath(ctx), path.Join(rc.JobContainer.GetActPath(), name)},
-					env, "", "")).
-				Finally(func(context.Context) error {
-					rc.JobContainer.ReplaceLogWriter(stdout, stderr)
-					return nil
-				})(timeed)
-			output := hout.String() + "\n" + herr.String()
-			guard := "__OUTPUT__"
-			outstart := strings.Index(output, guard)
-			if outstart != -1 {
-				outstart += len(guard)
-				outend := strings.Index(output[outstart:], guard)
-				if outend != -1 {
-					return output[outstart : outstart+outend], nil
-				}
-			}
+		fsys, err := rc.hashFilesFS(ctx)
+		if err != nil {
+			return "", err
 		}
-		return "", nil
+		return hashFilesOf(fsys, rc.JobContainer.ToContainerPath(rc.Config.Workdir), patterns, followSymlink)
 	}
 	return hashFiles
 }
 
+// hashFilesFS returns the workspace of the job, bind mounted and host workspaces
+// are read directly, all other workspaces are read from an archive of the job container,
+// which is kept until the workspace may change with the next step
+func (rc *RunContext) hashFilesFS(ctx context.Context) (hashFilesFS, error) {
+	workspace := rc.JobContainer.ToContainerPath(rc.Config.Workdir)
+	if rc.IsHostEnv(ctx) {
+		return &hostHashFilesFS{Root: workspace}, nil
+	}
+	if rc.Config.BindWorkdir {
+		return &hostHashFilesFS{Root: rc.Config.Workdir}, nil
+	}
+
+	if rc.hashFilesArchive != nil {
+		return rc.hashFilesArchive, nil
+	}
+	timeed, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	archive, err := rc.JobContainer.GetContainerArchive(timeed, workspace)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the workspace for hashFiles: %w", err)
+	}
+	defer archive.Close()
+	fsys, err := newArchiveHashFilesFS(workspace, archive)
+	if err != nil {
+		return nil, err
+	}
+	rc.hashFilesArchive = fsys
+	return fsys, nil
+}
+
 type expressionEvaluator struct {
 	interpreter exprparser.Interpreter
 }
@@ -479,6 +456,24 @@ func rewriteSubExpression(ctx context.Context, in string, forceFormat bool) (str
 	return out, nil
 }
 
+// getEvaluatorNeeds returns the needs context of the job, the external needs of
+// the run context take precedence over the jobs of the workflow
+func getEvaluatorNeeds(rc *RunContext) map[string]exprparser.Needs {
+	jobs := rc.Run.Workflow.Jobs
+	using := make(map[string]exprparser.Needs)
+	for _, needs := range rc.Run.Job().Needs() {
+		if external, ok := rc.externalNeeds[needs]; ok {
+			using[needs] = external
+			continue
+		}
+		using[needs] = exprparser.Needs{
+			Outputs: jobs[needs].Outputs,
+			Result:  jobs[needs].Result,
+		}
+	}
+	return using
+}
+
 func getEvaluatorInputs(ctx context.Context, rc *RunContext, step step, ghc *model.GithubContext) map[string]interface{} {
 	inputs := map[string]interface{}{}
 
@@ -505,11 +500,15 @@ func getEvaluatorInputs(ctx context.Context, rc *RunContext, step step, ghc *mod
 				if value == nil {
 					value = v.Default
 				}
-				if v.Type == "boolean" {
-					inputs[k] = value == "true"
-				} else {
-					inputs[k] = value
+				if s, ok := value.(string); ok {
+					typed, err := v.Convert(s)
+					if err != nil {
+						common.Logger(ctx).Warnf("invalid value for input %s: %v", k, err)
+						typed = s
+					}
+					value = typed
 				}
+				inputs[k] = value
 			}
 		}
 	}
@@ -524,11 +523,15 @@ func getEvaluatorInputs(ctx context.Context, rc *RunContext, step step, ghc *mod
 						common.Logger(ctx).Debugf("error decoding default value for %s: %v", k, err)
 					}
 				}
-				if v.Type == "boolean" {
-					inputs[k] = value == "true"
-				} else {
-					inputs[k] = value
+				if value != nil {
+					typed, err := v.Convert(value)
+					if err != nil {
+						common.Logger(ctx).Warnf("invalid value for input %s: %v", k, err)
+					} else {
+						value = typed
+					}
 				}
+				inputs[k] = value
 			}
 		}
 	}
@@ -540,17 +543,7 @@ func setupWorkflowInputs(ctx context.Context, inputs *map[string]interface{}, rc
 		config := rc.Run.Workflow.WorkflowCallConfig()
 
 		for name, input := range config.Inputs {
-			value := rc.caller.runContext.Run.Job().With[name]
-
-			if value != nil {
-				node := yaml.Node{}
-				_ = node.Encode(value)
-				if rc.caller.runContext.ExprEval != nil {
-					// evaluate using the calling RunContext (outside)
-					_ = rc.caller.runContext.ExprEval.EvaluateYamlNode(ctx, &node)
-				}
-				_ = node.Decode(&value)
-			}
+			value := getCallerInput(ctx, rc.caller.runContext, name)
 
 			if value == nil && config != nil && config.Inputs != nil {
 				def := input.Default
@@ -561,11 +554,37 @@ func setupWorkflowInputs(ctx context.Context, inputs *map[string]interface{}, rc
 				_ = def.Decode(&value)
 			}
 
+			if value != nil {
+				typed, err := input.Convert(value)
+				if err != nil {
+					common.Logger(ctx).Debugf("unable to convert input %s: %v", name, err)
+				} else {
+					value = typed
+				}
+			}
+
 			(*inputs)[name] = value
 		}
 	}
 }
 
+// getCallerInput evaluates the with value of the calling job for the given input
+func getCallerInput(ctx context.Context, caller *RunContext, name string) interface{} {
+	value := caller.Run.Job().With[name]
+
+	if value != nil {
+		node := yaml.Node{}
+		_ = node.Encode(value)
+		if caller.ExprEval != nil {
+			// evaluate using the calling RunContext (outside)
+			_ = caller.ExprEval.EvaluateYamlNode(ctx, &node)
+		}
+		_ = node.Decode(&value)
+	}
+
+	return value
+}
+
 func getWorkflowSecrets(ctx context.Context, rc *RunContext) map[string]string {
 	if rc.caller != nil {
 		job := rc.caller.runContext.Run.Job()
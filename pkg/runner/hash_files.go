@@ -0,0 +1,474 @@
+package runner
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// hashFilesFS is the job filesystem below the workspace, names are slash
+// separated and relative to the workspace, the workspace itself is ""
+type hashFilesFS interface {
+	// Lstat returns the type of the file without following a final symbolic link
+	Lstat(name string) (fs.FileMode, error)
+	// Stat returns the type of the file after following all symbolic links
+	Stat(name string) (fs.FileMode, error)
+	// ReadDir returns the sorted names of the entries of the directory
+	ReadDir(name string) ([]string, error)
+	// RealPath returns the name after resolving all symbolic links
+	RealPath(name string) (string, error)
+	// Digest returns the sha256 digest of the content of the file
+	Digest(name string) ([]byte, error)
+}
+
+// hashFilesPattern is a single line of the patterns of hashFiles, matching
+// follows @actions/glob
+type hashFilesPattern struct {
+	negate   bool
+	segments []string
+	// trailingSeparator patterns only match directories
+	trailingSeparator bool
+}
+
+// parseHashFilesPatterns returns the patterns relative to the workspace together
+// with their implicit descendants, patterns outside of the workspace never match
+func parseHashFilesPatterns(patterns []string, workspace string) []*hashFilesPattern {
+	workspace = path.Clean(filepath.ToSlash(workspace))
+
+	parsed := []*hashFilesPattern{}
+	for _, p := range patterns {
+		for _, line := range strings.Split(p, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			pattern := &hashFilesPattern{}
+			for strings.HasPrefix(line, "!") {
+				pattern.negate = !pattern.negate
+				line = line[1:]
+			}
+			line = filepath.ToSlash(line)
+			pattern.trailingSeparator = strings.HasSuffix(line, "/")
+
+			if !path.IsAbs(line) {
+				line = path.Join(workspace, line)
+			}
+			line = path.Clean(line)
+			var rel string
+			switch {
+			case line == workspace:
+			case strings.HasPrefix(line, strings.TrimSuffix(workspace, "/")+"/"):
+				rel = strings.TrimPrefix(line, strings.TrimSuffix(workspace, "/")+"/")
+			default:
+				continue
+			}
+			if rel != "" {
+				for _, segment := range strings.Split(rel, "/") {
+					// minimatch negates character classes with ! as well as ^
+					pattern.segments = append(pattern.segments, strings.ReplaceAll(segment, "[!", "[^"))
+				}
+			}
+			parsed = append(parsed, pattern)
+
+			if pattern.trailingSeparator || len(pattern.segments) == 0 || pattern.segments[len(pattern.segments)-1] != "**" {
+				parsed = append(parsed, &hashFilesPattern{
+					negate:   pattern.negate,
+					segments: append(append([]string{}, pattern.segments...), "**"),
+				})
+			}
+		}
+	}
+	return parsed
+}
+
+// match reports whether the segments of a file name match the pattern
+func (p *hashFilesPattern) match(segments []string, isDir bool) bool {
+	if p.trailingSeparator && !isDir {
+		return false
+	}
+	return matchHashFilesSegments(p.segments, segments)
+}
+
+func matchHashFilesSegments(pattern []string, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchHashFilesSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	if ok, err := path.Match(pattern[0], segments[0]); err != nil || !ok {
+		return false
+	}
+	return matchHashFilesSegments(pattern[1:], segments[1:])
+}
+
+// searchRoot returns the leading segments of the pattern without glob characters
+func (p *hashFilesPattern) searchRoot() string {
+	root := []string{}
+	for _, segment := range p.segments {
+		if strings.ContainsAny(segment, "*?[\\") {
+			break
+		}
+		root = append(root, segment)
+	}
+	return strings.Join(root, "/")
+}
+
+func matchHashFilesPatterns(patterns []*hashFilesPattern, name string, isDir bool) bool {
+	segments := []string{}
+	if name != "" {
+		segments = strings.Split(name, "/")
+	}
+	matched := false
+	for _, pattern := range patterns {
+		if pattern.negate {
+			if matched && pattern.match(segments, isDir) {
+				matched = false
+			}
+		} else if !matched && pattern.match(segments, isDir) {
+			matched = true
+		}
+	}
+	return matched
+}
+
+// hashFilesOf returns the sha256 over the sha256 digests of all files of the
+// workspace matching the patterns or an empty string if there is no match
+func hashFilesOf(fsys hashFilesFS, workspace string, patterns []string, followSymlinks bool) (string, error) {
+	parsed := parseHashFilesPatterns(patterns, workspace)
+
+	roots := []string{}
+	for _, pattern := range parsed {
+		if pattern.negate {
+			continue
+		}
+		roots = append(roots, pattern.searchRoot())
+	}
+	sort.Strings(roots)
+	searchRoots := []string{}
+	for _, root := range roots {
+		covered := false
+		for _, searchRoot := range searchRoots {
+			if searchRoot == "" || root == searchRoot || strings.HasPrefix(root, searchRoot+"/") {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			searchRoots = append(searchRoots, root)
+		}
+	}
+
+	result := sha256.New()
+	count := 0
+	var walk func(name string, chain []string) error
+	walk = func(name string, chain []string) error {
+		mode, err := fsys.Lstat(name)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if mode&fs.ModeSymlink != 0 {
+			if mode, err = fsys.Stat(name); err != nil {
+				// broken symbolic links are omitted
+				if errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+				return err
+			}
+			if mode.IsDir() && !followSymlinks {
+				return nil
+			}
+		}
+
+		if !mode.IsDir() {
+			if !matchHashFilesPatterns(parsed, name, false) {
+				return nil
+			}
+			digest, err := fsys.Digest(name)
+			if err != nil {
+				return err
+			}
+			result.Write(digest)
+			count++
+			return nil
+		}
+
+		realPath, err := fsys.RealPath(name)
+		if err != nil {
+			return err
+		}
+		for _, p := range chain {
+			if p == realPath {
+				// symbolic link cycle
+				return nil
+			}
+		}
+		entries, err := fsys.ReadDir(name)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if err := walk(path.Join(name, entry), append(chain, realPath)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, root := range searchRoots {
+		if err := walk(root, nil); err != nil {
+			return "", err
+		}
+	}
+
+	if count == 0 {
+		return "", nil
+	}
+	return hex.EncodeToString(result.Sum(nil)), nil
+}
+
+// hostHashFilesFS is the workspace directory on the host
+type hostHashFilesFS struct {
+	Root string
+}
+
+func (h *hostHashFilesFS) path(name string) string {
+	return filepath.Join(h.Root, filepath.FromSlash(name))
+}
+
+func (h *hostHashFilesFS) Lstat(name string) (fs.FileMode, error) {
+	fi, err := os.Lstat(h.path(name))
+	if err != nil {
+		return 0, err
+	}
+	return fi.Mode(), nil
+}
+
+func (h *hostHashFilesFS) Stat(name string) (fs.FileMode, error) {
+	fi, err := os.Stat(h.path(name))
+	if err != nil {
+		return 0, err
+	}
+	return fi.Mode(), nil
+}
+
+func (h *hostHashFilesFS) ReadDir(name string) ([]string, error) {
+	entries, err := os.ReadDir(h.path(name))
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names, nil
+}
+
+func (h *hostHashFilesFS) RealPath(name string) (string, error) {
+	return filepath.EvalSymlinks(h.path(name))
+}
+
+func (h *hostHashFilesFS) Digest(name string) ([]byte, error) {
+	f, err := os.Open(h.path(name))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return nil, err
+	}
+	return hash.Sum(nil), nil
+}
+
+type archiveHashFilesEntry struct {
+	mode   fs.FileMode
+	target string
+	digest []byte
+}
+
+// archiveHashFilesFS is the workspace read from a tar archive of the job
+// container, the digests of all regular files are computed while reading
+type archiveHashFilesFS struct {
+	workspace string
+	entries   map[string]*archiveHashFilesEntry
+	children  map[string][]string
+}
+
+// newArchiveHashFilesFS reads the archive of the workspace, the entries of the
+// archive may be prefixed with the base name of the workspace
+func newArchiveHashFilesFS(workspace string, archive io.Reader) (*archiveHashFilesFS, error) {
+	a := &archiveHashFilesFS{
+		workspace: path.Clean(filepath.ToSlash(workspace)),
+		entries:   map[string]*archiveHashFilesEntry{"": {mode: fs.ModeDir}},
+		children:  map[string][]string{},
+	}
+
+	prefix := ""
+	reader := tar.NewReader(archive)
+	for first := true; ; first = false {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		name := strings.Trim(path.Clean(header.Name), "/")
+		if first && header.Typeflag == tar.TypeDir && name == path.Base(a.workspace) {
+			prefix = name + "/"
+			continue
+		}
+		if prefix != "" {
+			if !strings.HasPrefix(name, prefix) {
+				continue
+			}
+			name = strings.TrimPrefix(name, prefix)
+		}
+		if name == "." {
+			continue
+		}
+
+		entry := &archiveHashFilesEntry{}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			entry.mode = fs.ModeDir
+		case tar.TypeSymlink:
+			entry.mode = fs.ModeSymlink
+			entry.target = header.Linkname
+		case tar.TypeReg:
+			hash := sha256.New()
+			if _, err := io.Copy(hash, reader); err != nil {
+				return nil, err
+			}
+			entry.digest = hash.Sum(nil)
+		default:
+			continue
+		}
+		a.add(name, entry)
+	}
+
+	for _, children := range a.children {
+		sort.Strings(children)
+	}
+	return a, nil
+}
+
+func (a *archiveHashFilesFS) add(name string, entry *archiveHashFilesEntry) {
+	if _, ok := a.entries[name]; !ok {
+		dir := path.Dir(name)
+		if dir == "." {
+			dir = ""
+		}
+		if _, ok := a.entries[dir]; !ok {
+			a.add(dir, &archiveHashFilesEntry{mode: fs.ModeDir})
+		}
+		a.children[dir] = append(a.children[dir], path.Base(name))
+	}
+	a.entries[name] = entry
+}
+
+// resolve returns the name of the entry after resolving the symbolic links of
+// all parent directories and optionally of the entry itself
+func (a *archiveHashFilesFS) resolve(name string, followLast bool) (string, *archiveHashFilesEntry, error) {
+	segments := []string{}
+	if name != "" {
+		segments = strings.Split(name, "/")
+	}
+	resolved := ""
+	for hops := 0; len(segments) > 0; {
+		current := path.Join(resolved, segments[0])
+		segments = segments[1:]
+		if current == ".." || strings.HasPrefix(current, "../") {
+			return "", nil, fs.ErrNotExist
+		}
+		entry, ok := a.entries[current]
+		if !ok {
+			return "", nil, fs.ErrNotExist
+		}
+		if entry.mode&fs.ModeSymlink == 0 || (len(segments) == 0 && !followLast) {
+			resolved = current
+			continue
+		}
+
+		hops++
+		if hops > 40 {
+			return "", nil, fmt.Errorf("too many levels of symbolic links in '%s'", name)
+		}
+		target := entry.target
+		if path.IsAbs(target) {
+			target = path.Clean(target)
+			if target != a.workspace && !strings.HasPrefix(target, strings.TrimSuffix(a.workspace, "/")+"/") {
+				// the target is outside of the archived workspace
+				return "", nil, fs.ErrNotExist
+			}
+			resolved = ""
+			target = strings.TrimPrefix(strings.TrimPrefix(target, a.workspace), "/")
+		}
+		if target != "" {
+			segments = append(strings.Split(target, "/"), segments...)
+		}
+	}
+	return resolved, a.entries[resolved], nil
+}
+
+func (a *archiveHashFilesFS) Lstat(name string) (fs.FileMode, error) {
+	_, entry, err := a.resolve(name, false)
+	if err != nil {
+		return 0, err
+	}
+	return entry.mode, nil
+}
+
+func (a *archiveHashFilesFS) Stat(name string) (fs.FileMode, error) {
+	_, entry, err := a.resolve(name, true)
+	if err != nil {
+		return 0, err
+	}
+	return entry.mode, nil
+}
+
+func (a *archiveHashFilesFS) ReadDir(name string) ([]string, error) {
+	resolved, _, err := a.resolve(name, true)
+	if err != nil {
+		return nil, err
+	}
+	return a.children[resolved], nil
+}
+
+func (a *archiveHashFilesFS) RealPath(name string) (string, error) {
+	resolved, _, err := a.resolve(name, true)
+	return resolved, err
+}
+
+func (a *archiveHashFilesFS) Digest(name string) ([]byte, error) {
+	_, entry, err := a.resolve(name, true)
+	if err != nil {
+		return nil, err
+	}
+	if entry.digest == nil {
+		return nil, fmt.Errorf("'%s' is not a regular file", name)
+	}
+	return entry.digest, nil
+}
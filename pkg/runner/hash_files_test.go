@@ -0,0 +1,124 @@
+package runner
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nektos/act/pkg/model"
+)
+
+var hashFilesTestFiles = map[string]string{
+	"package.json":          "{}",
+	"package-lock.json":     "lock",
+	"src/main.go":           "package main",
+	"src/util/util.go":      "package util",
+	"src/util/util_test.go": "package util_test",
+	".github/ci.yml":        "on: push",
+	"vendor/dep/dep.go":     "package dep",
+}
+
+func expectedHashFiles(names ...string) string {
+	result := sha256.New()
+	for _, name := range names {
+		digest := sha256.Sum256([]byte(hashFilesTestFiles[name]))
+		result.Write(digest[:])
+	}
+	return hex.EncodeToString(result.Sum(nil))
+}
+
+func hashFilesTestArchive(t *testing.T) *bytes.Buffer {
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	assert.NoError(t, tw.WriteHeader(&tar.Header{Name: "workspace/", Typeflag: tar.TypeDir, Mode: 0o755}))
+	for name, content := range hashFilesTestFiles {
+		assert.NoError(t, tw.WriteHeader(&tar.Header{Name: "workspace/" + name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(content))}))
+		_, err := tw.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.WriteHeader(&tar.Header{Name: "workspace/link.json", Typeflag: tar.TypeSymlink, Linkname: "package.json"}))
+	assert.NoError(t, tw.WriteHeader(&tar.Header{Name: "workspace/lib", Typeflag: tar.TypeSymlink, Linkname: "/github/workspace/src/util"}))
+	assert.NoError(t, tw.WriteHeader(&tar.Header{Name: "workspace/loop", Typeflag: tar.TypeSymlink, Linkname: "."}))
+	assert.NoError(t, tw.Close())
+	return buf
+}
+
+func TestHashFiles(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range hashFilesTestFiles {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	assert.NoError(t, os.Symlink("package.json", filepath.Join(dir, "link.json")))
+	assert.NoError(t, os.Symlink(filepath.Join(dir, "src", "util"), filepath.Join(dir, "lib")))
+	assert.NoError(t, os.Symlink(".", filepath.Join(dir, "loop")))
+
+	archive, err := newArchiveHashFilesFS("/github/workspace", hashFilesTestArchive(t))
+	assert.NoError(t, err)
+
+	tables := []struct {
+		name           string
+		patterns       []string
+		followSymlinks bool
+		expected       string
+	}{
+		{"no-match", []string{"**/*.rs"}, false, ""},
+		{"single-file", []string{"package.json"}, false, expectedHashFiles("package.json")},
+		{"relative-file", []string{"./src/main.go"}, false, expectedHashFiles("src/main.go")},
+		{"absolute-file", []string{"/github/workspace/src/main.go"}, false, expectedHashFiles("src/main.go")},
+		{"outside-workspace", []string{"/etc/passwd"}, false, ""},
+		{"globstar", []string{"**/*.go"}, false, expectedHashFiles("src/main.go", "src/util/util.go", "src/util/util_test.go", "vendor/dep/dep.go")},
+		{"negation", []string{"**/*.go", "!vendor/**", "!**/*_test.go"}, false, expectedHashFiles("src/main.go", "src/util/util.go")},
+		{"negation-order", []string{"!vendor/**", "**/*.go"}, false, expectedHashFiles("src/main.go", "src/util/util.go", "src/util/util_test.go", "vendor/dep/dep.go")},
+		{"multiline", []string{"# comment\nsrc/main.go\n\n  package.json  "}, false, expectedHashFiles("package.json", "src/main.go")},
+		{"implicit-descendants", []string{"src"}, false, expectedHashFiles("src/main.go", "src/util/util.go", "src/util/util_test.go")},
+		{"trailing-separator", []string{"src/util/"}, false, expectedHashFiles("src/util/util.go", "src/util/util_test.go")},
+		{"character-class", []string{"src/util/util[!_]*"}, false, expectedHashFiles("src/util/util.go")},
+		{"dot-files", []string{"**/*.yml"}, false, expectedHashFiles(".github/ci.yml")},
+		{"file-symlink", []string{"*.json"}, false, expectedHashFiles("package.json", "package-lock.json", "package.json")},
+		{"directory-symlink", []string{"lib/*.go"}, false, ""},
+		{"follow-directory-symlink", []string{"lib/*.go"}, true, expectedHashFiles("src/util/util.go", "src/util/util_test.go")},
+		{"follow-symlink-cycle", []string{"**/main.go"}, true, expectedHashFiles("src/main.go")},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			host, err := hashFilesOf(&hostHashFilesFS{Root: dir}, "/github/workspace", table.patterns, table.followSymlinks)
+			assert.NoError(t, err)
+			assert.Equal(t, table.expected, host, "host")
+
+			fromArchive, err := hashFilesOf(archive, "/github/workspace", table.patterns, table.followSymlinks)
+			assert.NoError(t, err)
+			assert.Equal(t, table.expected, fromArchive, "archive")
+		})
+	}
+}
+
+func TestHashFilesFSOfStep(t *testing.T) {
+	ctx := context.Background()
+	cm := &containerMock{}
+	cm.On("GetContainerArchive", mock.Anything, "/github/workspace").Return(io.NopCloser(hashFilesTestArchive(t)), nil).Once()
+	rc := &RunContext{
+		Config: &Config{Workdir: "/github/workspace"},
+		Run: &model.Run{JobID: "test", Workflow: &model.Workflow{
+			Jobs: map[string]*model.Job{"test": {}},
+		}},
+		JobContainer: cm,
+	}
+
+	first, err := rc.hashFilesFS(ctx)
+	assert.NoError(t, err)
+	second, err := rc.hashFilesFS(ctx)
+	assert.NoError(t, err)
+	assert.Same(t, first, second, "the workspace is archived once per step")
+	cm.AssertExpectations(t)
+}
@@ -53,6 +53,16 @@ func newJobExecutor(info jobInfo, sf stepFactory, rc *RunContext) common.Executo
 		return nil
 	})
 
+	// steps before the step to resume from are restored from the checkpoint instead of running them
+	firstStep := 0
+	if rc.Config != nil && rc.Config.ResumeFrom != "" {
+		var err error
+		if firstStep, err = rc.setupResume(infoSteps); err != nil {
+			return common.NewErrorExecutor(err)
+		}
+		preSteps = append(preSteps, rc.restoreCheckpointState)
+	}
+
 	var setJobError = func(ctx context.Context, err error) error {
 		if err == nil {
 			return nil
@@ -72,6 +82,9 @@ func newJobExecutor(info jobInfo, sf stepFactory, rc *RunContext) common.Executo
 		if stepModel.ID == "" {
 			stepModel.ID = fmt.Sprintf("%d", i)
 		}
+		if i < firstStep {
+			continue
+		}
 
 		step, err := sf.newStep(stepModel, rc)
 
@@ -88,6 +101,10 @@ func newJobExecutor(info jobInfo, sf stepFactory, rc *RunContext) common.Executo
 				_ = setJobError(ctx, err)
 			} else if ctx.Err() != nil {
 				_ = setJobError(ctx, ctx.Err())
+			} else if rc.Config.Checkpoint && common.JobError(ctx) == nil {
+				if err := rc.checkpoint(ctx, i, stepModel); err != nil {
+					common.Logger(ctx).Warnf("Unable to checkpoint step '%s': %v", stepModel.ID, err)
+				}
 			}
 			return nil
 		}))
@@ -147,6 +164,7 @@ func newJobExecutor(info jobInfo, sf stepFactory, rc *RunContext) common.Executo
 			}).
 			Finally(common.NewFieldExecutor("step", "Complete job", common.NewFieldExecutor("stepid", []string{"--complete-job"},
 				common.NewInfoExecutor("\u2B50 Run Complete job").
+					Finally(rc.exportJobFiles()).
 					Finally(stopContainerExecutor).
 					Finally(
 						info.interpolateOutputs().Finally(info.closeContainer()).Then(common.NewFieldExecutor("stepResult", model.StepStatusSuccess, common.NewInfoExecutor("  \u2705  Success - Complete job"))).
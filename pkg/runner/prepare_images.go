@@ -0,0 +1,247 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/nektos/act/pkg/common"
+	"github.com/nektos/act/pkg/container"
+	"github.com/nektos/act/pkg/model"
+)
+
+// planImage is an image used by a job of the plan, it is built from
+// BuildContext if set and pulled otherwise
+type planImage struct {
+	Image        string
+	Username     string
+	Password     string
+	Repository   string
+	ContextDir   string
+	Dockerfile   string
+	BuildContext *container.BuildContext
+}
+
+// collectPlanImages returns the images of the job containers, services and docker
+// actions of the plan in the order of the plan, every image once. Remote actions are
+// fetched to read their docker images. Images depending on the outputs of other jobs
+// are only known while the jobs run and are prepared by the jobs themselves.
+func (runner *runnerImpl) collectPlanImages(ctx context.Context, plan *model.Plan) []planImage {
+	logger := common.Logger(ctx)
+	images := []planImage{}
+	seen := map[string]bool{}
+	add := func(image planImage) {
+		if image.Image == "" || seen[image.Image] {
+			return
+		}
+		seen[image.Image] = true
+		images = append(images, image)
+	}
+
+	for _, stage := range plan.Stages {
+		for _, run := range stage.Runs {
+			if jobType, err := run.Job().Type(); err != nil || jobType != model.JobTypeDefault {
+				continue
+			}
+			// evaluating the matrix replaces it, the outputs of the needed jobs are not known yet
+			if runner.needsPlannedJobs(run) {
+				continue
+			}
+			for _, matrix := range runner.jobMatrixes(ctx, run) {
+				rc := runner.newRunContext(ctx, run, matrix)
+
+				// the images of other backends are not pulled by docker, the host backend
+				// runs the services and step containers of its jobs with docker
+				backend, _ := rc.jobBackend(ctx)
+				if backend == container.BackendDocker {
+					username, password, err := rc.handleCredentials(ctx)
+					if err != nil {
+						logger.Debugf("Skipping the image of job '%s': %v", rc.String(), err)
+					} else {
+						add(planImage{Image: rc.platformImage(ctx), Username: username, Password: password})
+					}
+				}
+
+				if backend != container.BackendDocker && backend != container.BackendHost {
+					continue
+				}
+
+				for serviceID, spec := range rc.Run.Job().Services {
+					username, password, err := rc.handleServiceCredentials(ctx, spec.Credentials)
+					if err != nil {
+						logger.Debugf("Skipping the image of service '%s': %v", serviceID, err)
+						continue
+					}
+					add(planImage{Image: rc.ExprEval.Interpolate(ctx, spec.Image), Username: username, Password: password})
+				}
+
+				for _, step := range rc.Run.Job().Steps {
+					if step == nil {
+						continue
+					}
+					switch step.Type() {
+					case model.StepTypeUsesDockerURL:
+						add(planImage{Image: strings.TrimPrefix(step.Uses, "docker://")})
+					case model.StepTypeUsesActionLocal:
+						add(rc.localDockerActionImage(ctx, step))
+					case model.StepTypeUsesActionRemote:
+						add(rc.remoteDockerActionImage(ctx, step))
+					}
+				}
+			}
+		}
+	}
+	return images
+}
+
+// localDockerActionImage returns the image of a local docker action, if the action
+// is found in the working directory on the host
+func (rc *RunContext) localDockerActionImage(ctx context.Context, step *model.Step) planImage {
+	actionDir := filepath.Join(rc.Config.Workdir, step.Uses)
+	readFile := func(filename string) (io.Reader, io.Closer, error) {
+		f, err := os.Open(filepath.Join(actionDir, filename))
+		if err != nil {
+			return nil, nil, err
+		}
+		return f, f, nil
+	}
+	noWrite := func(string, []byte, os.FileMode) error {
+		return nil
+	}
+	action, err := readActionImpl(ctx, step, actionDir, "", readFile, noWrite)
+	if err != nil {
+		return planImage{}
+	}
+	repository := rc.dockerActionRepository("./" + getOsSafeRelativePath(actionDir, rc.Config.Workdir))
+	return dockerActionPlanImage(ctx, step, action, repository, actionDir, "", func(contextDir, fileName string) (*container.BuildContext, error) {
+		return container.NewBuildContext(func() (io.ReadCloser, error) {
+			return container.NewBuildContextArchive(ctx, filepath.Join(actionDir, contextDir), fileName)
+		}, fileName)
+	})
+}
+
+// remoteDockerActionImage fetches the remote action of the step the way the step does,
+// from the action cache or by cloning it, and returns the image of the action
+func (rc *RunContext) remoteDockerActionImage(ctx context.Context, step *model.Step) planImage {
+	sar := &stepActionRemote{
+		Step:       step,
+		RunContext: rc,
+		readAction: readActionImpl,
+	}
+	if err := sar.prepareActionExecutor()(ctx); err != nil {
+		common.Logger(ctx).Debugf("Skipping the image of action '%s': %v", step.Uses, err)
+		return planImage{}
+	}
+	// local checkouts are skipped by the step
+	if sar.action == nil {
+		return planImage{}
+	}
+	actionDir := fmt.Sprintf("%s/%s", rc.ActionCacheDir(), safeFilename(step.Uses))
+	repository := rc.dockerActionRepository(getOsSafeRelativePath(path.Join(actionDir, sar.remoteAction.Path), rc.ActionCacheDir()))
+	return dockerActionPlanImage(ctx, step, sar.action, repository, actionDir, sar.remoteAction.Path, func(contextDir, fileName string) (*container.BuildContext, error) {
+		return dockerActionBuildContext(ctx, sar, false, filepath.Join(actionDir, contextDir), contextDir, fileName)
+	})
+}
+
+// dockerActionPlanImage returns the image of a docker action, Dockerfile images are
+// tagged with the hash of the build context read by readBuildContext. The build args of
+// x-act-build depend on the inputs of the step, so these images are built by the jobs.
+func dockerActionPlanImage(ctx context.Context, step *model.Step, action *model.Action, repository, basedir, subpath string,
+	readBuildContext func(contextDir, fileName string) (*container.BuildContext, error)) planImage {
+	if action.Runs.Using != model.ActionRunsUsingDocker || action.Runs.ActBuild != nil {
+		return planImage{}
+	}
+	if strings.HasPrefix(action.Runs.Image, "docker://") {
+		return planImage{Image: strings.TrimPrefix(action.Runs.Image, "docker://")}
+	}
+
+	contextDir, fileName := path.Split(path.Join(subpath, action.Runs.Image))
+	buildContext, err := readBuildContext(contextDir, fileName)
+	if err != nil {
+		common.Logger(ctx).Debugf("Skipping the image of action '%s': %v", step.Uses, err)
+		return planImage{}
+	}
+	return planImage{
+		Image:        dockerActionImage(repository, buildContext.Hash),
+		Repository:   repository,
+		ContextDir:   filepath.Join(basedir, contextDir),
+		Dockerfile:   fileName,
+		BuildContext: buildContext,
+	}
+}
+
+// prepareImages pulls and builds the images of the plan with bounded parallelism
+// before the first job starts. Failures are only logged, the jobs retry to pull
+// or build their images.
+func (runner *runnerImpl) prepareImages(plan *model.Plan) common.Executor {
+	return func(ctx context.Context) error {
+		logger := common.Logger(ctx)
+		images := runner.collectPlanImages(ctx, plan)
+		if len(images) == 0 {
+			return nil
+		}
+		logger.Infof("\U0001F4E6  Preparing %d images", len(images))
+
+		var mu sync.Mutex
+		done := 0
+		executors := make([]common.Executor, 0, len(images))
+		for _, image := range images {
+			executors = append(executors, func(ctx context.Context) error {
+				err := runner.prepareImage(ctx, image)
+
+				mu.Lock()
+				defer mu.Unlock()
+				done++
+				if err != nil {
+					logger.Warnf("  ❌  [%d/%d] %s: %v", done, len(images), image.Image, err)
+					return nil
+				}
+				runner.preparedImages[image.Image] = true
+				if image.Repository != "" {
+					runner.dockerActionImages.add(image.Repository, image.Image)
+				}
+				logger.Infof("  ✅  [%d/%d] %s", done, len(images), image.Image)
+				return nil
+			})
+		}
+		return common.NewParallelExecutor(runner.config.GetConcurrentJobs(), executors...)(ctx)
+	}
+}
+
+func (runner *runnerImpl) prepareImage(ctx context.Context, image planImage) error {
+	if image.BuildContext == nil {
+		return container.NewDockerPullExecutor(container.NewDockerPullExecutorInput{
+			Image:     image.Image,
+			ForcePull: runner.config.ForcePull,
+			Platform:  runner.config.ContainerArchitecture,
+			Username:  image.Username,
+			Password:  image.Password,
+		})(ctx)
+	}
+
+	exists, err := container.ImageExistsLocally(ctx, image.Image, runner.config.ContainerArchitecture)
+	if err != nil {
+		return err
+	}
+	if exists && !runner.config.ForceRebuild {
+		return nil
+	}
+	return container.NewDockerBuildExecutor(container.NewDockerBuildExecutorInput{
+		ContextDir:   image.ContextDir,
+		Dockerfile:   image.Dockerfile,
+		ImageTag:     image.Image,
+		BuildContext: image.BuildContext,
+		Platform:     runner.config.ContainerArchitecture,
+	})(ctx)
+}
+
+// forcePull returns whether an image has to be pulled even if it exists,
+// images prepared for the plan are up to date already
+func (rc *RunContext) forcePull(image string) bool {
+	return rc.Config.ForcePull && !rc.preparedImages[image]
+}
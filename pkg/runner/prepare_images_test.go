@@ -0,0 +1,227 @@
+package runner
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nektos/act/pkg/model"
+)
+
+func TestCollectPlanImages(t *testing.T) {
+	workdir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(workdir, "build"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(workdir, "build", "action.yml"), []byte("name: build\nruns:\n  using: docker\n  image: Dockerfile\n"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(workdir, "build", "Dockerfile"), []byte("FROM alpine:3\n"), 0o644))
+	assert.NoError(t, os.MkdirAll(filepath.Join(workdir, "lint"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(workdir, "lint", "action.yml"), []byte("name: lint\nruns:\n  using: docker\n  image: docker://golangci/golangci-lint\n"), 0o644))
+
+	planner, err := model.NewSingleWorkflowPlanner("ci.yml", strings.NewReader(`
+on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    strategy:
+      matrix:
+        node: [18, 20]
+    container: node:${{ matrix.node }}
+    services:
+      db:
+        image: postgres:16
+    steps:
+      - uses: docker://alpine:3
+      - uses: ./build
+      - uses: ./lint
+      - uses: ./missing
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: docker://alpine:3
+  host:
+    runs-on: self-hosted
+    steps:
+      - run: echo
+  k8s:
+    runs-on: k8s
+    services:
+      cache:
+        image: redis:7
+    steps:
+      - uses: docker://busybox
+`))
+	assert.NoError(t, err)
+	plan, err := planner.PlanAll()
+	assert.NoError(t, err)
+
+	runner := &runnerImpl{config: &Config{
+		Workdir: workdir,
+		Platforms: map[string]string{
+			"ubuntu-latest": "catthehacker/ubuntu:act-latest",
+			"self-hosted":   "-self-hosted",
+			"k8s":           "backend:k8s:runner:1",
+		},
+	}, eventJSON: "{}"}
+
+	images := map[string]planImage{}
+	for _, image := range runner.collectPlanImages(context.Background(), plan) {
+		_, duplicate := images[image.Image]
+		assert.False(t, duplicate, image.Image)
+		images[image.Image] = image
+	}
+
+	assert.Contains(t, images, "node:18")
+	assert.Contains(t, images, "node:20")
+	assert.Contains(t, images, "postgres:16")
+	assert.Contains(t, images, "alpine:3")
+	assert.Contains(t, images, "golangci/golangci-lint")
+	assert.Contains(t, images, "catthehacker/ubuntu:act-latest")
+	assert.NotContains(t, images, "redis:7", "the services of other backends are not pulled by docker")
+	assert.NotContains(t, images, "busybox", "the step containers of other backends are not pulled by docker")
+	assert.Len(t, images, 7)
+
+	var build planImage
+	for name, image := range images {
+		if strings.HasPrefix(name, "act-build-dockeraction-") {
+			build = image
+		}
+	}
+	assert.NotNil(t, build.BuildContext)
+	assert.Equal(t, dockerActionImage(build.Repository, build.BuildContext.Hash), build.Image)
+	digest := sha256.Sum256([]byte(workdir))
+	assert.Equal(t, "act-build-dockeraction-"+hex.EncodeToString(digest[:4]), build.Repository, "local actions of different repositories have their own images")
+	assert.Equal(t, filepath.Join(workdir, "build"), build.ContextDir)
+	assert.Equal(t, "Dockerfile", build.Dockerfile)
+}
+
+// filesActionCache serves the files of every action from memory, the names of the
+// tar entries are relative to the prefix like the ones of GoGitActionCache
+type filesActionCache map[string]string
+
+func (c filesActionCache) Fetch(_ context.Context, _, _, _, _ string) (string, error) {
+	return "0123456789abcdef", nil
+}
+
+func (c filesActionCache) GetTarArchive(_ context.Context, _, _, includePrefix string) (io.ReadCloser, error) {
+	prefix := path.Clean(includePrefix)
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	for name, content := range c {
+		if prefix != "." && name != prefix && !strings.HasPrefix(name, prefix+"/") {
+			continue
+		}
+		if name == prefix {
+			name = path.Base(name)
+		} else if prefix != "." {
+			name = strings.TrimPrefix(name, prefix+"/")
+		}
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content))}); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return io.NopCloser(buf), nil
+}
+
+func TestCollectPlanImagesRemoteAction(t *testing.T) {
+	planner, err := model.NewSingleWorkflowPlanner("ci.yml", strings.NewReader(`
+on: push
+jobs:
+  test:
+    runs-on: self-hosted
+    steps:
+      - uses: org/build/docker@v1
+      - uses: org/lint@v1
+`))
+	assert.NoError(t, err)
+	plan, err := planner.PlanAll()
+	assert.NoError(t, err)
+
+	runner := &runnerImpl{config: &Config{
+		Workdir:        t.TempDir(),
+		ActionCacheDir: t.TempDir(),
+		Platforms:      map[string]string{"self-hosted": "-self-hosted"},
+		ActionCache: filesActionCache{
+			"docker/action.yml":  "name: build\nruns:\n  using: docker\n  image: Dockerfile\n",
+			"docker/Dockerfile":  "FROM alpine:3\n",
+			"action.yml":         "name: lint\nruns:\n  using: docker\n  image: docker://golangci/golangci-lint\n",
+			"unrelated/file.txt": "",
+		},
+	}, eventJSON: "{}"}
+
+	images := map[string]planImage{}
+	for _, image := range runner.collectPlanImages(context.Background(), plan) {
+		images[image.Image] = image
+	}
+	assert.Contains(t, images, "golangci/golangci-lint")
+	assert.Len(t, images, 2)
+
+	var build planImage
+	for name, image := range images {
+		if strings.HasPrefix(name, "act-org-build-docker-v1-docker-dockeraction:") {
+			build = image
+		}
+	}
+	if assert.NotNil(t, build.BuildContext) {
+		assert.Equal(t, dockerActionImage("act-org-build-docker-v1-docker-dockeraction", build.BuildContext.Hash), build.Image)
+		assert.Equal(t, "Dockerfile", build.Dockerfile)
+	}
+}
+
+func TestJobMatrixesEvaluatedOnce(t *testing.T) {
+	planner, err := model.NewSingleWorkflowPlanner("ci.yml", strings.NewReader(`
+on: push
+jobs:
+  setup:
+    runs-on: self-hosted
+    steps:
+      - run: echo
+  test:
+    runs-on: self-hosted
+    strategy:
+      matrix:
+        node: [18, 20]
+    steps:
+      - run: echo
+  deploy:
+    needs: setup
+    runs-on: self-hosted
+    strategy:
+      matrix:
+        env: [dev]
+    steps:
+      - run: echo
+`))
+	assert.NoError(t, err)
+	plan, err := planner.PlanAll()
+	assert.NoError(t, err)
+
+	runner := &runnerImpl{config: &Config{}, eventJSON: "{}"}
+	runner.setupSkippedNeeds(plan)
+	runner.matrixes = map[*model.Run][]map[string]interface{}{}
+	for _, stage := range plan.Stages {
+		for _, run := range stage.Runs {
+			matrixes := runner.jobMatrixes(context.Background(), run)
+			_, cached := runner.matrixes[run]
+			// the matrix of a job needing planned jobs may use their outputs
+			assert.Equal(t, run.JobID != "deploy", cached, run.JobID)
+			if cached {
+				assert.Equal(t, matrixes, runner.jobMatrixes(context.Background(), run))
+			}
+		}
+	}
+}
@@ -70,6 +70,10 @@ func newActionCacheReusableWorkflowExecutor(rc *RunContext, filename string, rem
 			return err
 		}
 
+		if err := validateWorkflowCall(ctx, rc, plan); err != nil {
+			return err
+		}
+
 		runner, err := NewReusableWorkflowRunner(rc)
 		if err != nil {
 			return err
@@ -125,6 +129,10 @@ func newReusableWorkflowExecutor(rc *RunContext, directory string, workflow stri
 			return err
 		}
 
+		if err := validateWorkflowCall(ctx, rc, plan); err != nil {
+			return err
+		}
+
 		runner, err := NewReusableWorkflowRunner(rc)
 		if err != nil {
 			return err
@@ -134,6 +142,61 @@ func newReusableWorkflowExecutor(rc *RunContext, directory string, workflow stri
 	}
 }
 
+// validateWorkflowCall checks the inputs and secrets of the calling job against
+// the workflow_call declaration of the called workflow
+func validateWorkflowCall(ctx context.Context, rc *RunContext, plan *model.Plan) error {
+	if len(plan.Stages) == 0 || len(plan.Stages[0].Runs) == 0 {
+		return nil
+	}
+	workflow := plan.Stages[0].Runs[0].Workflow
+	config := workflow.WorkflowCallConfig()
+	job := rc.Run.Job()
+
+	inputs := map[string]interface{}{}
+	for name := range job.With {
+		inputs[name] = getCallerInput(ctx, rc, name)
+	}
+
+	secrets := job.Secrets()
+	if job.InheritSecrets() {
+		secrets = rc.Config.Secrets
+	} else {
+		for name := range secrets {
+			if !model.HasSecret(config.Secrets, name) {
+				common.Logger(ctx).Warnf("Secret %s is not defined in the referenced workflow %s", name, workflow.File)
+			}
+		}
+	}
+
+	if err := config.Validate(inputs, secrets); err != nil {
+		return fmt.Errorf("invalid call of reusable workflow '%s' in job '%s': %w", workflow.File, rc.Run.JobID, err)
+	}
+	return nil
+}
+
+// EvaluateWorkflowCallOutputs evaluates the declared outputs of a workflow_call workflow
+// from the outputs of its jobs, once the plan has been executed
+func EvaluateWorkflowCallOutputs(ctx context.Context, config *Config, plan *model.Plan) (map[string]string, error) {
+	outputs := map[string]string{}
+	if len(plan.Stages) == 0 || len(plan.Stages[0].Runs) == 0 {
+		return outputs, nil
+	}
+
+	runner := &runnerImpl{
+		config: config,
+	}
+	if _, err := runner.configure(); err != nil {
+		return nil, err
+	}
+
+	run := plan.Stages[len(plan.Stages)-1].Runs[0]
+	ee := runner.newRunContext(ctx, run, nil).NewExpressionEvaluator(ctx)
+	for k, v := range run.Workflow.WorkflowCallConfig().Outputs {
+		outputs[k] = ee.Interpolate(ctx, v.Value)
+	}
+	return outputs, nil
+}
+
 func NewReusableWorkflowRunner(rc *RunContext) (Runner, error) {
 	runner := &runnerImpl{
 		config:    rc.Config,
@@ -0,0 +1,88 @@
+package runner
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+
+	"github.com/nektos/act/pkg/model"
+)
+
+func TestValidateWorkflowCall(t *testing.T) {
+	reusable, err := model.ReadWorkflow(strings.NewReader(`
+name: reusable
+on:
+  workflow_call:
+    inputs:
+      name:
+        required: true
+        type: string
+      count:
+        type: number
+        default: 1
+      enabled:
+        type: boolean
+    secrets:
+      token:
+        required: true
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+    - run: echo
+`), false)
+	assert.NoError(t, err)
+	reusable.File = "reusable.yml"
+	plan := &model.Plan{Stages: []*model.Stage{{Runs: []*model.Run{{Workflow: reusable, JobID: "test"}}}}}
+
+	newCaller := func(with map[string]interface{}, secrets string) *RunContext {
+		var node yaml.Node
+		assert.NoError(t, yaml.Unmarshal([]byte(secrets), &node))
+		job := &model.Job{With: with, RawSecrets: *node.Content[0]}
+		return &RunContext{
+			Config: &Config{Secrets: map[string]string{"TOKEN": "secret"}},
+			Run: &model.Run{
+				JobID:    "caller",
+				Workflow: &model.Workflow{Jobs: map[string]*model.Job{"caller": job}},
+			},
+		}
+	}
+
+	tables := []struct {
+		name    string
+		with    map[string]interface{}
+		secrets string
+		errors  []string
+	}{
+		{"valid", map[string]interface{}{"name": "act", "count": 2, "enabled": "true"}, "token: abc", nil},
+		{"case insensitive", map[string]interface{}{"name": "act"}, "TOKEN: abc", nil},
+		{"inherit", map[string]interface{}{"name": "act"}, "inherit", nil},
+		{"missing", map[string]interface{}{}, "{}", []string{
+			"Input name is required, but not provided while calling",
+			"Secret token is required, but not provided while calling",
+		}},
+		{"undeclared", map[string]interface{}{"name": "act", "nmae": "act"}, "inherit", []string{
+			"Invalid input, nmae is not defined in the referenced workflow",
+		}},
+		{"mismatch", map[string]interface{}{"name": "act", "count": "two", "enabled": "yes"}, "inherit", []string{
+			"Input count: expected a number but got 'two'",
+			"Input enabled: expected a boolean but got 'yes'",
+		}},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			err := validateWorkflowCall(context.Background(), newCaller(table.with, table.secrets), plan)
+			if table.errors == nil {
+				assert.NoError(t, err)
+				return
+			}
+			for _, e := range table.errors {
+				assert.ErrorContains(t, err, e)
+			}
+		})
+	}
+}
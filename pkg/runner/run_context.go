@@ -5,11 +5,8 @@ import (
 	"bufio"
 	"bytes"
 	"context"
-	"crypto/rand"
 	"crypto/sha256"
-	"encoding/hex"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -40,6 +37,7 @@ type RunContext struct {
 	ExtraPath           []string
 	CurrentStep         string
 	StepResults         map[string]*model.StepResult
+	PostStepResults     map[string]*model.StepResult
 	IntraActionState    map[string]map[string]string
 	ExprEval            ExpressionEvaluator
 	JobContainer        container.ExecutionsEnvironment
@@ -53,6 +51,15 @@ type RunContext struct {
 	caller              *caller // job calling this RunContext (reusable workflows)
 	Cancelled           bool
 	nodeToolFullPath    string
+	checkpointRun       string         // identifies the run in the tags of checkpoint images
+	resume              *jobCheckpoint // the checkpoint the job is resumed from
+	pooled              *pooledContainer
+	overlay             *workspaceOverlay
+	preparedImages      map[string]bool     // images pulled or built before the plan started
+	dockerActionImages  *dockerActionImages // images of docker actions used by the plan
+	services            map[string]model.JobServiceContext
+	externalNeeds       map[string]exprparser.Needs // results and outputs of needed jobs, which are skipped or restored from a checkpoint
+	hashFilesArchive    *archiveHashFilesFS         // the workspace read by hashFiles during the current step
 }
 
 func (rc *RunContext) AddMask(mask string) {
@@ -126,6 +133,9 @@ func getDockerDaemonSocketMountPath(daemonPath string) string {
 // Returns the binds and mounts for the container, resolving paths as appropriate
 func (rc *RunContext) GetBindsAndMounts() ([]string, map[string]string) {
 	name := rc.jobContainerName()
+	if rc.pooled != nil {
+		name = rc.pooled.name
+	}
 
 	if rc.Config.ContainerDaemonSocket == "" {
 		rc.Config.ContainerDaemonSocket = "/var/run/docker.sock"
@@ -176,6 +186,8 @@ func (rc *RunContext) GetBindsAndMounts() ([]string, map[string]string) {
 			bindModifiers = ":z"
 		}
 		binds = append(binds, fmt.Sprintf("%s:%s%s", rc.Config.Workdir, ext.ToContainerPath(rc.Config.Workdir), bindModifiers))
+	} else if rc.overlay != nil && rc.overlay.fuse {
+		binds = append(binds, fmt.Sprintf("%s:%s", rc.overlay.mergedDir(), ext.ToContainerPath(rc.Config.Workdir)))
 	} else {
 		mounts[name] = ext.ToContainerPath(rc.Config.Workdir)
 	}
@@ -195,35 +207,48 @@ func (rc *RunContext) startHostEnvironment() common.Executor {
 			}
 			return true
 		})
-		cacheDir := rc.ActionCacheDir()
-		randBytes := make([]byte, 8)
-		_, _ = rand.Read(randBytes)
-		miscpath := filepath.Join(cacheDir, hex.EncodeToString(randBytes))
-		actPath := filepath.Join(miscpath, "act")
-		if err := os.MkdirAll(actPath, 0o777); err != nil {
+		if rc.Config.Checkpoint {
+			logger.Warnf("--checkpoint is not supported for jobs running on the host")
+		}
+		backend, err := container.GetBackend(container.BackendHost)
+		if err != nil {
 			return err
 		}
-		path := filepath.Join(miscpath, "hostexecutor")
-		if err := os.MkdirAll(path, 0o777); err != nil {
+		rc.JobContainer, err = backend.NewEnvironment(ctx, &container.BackendInput{
+			NewContainerInput: container.NewContainerInput{
+				Stdout: logWriter,
+			},
+			Workdir:  rc.Config.Workdir,
+			CacheDir: rc.ActionCacheDir(),
+		})
+		if err != nil {
 			return err
 		}
-		runnerTmp := filepath.Join(miscpath, "tmp")
-		if err := os.MkdirAll(runnerTmp, 0o777); err != nil {
+
+		// services run in docker with their ports published to localhost, the job reaches them
+		// on the ports in job.services.<id>.ports
+		services, err := rc.serviceContainerInputs(ctx, logWriter, "bridge")
+		if err != nil {
 			return err
 		}
-		toolCache := filepath.Join(cacheDir, "tool_cache")
-		rc.JobContainer = &container.HostEnvironment{
-			Path:      path,
-			TmpDir:    runnerTmp,
-			ToolCache: toolCache,
-			Workdir:   rc.Config.Workdir,
-			ActPath:   actPath,
-			CleanUp: func() {
-				os.RemoveAll(miscpath)
-			},
-			StdOut: logWriter,
+		forceServicePull := false
+		serviceContainers := map[string]container.ExecutionsEnvironment{}
+		for serviceID, input := range services {
+			publishPortsToLocalhost(input)
+			forceServicePull = forceServicePull || rc.forcePull(input.Image)
+			serviceContainers[serviceID] = container.NewContainer(input)
+			rc.ServiceContainers = append(rc.ServiceContainers, serviceContainers[serviceID])
 		}
-		rc.cleanUpJobContainer = rc.JobContainer.Remove()
+
+		rc.cleanUpJobContainer = rc.JobContainer.Remove().Then(func(ctx context.Context) error {
+			if len(rc.ServiceContainers) > 0 {
+				logger.Infof("Cleaning up services for job %s", rc.JobName)
+				if err := rc.stopServiceContainers()(ctx); err != nil {
+					logger.Errorf("Error while cleaning services: %v", err)
+				}
+			}
+			return nil
+		})
 		for k, v := range rc.JobContainer.GetRunnerContext(ctx) {
 			if v, ok := v.(string); ok {
 				rc.Env[fmt.Sprintf("RUNNER_%s", strings.ToUpper(k))] = v
@@ -239,6 +264,10 @@ func (rc *RunContext) startHostEnvironment() common.Executor {
 		}
 
 		return common.NewPipelineExecutor(
+			rc.pullServicesImages(forceServicePull),
+			// services of a previous run with the same names
+			rc.stopServiceContainers(),
+			rc.startServiceContainers(""),
 			rc.JobContainer.Copy(rc.JobContainer.GetActPath()+"/", &container.FileEntry{
 				Name: "workflow/event.json",
 				Mode: 0o644,
@@ -248,6 +277,8 @@ func (rc *RunContext) startHostEnvironment() common.Executor {
 				Mode: 0o666,
 				Body: "",
 			}),
+			rc.waitForServiceContainers(),
+			rc.updateServicesContext(serviceContainers),
 		)(ctx)
 	}
 }
@@ -256,6 +287,9 @@ func (rc *RunContext) startJobContainer() common.Executor {
 	return func(ctx context.Context) error {
 		logger := common.Logger(ctx)
 		image := rc.platformImage(ctx)
+		if rc.resume != nil {
+			image = rc.resume.Image
+		}
 		rawLogger := logger.WithField("raw_output", true)
 		logWriter := common.NewLineWriter(rc.commandHandler(ctx), func(s string) bool {
 			if rc.Config.LogOutput {
@@ -271,6 +305,10 @@ func (rc *RunContext) startJobContainer() common.Executor {
 			return fmt.Errorf("failed to handle credentials: %s", err)
 		}
 
+		if rc.overlay, err = rc.newWorkspaceOverlay(ctx); err != nil {
+			return err
+		}
+
 		logger.Infof("\U0001f680  Start image=%s", image)
 		name := rc.jobContainerName()
 
@@ -291,67 +329,26 @@ func (rc *RunContext) startJobContainer() common.Executor {
 		networkName, createAndDeleteNetwork := rc.networkName()
 
 		// add service containers
-		for serviceID, spec := range rc.Run.Job().Services {
-			// interpolate env
-			interpolatedEnvs := make(map[string]string, len(spec.Env))
-			for k, v := range spec.Env {
-				interpolatedEnvs[k] = rc.ExprEval.Interpolate(ctx, v)
-			}
-			envs := make([]string, 0, len(interpolatedEnvs))
-			for k, v := range interpolatedEnvs {
-				envs = append(envs, fmt.Sprintf("%s=%s", k, v))
-			}
-			username, password, err = rc.handleServiceCredentials(ctx, spec.Credentials)
-			if err != nil {
-				return fmt.Errorf("failed to handle service %s credentials: %w", serviceID, err)
-			}
-
-			interpolatedVolumes := make([]string, 0, len(spec.Volumes))
-			for _, volume := range spec.Volumes {
-				interpolatedVolumes = append(interpolatedVolumes, rc.ExprEval.Interpolate(ctx, volume))
-			}
-			serviceBinds, serviceMounts := rc.GetServiceBindsAndMounts(interpolatedVolumes)
-
-			interpolatedPorts := make([]string, 0, len(spec.Ports))
-			for _, port := range spec.Ports {
-				interpolatedPorts = append(interpolatedPorts, rc.ExprEval.Interpolate(ctx, port))
-			}
-			exposedPorts, portBindings, err := nat.ParsePortSpecs(interpolatedPorts)
-			if err != nil {
-				return fmt.Errorf("failed to parse service %s ports: %w", serviceID, err)
-			}
-
-			imageName := rc.ExprEval.Interpolate(ctx, spec.Image)
-			if imageName == "" {
-				logger.Infof("The service '%s' will not be started because the container definition has an empty image.", serviceID)
-				continue
-			}
-
-			serviceContainerName := createContainerName(rc.jobContainerName(), serviceID)
-			c := container.NewContainer(&container.NewContainerInput{
-				Name:           serviceContainerName,
-				WorkingDir:     ext.ToContainerPath(rc.Config.Workdir),
-				Image:          imageName,
-				Username:       username,
-				Password:       password,
-				Env:            envs,
-				Mounts:         serviceMounts,
-				Binds:          serviceBinds,
-				Stdout:         logWriter,
-				Stderr:         logWriter,
-				Privileged:     rc.Config.Privileged,
-				UsernsMode:     rc.Config.UsernsMode,
-				Platform:       rc.Config.ContainerArchitecture,
-				Options:        rc.ExprEval.Interpolate(ctx, spec.Options),
-				NetworkMode:    networkName,
-				NetworkAliases: []string{serviceID},
-				ExposedPorts:   exposedPorts,
-				PortBindings:   portBindings,
-			})
-			rc.ServiceContainers = append(rc.ServiceContainers, c)
+		services, err := rc.serviceContainerInputs(ctx, logWriter, networkName)
+		if err != nil {
+			return err
+		}
+		forceServicePull := false
+		serviceContainers := map[string]container.ExecutionsEnvironment{}
+		for serviceID, input := range services {
+			forceServicePull = forceServicePull || rc.forcePull(input.Image)
+			serviceContainers[serviceID] = container.NewContainer(input)
+			rc.ServiceContainers = append(rc.ServiceContainers, serviceContainers[serviceID])
 		}
 
 		rc.cleanUpJobContainer = func(ctx context.Context) error {
+			if rc.pooled != nil {
+				// the container keeps running for the next job with the same image and options
+				jobContainerPool.release(rc.pooled, rc.nodeToolFullPath)
+				rc.pooled = nil
+				return nil
+			}
+
 			reuseJobContainer := func(_ context.Context) bool {
 				return rc.Config.ReuseContainers
 			}
@@ -360,6 +357,7 @@ func (rc *RunContext) startJobContainer() common.Executor {
 				return rc.JobContainer.Remove().IfNot(reuseJobContainer).
 					Then(container.NewDockerVolumeRemoveExecutor(rc.jobContainerName(), false)).IfNot(reuseJobContainer).
 					Then(container.NewDockerVolumeRemoveExecutor(rc.jobContainerName()+"-env", false)).IfNot(reuseJobContainer).
+					Then(rc.cleanUpWorkspaceOverlay()).IfNot(reuseJobContainer).
 					Then(func(ctx context.Context) error {
 						if len(rc.ServiceContainers) > 0 {
 							logger.Infof("Cleaning up services for job %s", rc.JobName)
@@ -390,38 +388,67 @@ func (rc *RunContext) startJobContainer() common.Executor {
 			jobContainerNetwork = "host"
 		}
 
-		rc.JobContainer = container.NewContainer(&container.NewContainerInput{
-			Cmd:            nil,
-			Entrypoint:     []string{"tail", "-f", "/dev/null"},
-			WorkingDir:     ext.ToContainerPath(rc.Config.Workdir),
-			Image:          image,
-			Username:       username,
-			Password:       password,
-			Name:           name,
-			Env:            envList,
-			Mounts:         mounts,
-			NetworkMode:    jobContainerNetwork,
-			NetworkAliases: []string{rc.Name},
-			Binds:          binds,
-			Stdout:         logWriter,
-			Stderr:         logWriter,
-			Privileged:     rc.Config.Privileged,
-			UsernsMode:     rc.Config.UsernsMode,
-			Platform:       rc.Config.ContainerArchitecture,
-			Options:        rc.options(ctx),
+		if key := rc.containerPoolKey(ctx, image, binds, jobContainerNetwork); key != "" {
+			pooled, err := jobContainerPool.acquire(containerPoolLockDir(rc.ActionCacheDir()), key)
+			if err != nil {
+				logger.Warnf("Unable to take a container of the pool, the job gets a container of its own: %v", err)
+			} else {
+				rc.pooled = pooled
+				if rc.nodeToolFullPath == "" {
+					rc.nodeToolFullPath = rc.pooled.nodeTool
+				}
+				name = rc.pooled.name
+				_, mounts = rc.GetBindsAndMounts()
+				logger.Infof("\u267B\uFE0F  Pooled container %s", name)
+			}
+		}
+
+		backend, err := container.GetBackend(container.BackendDocker)
+		if err != nil {
+			return err
+		}
+		rc.JobContainer, err = backend.NewEnvironment(ctx, &container.BackendInput{
+			NewContainerInput: container.NewContainerInput{
+				Cmd:            nil,
+				Entrypoint:     []string{"tail", "-f", "/dev/null"},
+				WorkingDir:     ext.ToContainerPath(rc.Config.Workdir),
+				Image:          image,
+				Username:       username,
+				Password:       password,
+				Name:           name,
+				Env:            envList,
+				Mounts:         mounts,
+				NetworkMode:    jobContainerNetwork,
+				NetworkAliases: []string{rc.Name},
+				Binds:          binds,
+				Stdout:         logWriter,
+				Stderr:         logWriter,
+				Privileged:     rc.Config.Privileged,
+				UsernsMode:     rc.Config.UsernsMode,
+				Platform:       rc.Config.ContainerArchitecture,
+				Options:        rc.options(ctx),
+			},
+			Workdir:  rc.Config.Workdir,
+			CacheDir: rc.ActionCacheDir(),
 		})
-		if rc.JobContainer == nil {
-			return errors.New("Failed to create job container")
+		if err != nil {
+			return err
 		}
 
 		return common.NewPipelineExecutor(
-			rc.pullServicesImages(rc.Config.ForcePull),
-			rc.JobContainer.Pull(rc.Config.ForcePull),
-			rc.stopJobContainer(),
+			rc.pullServicesImages(forceServicePull),
+			// checkpoint images only exist locally
+			rc.JobContainer.Pull(rc.forcePull(image)).IfBool(rc.resume == nil),
+			rc.stopJobContainer().IfBool(rc.pooled == nil),
+			rc.removeOutdatedPooledContainer(image),
+			// a reused container must not replace the container of the checkpoint
+			rc.JobContainer.Remove().IfBool(rc.resume != nil),
+			rc.mountWorkspaceOverlay(),
 			container.NewDockerNetworkCreateExecutor(networkName).IfBool(createAndDeleteNetwork),
 			rc.startServiceContainers(networkName),
 			rc.JobContainer.Create(rc.Config.ContainerCapAdd, rc.Config.ContainerCapDrop),
 			rc.JobContainer.Start(false),
+			rc.resetPooledContainer(),
 			rc.JobContainer.Copy(rc.JobContainer.GetActPath()+"/", &container.FileEntry{
 				Name: "workflow/event.json",
 				Mode: 0o644,
@@ -431,11 +458,78 @@ func (rc *RunContext) startJobContainer() common.Executor {
 				Mode: 0o666,
 				Body: "",
 			}),
+			rc.restoreCheckpointWorkspace(),
 			rc.waitForServiceContainers(),
+			rc.updateServicesContext(serviceContainers),
 		)(ctx)
 	}
 }
 
+// serviceContainerInputs returns the containers of the services of the job by service id,
+// services with an empty image are not started
+func (rc *RunContext) serviceContainerInputs(ctx context.Context, logWriter io.Writer, networkName string) (map[string]*container.NewContainerInput, error) {
+	ext := container.LinuxContainerEnvironmentExtensions{}
+	inputs := map[string]*container.NewContainerInput{}
+	for serviceID, spec := range rc.Run.Job().Services {
+		// interpolate env
+		interpolatedEnvs := make(map[string]string, len(spec.Env))
+		for k, v := range spec.Env {
+			interpolatedEnvs[k] = rc.ExprEval.Interpolate(ctx, v)
+		}
+		envs := make([]string, 0, len(interpolatedEnvs))
+		for k, v := range interpolatedEnvs {
+			envs = append(envs, fmt.Sprintf("%s=%s", k, v))
+		}
+		username, password, err := rc.handleServiceCredentials(ctx, spec.Credentials)
+		if err != nil {
+			return nil, fmt.Errorf("failed to handle service %s credentials: %w", serviceID, err)
+		}
+
+		interpolatedVolumes := make([]string, 0, len(spec.Volumes))
+		for _, volume := range spec.Volumes {
+			interpolatedVolumes = append(interpolatedVolumes, rc.ExprEval.Interpolate(ctx, volume))
+		}
+		serviceBinds, serviceMounts := rc.GetServiceBindsAndMounts(interpolatedVolumes)
+
+		interpolatedPorts := make([]string, 0, len(spec.Ports))
+		for _, port := range spec.Ports {
+			interpolatedPorts = append(interpolatedPorts, rc.ExprEval.Interpolate(ctx, port))
+		}
+		exposedPorts, portBindings, err := nat.ParsePortSpecs(interpolatedPorts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse service %s ports: %w", serviceID, err)
+		}
+
+		imageName := rc.ExprEval.Interpolate(ctx, spec.Image)
+		if imageName == "" {
+			common.Logger(ctx).Infof("The service '%s' will not be started because the container definition has an empty image.", serviceID)
+			continue
+		}
+
+		inputs[serviceID] = &container.NewContainerInput{
+			Name:           createContainerName(rc.jobContainerName(), serviceID),
+			WorkingDir:     ext.ToContainerPath(rc.Config.Workdir),
+			Image:          imageName,
+			Username:       username,
+			Password:       password,
+			Env:            envs,
+			Mounts:         serviceMounts,
+			Binds:          serviceBinds,
+			Stdout:         logWriter,
+			Stderr:         logWriter,
+			Privileged:     rc.Config.Privileged,
+			UsernsMode:     rc.Config.UsernsMode,
+			Platform:       rc.Config.ContainerArchitecture,
+			Options:        rc.ExprEval.Interpolate(ctx, spec.Options),
+			NetworkMode:    networkName,
+			NetworkAliases: []string{serviceID},
+			ExposedPorts:   exposedPorts,
+			PortBindings:   portBindings,
+		}
+	}
+	return inputs, nil
+}
+
 func (rc *RunContext) execJobContainer(cmd []string, env map[string]string, user, workdir string) common.Executor {
 	return func(ctx context.Context) error {
 		return rc.JobContainer.Exec(cmd, env, user, workdir)(ctx)
@@ -617,6 +711,38 @@ func (rc *RunContext) waitForServiceContainers() common.Executor {
 	}
 }
 
+// publishPortsToLocalhost publishes the ports of a service without a host address only on localhost
+func publishPortsToLocalhost(input *container.NewContainerInput) {
+	for _, bindings := range input.PortBindings {
+		for i := range bindings {
+			if bindings[i].HostIP == "" {
+				bindings[i].HostIP = "127.0.0.1"
+			}
+		}
+	}
+}
+
+// updateServicesContext sets the ids, the networks and the published ports of the service
+// containers in the job context
+func (rc *RunContext) updateServicesContext(serviceContainers map[string]container.ExecutionsEnvironment) common.Executor {
+	return func(ctx context.Context) error {
+		services := map[string]model.JobServiceContext{}
+		for serviceID, c := range serviceContainers {
+			service := model.JobServiceContext{Ports: map[string]string{}}
+			if inspector, ok := c.(container.ServiceInspector); ok && !common.Dryrun(ctx) {
+				inspected, err := inspector.InspectService(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to inspect service %s: %w", serviceID, err)
+				}
+				service.ID, service.Network, service.Ports = inspected.ID, inspected.Network, inspected.Ports
+			}
+			services[serviceID] = service
+		}
+		rc.services = services
+		return nil
+	}
+}
+
 func (rc *RunContext) stopServiceContainers() common.Executor {
 	return func(ctx context.Context) error {
 		execs := []common.Executor{}
@@ -665,17 +791,46 @@ func (rc *RunContext) startContainer() common.Executor {
 	return func(ctx context.Context) error {
 		ctx, cancel := common.EarlyCancelContext(ctx)
 		defer cancel()
-		if rc.IsHostEnv(ctx) {
+		switch backend, _ := rc.jobBackend(ctx); backend {
+		case container.BackendHost:
 			return rc.startHostEnvironment()(ctx)
+		case container.BackendDocker:
+			return rc.startJobContainer()(ctx)
+		default:
+			return rc.startBackendEnvironment(backend)(ctx)
 		}
-		return rc.startJobContainer()(ctx)
 	}
 }
 
 func (rc *RunContext) IsHostEnv(ctx context.Context) bool {
+	backend, _ := rc.jobBackend(ctx)
+	return backend == container.BackendHost
+}
+
+// jobBackend returns the backend of the job and the image passed to it. Platforms select
+// a backend with backend:<name>:<image>, -self-hosted selects the host backend and
+// ssh://[user@]host[:port][/dir] selects the ssh backend, the image of the job container
+// replaces the image of the platform. Jobs of the host backend with a job container run
+// with docker, the ssh backend keeps its target and fails these jobs.
+func (rc *RunContext) jobBackend(ctx context.Context) (string, string) {
 	platform := rc.runsOnImage(ctx)
-	image := rc.containerImage(ctx)
-	return image == "" && strings.EqualFold(platform, "-self-hosted")
+	backend, image := container.BackendDocker, platform
+	if strings.EqualFold(platform, "-self-hosted") {
+		backend, image = container.BackendHost, ""
+	} else if strings.HasPrefix(platform, "ssh://") {
+		backend = container.BackendSSH
+	} else if rest, ok := strings.CutPrefix(platform, "backend:"); ok {
+		backend, image, _ = strings.Cut(rest, ":")
+		backend = strings.ToLower(backend)
+	}
+
+	if containerImage := rc.containerImage(ctx); containerImage != "" && backend != container.BackendSSH {
+		if backend == container.BackendHost {
+			backend = container.BackendDocker
+		}
+		image = containerImage
+	}
+	return backend, image
 }
 
 func (rc *RunContext) stopContainer() common.Executor {
@@ -720,6 +875,10 @@ func (rc *RunContext) Executor() (common.Executor, error) {
 	}
 
 	return func(ctx context.Context) error {
+		if rc.resume != nil {
+			// the evaluator of the job if has to see the needs restored from the checkpoint
+			rc.ExprEval = rc.NewExpressionEvaluator(ctx)
+		}
 		res, err := rc.isEnabled(ctx)
 		if err != nil {
 			return err
@@ -773,11 +932,8 @@ func (rc *RunContext) runsOnPlatformNames(ctx context.Context) []string {
 }
 
 func (rc *RunContext) platformImage(ctx context.Context) string {
-	if containerImage := rc.containerImage(ctx); containerImage != "" {
-		return containerImage
-	}
-
-	return rc.runsOnImage(ctx)
+	_, image := rc.jobBackend(ctx)
+	return image
 }
 
 func (rc *RunContext) options(ctx context.Context) string {
@@ -814,8 +970,7 @@ func (rc *RunContext) isEnabled(ctx context.Context) (bool, error) {
 		return true, nil
 	}
 
-	img := rc.platformImage(ctx)
-	if img == "" {
+	if rc.containerImage(ctx) == "" && rc.runsOnImage(ctx) == "" {
 		for _, platformName := range rc.runsOnPlatformNames(ctx) {
 			l.Infof("\U0001F6A7  Skipping unsupported platform -- Try running with `-P %+v=...`", platformName)
 		}
@@ -870,7 +1025,8 @@ func (rc *RunContext) getJobContext() *model.JobContext {
 		}
 	}
 	return &model.JobContext{
-		Status: jobStatus,
+		Status:   jobStatus,
+		Services: rc.services,
 	}
 }
 
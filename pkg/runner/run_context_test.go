@@ -10,7 +10,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/docker/go-connections/nat"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/nektos/act/pkg/container"
 	"github.com/nektos/act/pkg/exprparser"
 	"github.com/nektos/act/pkg/model"
 
@@ -726,3 +728,45 @@ func TestSetRuntimeVariablesWithRunID(t *testing.T) {
 	assert.True(t, ok, "scp claim exists")
 	assert.Equal(t, "Actions.Results:45:45", scp, "contains expected scp claim")
 }
+
+type serviceInspectorMock struct {
+	containerMock
+	service *container.ServiceContext
+}
+
+func (m *serviceInspectorMock) InspectService(_ context.Context) (*container.ServiceContext, error) {
+	return m.service, nil
+}
+
+func TestUpdateServicesContext(t *testing.T) {
+	ctx := context.Background()
+	rc := createIfTestRunContext(map[string]*model.Job{"job1": createJob(t, "runs-on: ubuntu-latest", "")})
+	err := rc.updateServicesContext(map[string]container.ExecutionsEnvironment{
+		"postgres": &serviceInspectorMock{service: &container.ServiceContext{
+			ID:      "4fa6e0f0c678",
+			Network: "7d3f1c2b9a10",
+			Ports:   map[string]string{"5432": "49153", "53/udp": "49154"},
+		}},
+		"redis": &containerMock{},
+	})(ctx)
+	assert.NoError(t, err)
+
+	rc.ExprEval = rc.NewExpressionEvaluator(ctx)
+	assert.Equal(t, "49153", rc.ExprEval.Interpolate(ctx, "${{ job.services.postgres.ports['5432'] }}"))
+	assert.Equal(t, "49154", rc.ExprEval.Interpolate(ctx, "${{ job.services.postgres.ports['53/udp'] }}"))
+	assert.Equal(t, "4fa6e0f0c678", rc.ExprEval.Interpolate(ctx, "${{ job.services.postgres.id }}"))
+	assert.Equal(t, "7d3f1c2b9a10", rc.ExprEval.Interpolate(ctx, "${{ job.services.postgres.network }}"))
+	assert.Equal(t, map[string]string{}, rc.getJobContext().Services["redis"].Ports)
+}
+
+func TestPublishPortsToLocalhost(t *testing.T) {
+	input := &container.NewContainerInput{PortBindings: nat.PortMap{
+		"80/tcp":   {{HostPort: ""}},
+		"5432/tcp": {{HostIP: "0.0.0.0", HostPort: "5432"}},
+	}}
+	publishPortsToLocalhost(input)
+	assert.Equal(t, nat.PortMap{
+		"80/tcp":   {{HostIP: "127.0.0.1", HostPort: ""}},
+		"5432/tcp": {{HostIP: "0.0.0.0", HostPort: "5432"}},
+	}, input.PortBindings)
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"runtime"
+	"time"
 
 	docker_container "github.com/moby/moby/api/types/container"
 	"github.com/nektos/act/pkg/common"
+	"github.com/nektos/act/pkg/exprparser"
 	"github.com/nektos/act/pkg/model"
 	log "github.com/sirupsen/logrus"
 )
@@ -59,9 +62,21 @@ type Config struct {
 	ReplaceGheActionWithGithubCom      []string                     // Use actions from GitHub Enterprise instance to GitHub
 	ReplaceGheActionTokenWithGithubCom string                       // Token of private action repo on GitHub.
 	Matrix                             map[string]map[string]bool   // Matrix config to run
+	MatrixFilter                       string                       // expression over the matrix context selecting the matrix combinations to run
+	MatrixIndex                        int                          // 1-based index of the only matrix combination to run, 0 runs all combinations
 	ContainerNetworkMode               docker_container.NetworkMode // the network mode of job containers (the value of --network)
 	ActionCache                        ActionCache                  // Use a custom ActionCache Implementation
 	ConcurrentJobs                     int                          // Number of max concurrent jobs
+	ActionMocks                        []*ActionMock                // actions replaced by steps with fixed outputs, the first match wins
+	SkippedNeeds                       map[string]exprparser.Needs  // outputs and results of needed jobs, which are not part of the plan
+	MemoizeDir                         string                       // directory of the recorded results of run steps, memoization is disabled if empty
+	Checkpoint                         bool                         // commit the job container and save the job state after each step
+	ResumeFrom                         string                       // id, name or 1-based number of the step to resume the jobs from, using the checkpoint of the step before
+	ContainerPool                      bool                         // keep job containers running after their job and reuse them for jobs with the same image and options
+	PrepareImages                      bool                         // pull and build the images of all jobs of the plan in parallel before the first job starts
+	WorkspaceMode                      string                       // how the workdir is provided to the job containers, one of WorkspaceModes, BindWorkdir is set for WorkspaceModeBind
+	ExportWorkspaceDir                 string                       // directory the workspaces of the jobs are exported to after the jobs, only the changes of overlay workspaces
+	Exports                            []*JobExport                 // paths of job containers copied to the host after the jobs
 }
 
 func (config *Config) GetConcurrentJobs() int {
@@ -82,9 +97,14 @@ type caller struct {
 }
 
 type runnerImpl struct {
-	config    *Config
-	eventJSON string
-	caller    *caller // the job calling this runner (caller of a reusable workflow)
+	config             *Config
+	eventJSON          string
+	checkpointRun      string
+	preparedImages     map[string]bool // images pulled or built by prepareImages
+	dockerActionImages *dockerActionImages
+	caller             *caller // the job calling this runner (caller of a reusable workflow)
+	skippedNeeds       map[*model.Run]map[string]exprparser.Needs
+	matrixes           map[*model.Run][]map[string]interface{} // matrix combinations of the runs not needing planned jobs
 }
 
 // New Creates a new Runner
@@ -98,6 +118,7 @@ func New(runnerConfig *Config) (Runner, error) {
 
 func (runner *runnerImpl) configure() (Runner, error) {
 	runner.eventJSON = "{}"
+	runner.checkpointRun = time.Now().UTC().Format("20060102150405")
 	if runner.config.EventPath != "" {
 		log.Debugf("Reading event.json from %s", runner.config.EventPath)
 		eventJSONBytes, err := os.ReadFile(runner.config.EventPath)
@@ -125,6 +146,20 @@ func (runner *runnerImpl) NewPlanExecutor(plan *model.Plan) common.Executor {
 	stagePipeline := make([]common.Executor, 0)
 	log.Debugf("Plan Stages: %v", plan.Stages)
 
+	runner.setupSkippedNeeds(plan)
+	runner.matrixes = map[*model.Run][]map[string]interface{}{}
+
+	runner.preparedImages = map[string]bool{}
+	// the images of the docker actions of reusable workflows are removed with the ones of the caller
+	if runner.caller != nil {
+		runner.dockerActionImages = runner.caller.runContext.dockerActionImages
+	} else {
+		runner.dockerActionImages = &dockerActionImages{}
+	}
+	if runner.config.PrepareImages {
+		stagePipeline = append(stagePipeline, runner.prepareImages(plan))
+	}
+
 	for i := range plan.Stages {
 		stage := plan.Stages[i]
 		stagePipeline = append(stagePipeline, func(ctx context.Context) error {
@@ -161,21 +196,9 @@ func (runner *runnerImpl) NewPlanExecutor(plan *model.Plan) common.Executor {
 					log.Debugf("Job.Strategy.FailFastString: %v", job.Strategy.FailFastString)
 					log.Debugf("Job.Strategy.MaxParallelString: %v", job.Strategy.MaxParallelString)
 					log.Debugf("Job.Strategy.RawMatrix: %v", job.Strategy.RawMatrix)
-
-					strategyRc := runner.newRunContext(ctx, run, nil)
-					if err := strategyRc.NewExpressionEvaluator(ctx).EvaluateYamlNode(ctx, &job.Strategy.RawMatrix); err != nil {
-						log.Errorf("Error while evaluating matrix: %v", err)
-					}
 				}
 
-				var matrixes []map[string]interface{}
-				if m, err := job.GetMatrixes(); err != nil {
-					log.Errorf("Error while get job's matrix: %v", err)
-				} else {
-					log.Debugf("Job Matrices: %v", m)
-					log.Debugf("Runner Matrices: %v", runner.config.Matrix)
-					matrixes = selectMatrixes(m, runner.config.Matrix)
-				}
+				matrixes := runner.jobMatrixes(ctx, run)
 				log.Debugf("Final matrix after applying user inclusions '%v'", matrixes)
 
 				maxParallel := 4
@@ -215,7 +238,69 @@ func (runner *runnerImpl) NewPlanExecutor(plan *model.Plan) common.Executor {
 		})
 	}
 
-	return common.NewPipelineExecutor(stagePipeline...).Then(handleFailure(plan))
+	return common.NewPipelineExecutor(stagePipeline...).Finally(runner.dockerActionImages.removeStale().IfBool(runner.caller == nil)).Then(handleFailure(plan))
+}
+
+// EvaluateJobMatrixes evaluates the matrix of the job of the run with the contexts of the config
+// and returns all its combinations, the 1-based index of a combination is the one of --matrix-index
+func EvaluateJobMatrixes(ctx context.Context, config *Config, run *model.Run) ([]map[string]interface{}, error) {
+	r, err := New(config)
+	if err != nil {
+		return nil, err
+	}
+	return r.(*runnerImpl).evaluateMatrixes(ctx, run)
+}
+
+// evaluateMatrixes evaluates the matrix of the job of the run and returns all its combinations
+func (runner *runnerImpl) evaluateMatrixes(ctx context.Context, run *model.Run) ([]map[string]interface{}, error) {
+	job := run.Job()
+	if job.Strategy != nil {
+		strategyRc := runner.newRunContext(ctx, run, nil)
+		if err := strategyRc.NewExpressionEvaluator(ctx).EvaluateYamlNode(ctx, &job.Strategy.RawMatrix); err != nil {
+			log.Errorf("Error while evaluating matrix: %v", err)
+		}
+	}
+	return job.GetMatrixes()
+}
+
+// jobMatrixes returns the matrix combinations of the job to run. The combinations of jobs,
+// which need no planned jobs, are evaluated once and shared by prepareImages and the jobs.
+func (runner *runnerImpl) jobMatrixes(ctx context.Context, run *model.Run) []map[string]interface{} {
+	cacheable := runner.matrixes != nil && !runner.needsPlannedJobs(run)
+	if matrixes, ok := runner.matrixes[run]; ok && cacheable {
+		return matrixes
+	}
+	matrixes := runner.selectJobMatrixes(ctx, run)
+	if cacheable {
+		runner.matrixes[run] = matrixes
+	}
+	return matrixes
+}
+
+// needsPlannedJobs returns whether the job of the run needs jobs of the plan, the
+// needs outside of the plan are known before the plan starts
+func (runner *runnerImpl) needsPlannedJobs(run *model.Run) bool {
+	for _, jobID := range run.Job().Needs() {
+		if _, ok := runner.skippedNeeds[run][jobID]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (runner *runnerImpl) selectJobMatrixes(ctx context.Context, run *model.Run) []map[string]interface{} {
+	job := run.Job()
+	m, err := runner.evaluateMatrixes(ctx, run)
+	if err != nil {
+		log.Errorf("Error while get job's matrix: %v", err)
+		return nil
+	}
+	log.Debugf("Job Matrices: %v", m)
+	log.Debugf("Runner Matrices: %v", runner.config.Matrix)
+	if job.Strategy != nil {
+		m = filterMatrixes(m, runner.config.MatrixFilter, runner.config.MatrixIndex)
+	}
+	return selectMatrixes(m, runner.config.Matrix)
 }
 
 func handleFailure(plan *model.Plan) common.Executor {
@@ -231,6 +316,102 @@ func handleFailure(plan *model.Plan) common.Executor {
 	}
 }
 
+// setupSkippedNeeds collects the results and outputs of all jobs needed by the runs of
+// the plan, which are not part of the plan themselves. Jobs without a configured result
+// are considered to be successful.
+func (runner *runnerImpl) setupSkippedNeeds(plan *model.Plan) {
+	planned := map[*model.Job]bool{}
+	for _, stage := range plan.Stages {
+		for _, run := range stage.Runs {
+			planned[run.Job()] = true
+		}
+	}
+
+	used := map[string]bool{}
+	runner.skippedNeeds = map[*model.Run]map[string]exprparser.Needs{}
+	for _, stage := range plan.Stages {
+		for _, run := range stage.Runs {
+			skippedNeeds := map[string]exprparser.Needs{}
+			needs := run.Job().Needs()
+			for len(needs) > 0 {
+				jobID := needs[0]
+				needs = needs[1:]
+
+				job := run.Workflow.GetJob(jobID)
+				if _, ok := skippedNeeds[jobID]; ok || job == nil || planned[job] {
+					continue
+				}
+				needs = append(needs, job.Needs()...)
+
+				skipped := runner.config.SkippedNeeds[jobID]
+				used[jobID] = true
+				if skipped.Result == "" {
+					skipped.Result = "success"
+				}
+				if skipped.Outputs == nil {
+					skipped.Outputs = map[string]string{}
+				}
+				skippedNeeds[jobID] = skipped
+				log.Debugf("Skipped job '%s' needed by '%s' with result '%s' and outputs %v", jobID, run.JobID, skipped.Result, skipped.Outputs)
+			}
+			runner.skippedNeeds[run] = skippedNeeds
+		}
+	}
+
+	// the plans of reusable workflows share the config of the caller
+	if runner.caller != nil {
+		return
+	}
+	for jobID := range runner.config.SkippedNeeds {
+		if !used[jobID] {
+			log.Warnf("Job '%s' of the needs outputs is not a skipped need of the planned jobs", jobID)
+		}
+	}
+}
+
+// filterMatrixes returns the matrix combination at the 1-based index, if index is set,
+// and the combinations for which the filter expression is true
+func filterMatrixes(matrixes []map[string]interface{}, filter string, index int) []map[string]interface{} {
+	if index > 0 {
+		if index > len(matrixes) {
+			log.Errorf("Matrix index %d is out of range, the job has %d matrix combinations", index, len(matrixes))
+			return []map[string]interface{}{}
+		}
+		matrixes = matrixes[index-1 : index]
+	}
+	if filter == "" {
+		return matrixes
+	}
+
+	filtered := make([]map[string]interface{}, 0, len(matrixes))
+	for _, matrix := range matrixes {
+		matched, err := MatchesMatrixFilter(filter, matrix)
+		if err != nil {
+			log.Errorf("%v", err)
+			continue
+		}
+		if !matched {
+			log.Debugf("Skipping matrix '%v' due to matrix filter '%s'", matrix, filter)
+			continue
+		}
+		filtered = append(filtered, matrix)
+	}
+	return filtered
+}
+
+// MatchesMatrixFilter reports whether the filter expression is true for the matrix combination
+func MatchesMatrixFilter(filter string, matrix map[string]interface{}) (bool, error) {
+	interpreter := exprparser.NewInterpeter(&exprparser.EvaluationEnvironment{
+		Github: &model.GithubContext{},
+		Matrix: matrix,
+	}, exprparser.Config{})
+	result, err := interpreter.Evaluate(filter, exprparser.DefaultStatusCheckNone)
+	if err != nil {
+		return false, fmt.Errorf("unable to evaluate matrix filter '%s' for matrix '%v': %w", filter, matrix, err)
+	}
+	return exprparser.IsTruthy(result), nil
+}
+
 func selectMatrixes(originalMatrixes []map[string]interface{}, targetMatrixValues map[string]map[string]bool) []map[string]interface{} {
 	matrixes := make([]map[string]interface{}, 0)
 	for _, original := range originalMatrixes {
@@ -252,12 +433,17 @@ func selectMatrixes(originalMatrixes []map[string]interface{}, targetMatrixValue
 
 func (runner *runnerImpl) newRunContext(ctx context.Context, run *model.Run, matrix map[string]interface{}) *RunContext {
 	rc := &RunContext{
-		Config:      runner.config,
-		Run:         run,
-		EventJSON:   runner.eventJSON,
-		StepResults: make(map[string]*model.StepResult),
-		Matrix:      matrix,
-		caller:      runner.caller,
+		Config:             runner.config,
+		Run:                run,
+		EventJSON:          runner.eventJSON,
+		StepResults:        make(map[string]*model.StepResult),
+		PostStepResults:    make(map[string]*model.StepResult),
+		Matrix:             matrix,
+		caller:             runner.caller,
+		checkpointRun:      runner.checkpointRun,
+		preparedImages:     runner.preparedImages,
+		dockerActionImages: runner.dockerActionImages,
+		externalNeeds:      maps.Clone(runner.skippedNeeds[run]),
 	}
 	rc.ExprEval = rc.NewExpressionEvaluator(ctx)
 	rc.Name = rc.ExprEval.Interpolate(ctx, run.String())
@@ -21,6 +21,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/nektos/act/pkg/common"
+	"github.com/nektos/act/pkg/exprparser"
 	"github.com/nektos/act/pkg/model"
 )
 
@@ -194,6 +195,7 @@ func (j *TestJobFileInfo) runTest(ctx context.Context, t *testing.T, cfg *Config
 		ContainerArchitecture: cfg.ContainerArchitecture,
 		Matrix:                cfg.Matrix,
 		ActionCache:           cfg.ActionCache,
+		PrepareImages:         cfg.PrepareImages,
 	}
 
 	runner, err := New(runnerConfig)
@@ -222,6 +224,55 @@ type TestConfig struct {
 	LocalRepositories map[string]string `yaml:"local-repositories"`
 }
 
+func TestSetupSkippedNeeds(t *testing.T) {
+	workflow, err := model.ReadWorkflow(strings.NewReader(`
+name: needs
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    outputs:
+      version: ${{ steps.version.outputs.version }}
+    steps:
+      - run: echo build
+  test:
+    runs-on: ubuntu-latest
+    needs: build
+    steps:
+      - run: echo test
+  deploy:
+    runs-on: ubuntu-latest
+    needs: test
+    steps:
+      - run: echo deploy
+`), false)
+	assert.NoError(t, err)
+
+	plan := &model.Plan{Stages: []*model.Stage{{Runs: []*model.Run{{Workflow: workflow, JobID: "deploy"}}}}}
+	runner := &runnerImpl{config: &Config{
+		SkippedNeeds: map[string]exprparser.Needs{
+			"build": {Outputs: map[string]string{"version": "1.2.3"}},
+			"test":  {Result: "failure"},
+		},
+	}}
+	runner.setupSkippedNeeds(plan)
+
+	run := plan.Stages[0].Runs[0]
+	assert.Equal(t, map[string]exprparser.Needs{
+		"build": {Result: "success", Outputs: map[string]string{"version": "1.2.3"}},
+		"test":  {Result: "failure", Outputs: map[string]string{}},
+	}, runner.skippedNeeds[run])
+	for _, job := range workflow.Jobs {
+		assert.Empty(t, job.Result, "the jobs of the workflow are left untouched")
+	}
+
+	rc := runner.newRunContext(context.Background(), run, nil)
+	assert.Equal(t, map[string]exprparser.Needs{"test": {Result: "failure", Outputs: map[string]string{}}}, getEvaluatorNeeds(rc))
+	result, err := rc.ExprEval.evaluate(context.Background(), "failure()", exprparser.DefaultStatusCheckNone)
+	assert.NoError(t, err)
+	assert.Equal(t, true, result)
+}
+
 func TestRunEvent(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
@@ -507,6 +558,30 @@ func TestFetchFailureIsJobFailure(t *testing.T) {
 	}
 }
 
+// TestRunEventHostEnvironmentPrepareImages runs matrixes of needs outputs with --prepare-images,
+// which must not evaluate the matrixes before the needed jobs ran
+func TestRunEventHostEnvironmentPrepareImages(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	if runtime.GOOS != "linux" {
+		t.Skip("the host environment tests run on linux")
+	}
+
+	platforms := map[string]string{
+		"ubuntu-latest": "-self-hosted",
+	}
+	tables := []TestJobFileInfo{
+		{workdir, "evalmatrixneeds", "push", "", platforms, secrets},
+		{workdir, "evalmatrixneeds2", "push", "", platforms, secrets},
+	}
+	for _, table := range tables {
+		t.Run(table.workflowPath, func(t *testing.T) {
+			table.runTest(context.Background(), t, &Config{PrepareImages: true})
+		})
+	}
+}
+
 func TestRunEventHostEnvironment(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
@@ -570,6 +645,7 @@ func TestRunEventHostEnvironment(t *testing.T) {
 			{workdir, "uses-action-with-pre-and-post-step", "push", "", platforms, secrets},
 			{workdir, "evalenv", "push", "", platforms, secrets},
 			{workdir, "ensure-post-steps", "push", "Job 'second-post-step-should-fail' failed", platforms, secrets},
+			{workdir, "services-self-hosted", "push", "", platforms, secrets},
 		}...)
 	}
 	if runtime.GOOS == "windows" {
@@ -805,3 +881,19 @@ func TestRunMatrixWithUserDefinedInclusions(t *testing.T) {
 
 	tjfi.runTest(context.Background(), t, &Config{Matrix: matrix})
 }
+
+func TestFilterMatrixes(t *testing.T) {
+	matrixes := []map[string]interface{}{
+		{"node": 16, "os": "ubuntu-latest"},
+		{"node": 18, "os": "ubuntu-latest"},
+		{"node": 18, "os": "windows-latest"},
+		{"node": 20, "os": "ubuntu-latest"},
+	}
+
+	assert.Equal(t, matrixes, filterMatrixes(matrixes, "", 0))
+	assert.Equal(t, matrixes[2:3], filterMatrixes(matrixes, "", 3))
+	assert.Empty(t, filterMatrixes(matrixes, "", 5))
+	assert.Equal(t, []map[string]interface{}{matrixes[1], matrixes[3]}, filterMatrixes(matrixes, "matrix.node >= 18 && matrix.os != 'windows-latest'", 0))
+	assert.Empty(t, filterMatrixes(matrixes, "matrix.node >= 18 && matrix.os != 'windows-latest'", 1))
+	assert.Empty(t, filterMatrixes(matrixes, "matrix.node >=", 0))
+}
@@ -99,6 +99,11 @@ func runStepExecutor(step step, stage stepStage, executor common.Executor) commo
 
 		ifExpression := step.getIfExpression(ctx, stage)
 		rc.CurrentStep = stepModel.ID
+		// the workspace read by hashFiles is shared by all expressions of the step
+		rc.hashFilesArchive = nil
+		defer func() {
+			rc.hashFilesArchive = nil
+		}()
 
 		stepResult := &model.StepResult{
 			Outcome:    model.StepStatusSuccess,
@@ -107,6 +112,8 @@ func runStepExecutor(step step, stage stepStage, executor common.Executor) commo
 		}
 		if stage == stepStageMain {
 			rc.StepResults[rc.CurrentStep] = stepResult
+		} else if stage == stepStagePost && rc.PostStepResults != nil {
+			rc.PostStepResults[rc.CurrentStep] = stepResult
 		}
 
 		err := setupEnv(ctx, step)
@@ -137,6 +144,26 @@ func runStepExecutor(step step, stage stepStage, executor common.Executor) commo
 		}
 		logger.Infof("\u2B50 Run %s %s", stage, stepString)
 
+		memoKey, err := stepMemoKey(ctx, step, stage)
+		if err != nil {
+			logger.Warnf("Unable to memoize %s: %v", stepString, err)
+		}
+		if memoKey != "" {
+			memo, err := rc.readStepMemo(memoKey)
+			if err != nil {
+				logger.Warnf("Unable to read the memoized result of %s: %v", stepString, err)
+			} else if memo != nil {
+				memo.replay(ctx, rc)
+				logger.WithField("stepResult", stepResult.Outcome).Infof("  \u267B\uFE0F  Memoized - %s %s", stage, stepString)
+				return nil
+			}
+		}
+		envBefore := map[string]string{}
+		for k, v := range rc.Env {
+			envBefore[k] = v
+		}
+		pathBefore := append([]string{}, rc.ExtraPath...)
+
 		// Prepare and clean Runner File Commands
 		actPath := rc.JobContainer.GetActPath()
 
@@ -210,7 +237,13 @@ func runStepExecutor(step step, stage stepStage, executor common.Executor) commo
 		ferrors = append(ferrors, processRunnerEnvFileCommand(ctx, outputFileCommand, rc, rc.setOutput))
 		ferrors = append(ferrors, processRunnerSummaryCommand(ctx, summaryFileCommand, rc))
 		ferrors = append(ferrors, rc.UpdateExtraPath(ctx, path.Join(actPath, pathFileCommand)))
-		return errors.Join(ferrors...)
+		err = errors.Join(ferrors...)
+		if err == nil && memoKey != "" && stepResult.Outcome == model.StepStatusSuccess {
+			if err := rc.writeStepMemo(memoKey, rc.newStepMemo(envBefore, pathBefore)); err != nil {
+				logger.Warnf("Unable to memoize %s: %v", stepString, err)
+			}
+		}
+		return err
 	}
 }
 
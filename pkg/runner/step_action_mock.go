@@ -0,0 +1,166 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/nektos/act/pkg/common"
+	"github.com/nektos/act/pkg/model"
+	"github.com/nektos/act/pkg/workflowpattern"
+	"gopkg.in/yaml.v3"
+)
+
+// ActionMock replaces the actions of steps whose uses value matches the glob
+// in Uses, the mocked step sets the outputs and env and exits with ExitCode
+// without fetching or running anything
+type ActionMock struct {
+	Uses     string            `yaml:"uses"`
+	Outputs  map[string]string `yaml:"outputs"`
+	Env      map[string]string `yaml:"env"`
+	ExitCode int               `yaml:"exit-code"`
+
+	pattern *regexp.Regexp
+}
+
+// Compile compiles the glob of Uses, ReadActionMock and ReadActionMocks return compiled mocks
+func (m *ActionMock) Compile() error {
+	pattern, err := workflowpattern.CompilePattern(m.Uses)
+	if err != nil {
+		return fmt.Errorf("invalid uses '%s' of action mock: %w", m.Uses, err)
+	}
+	m.pattern = pattern.Regex
+	return nil
+}
+
+// Matches reports whether the uses value of a step matches the glob of the mock,
+// a mock never matches before it is compiled
+func (m *ActionMock) Matches(uses string) bool {
+	return m.pattern != nil && m.pattern.MatchString(uses)
+}
+
+// ReadActionMock reads the outputs, env and exit code of a mock for the actions matching uses from a JSON or YAML file
+func ReadActionMock(uses string, path string) (*ActionMock, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	mock := &ActionMock{}
+	if err := decodeActionMocks(content, mock); err != nil {
+		return nil, fmt.Errorf("unable to read action mock %s: %w", path, err)
+	}
+	mock.Uses = uses
+	if err := mock.Compile(); err != nil {
+		return nil, err
+	}
+	return mock, nil
+}
+
+// ReadActionMocks reads a list of action mocks from a JSON or YAML file
+func ReadActionMocks(path string) ([]*ActionMock, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	mocks := []*ActionMock{}
+	if err := decodeActionMocks(content, &mocks); err != nil {
+		return nil, fmt.Errorf("unable to read action mocks %s: %w", path, err)
+	}
+	for i, mock := range mocks {
+		if mock.Uses == "" {
+			return nil, fmt.Errorf("action mock %d in %s has no uses", i+1, path)
+		}
+		if err := mock.Compile(); err != nil {
+			return nil, fmt.Errorf("action mock %d in %s: %w", i+1, path, err)
+		}
+	}
+	return mocks, nil
+}
+
+func decodeActionMocks(content []byte, out interface{}) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	return decoder.Decode(out)
+}
+
+// actionMock returns the first mock matching the action of the step
+func (rc *RunContext) actionMock(stepModel *model.Step) *ActionMock {
+	if rc.Config == nil {
+		return nil
+	}
+	switch stepModel.Type() {
+	case model.StepTypeUsesActionLocal, model.StepTypeUsesActionRemote, model.StepTypeUsesDockerURL:
+	default:
+		return nil
+	}
+
+	for _, mock := range rc.Config.ActionMocks {
+		if mock.Matches(stepModel.Uses) {
+			return mock
+		}
+	}
+	return nil
+}
+
+type stepActionMock struct {
+	Step       *model.Step
+	RunContext *RunContext
+	Mock       *ActionMock
+	env        map[string]string
+}
+
+func (sam *stepActionMock) pre() common.Executor {
+	return func(_ context.Context) error {
+		return nil
+	}
+}
+
+func (sam *stepActionMock) main() common.Executor {
+	sam.env = map[string]string{}
+
+	return runStepExecutor(sam, stepStageMain, func(ctx context.Context) error {
+		rc := sam.RunContext
+		common.Logger(ctx).Infof("  \U0001F3AD  Mocked action %s", sam.Step.Uses)
+
+		eval := rc.NewExpressionEvaluatorWithEnv(ctx, sam.env)
+		for k, v := range sam.Mock.Outputs {
+			rc.setOutput(ctx, map[string]string{"name": k}, eval.Interpolate(ctx, v))
+		}
+		for k, v := range sam.Mock.Env {
+			rc.setEnv(ctx, map[string]string{"name": k}, eval.Interpolate(ctx, v))
+		}
+
+		if sam.Mock.ExitCode != 0 {
+			return fmt.Errorf("mocked action '%s' exited with code %d", sam.Step.Uses, sam.Mock.ExitCode)
+		}
+		return nil
+	})
+}
+
+func (sam *stepActionMock) post() common.Executor {
+	return func(_ context.Context) error {
+		return nil
+	}
+}
+
+func (sam *stepActionMock) getRunContext() *RunContext {
+	return sam.RunContext
+}
+
+func (sam *stepActionMock) getGithubContext(ctx context.Context) *model.GithubContext {
+	return sam.getRunContext().getGithubContext(ctx)
+}
+
+func (sam *stepActionMock) getStepModel() *model.Step {
+	return sam.Step
+}
+
+func (sam *stepActionMock) getEnv() *map[string]string {
+	return &sam.env
+}
+
+func (sam *stepActionMock) getIfExpression(_ context.Context, _ stepStage) string {
+	return sam.Step.If.Value
+}
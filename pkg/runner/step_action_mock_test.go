@@ -0,0 +1,130 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/nektos/act/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestActionMockMatches(t *testing.T) {
+	m := &ActionMock{Uses: "aws-actions/configure-aws-credentials@*"}
+	assert.False(t, m.Matches("aws-actions/configure-aws-credentials@v4"), "the mock is not compiled")
+	assert.NoError(t, m.Compile())
+	assert.True(t, m.Matches("aws-actions/configure-aws-credentials@v4"))
+	assert.False(t, m.Matches("aws-actions/amazon-ecr-login@v2"))
+
+	m = &ActionMock{Uses: "./.github/actions/**"}
+	assert.NoError(t, m.Compile())
+	assert.True(t, m.Matches("./.github/actions/deploy/prod"))
+	assert.False(t, m.Matches("./actions/deploy"))
+
+	m = &ActionMock{Uses: "actions/[setup-node@v4"}
+	assert.ErrorContains(t, m.Compile(), "invalid uses 'actions/[setup-node@v4' of action mock")
+}
+
+func TestReadActionMocks(t *testing.T) {
+	m, err := ReadActionMock("aws-actions/*@*", "testdata/mocks/outputs.json")
+	assert.NoError(t, err)
+	assert.Equal(t, "aws-actions/*@*", m.Uses)
+	assert.Equal(t, map[string]string{"aws-account-id": "123456789012"}, m.Outputs)
+	assert.Equal(t, map[string]string{"AWS_REGION": "eu-west-1"}, m.Env)
+	assert.True(t, m.Matches("aws-actions/configure-aws-credentials@v4"))
+
+	_, err = ReadActionMock("aws-actions/[*@*", "testdata/mocks/outputs.json")
+	assert.Error(t, err)
+
+	mocks, err := ReadActionMocks("testdata/mocks/mocks.yml")
+	assert.NoError(t, err)
+	assert.Len(t, mocks, 2)
+	assert.Equal(t, "123456789012", mocks[0].Outputs["aws-account-id"])
+	assert.Equal(t, 1, mocks[1].ExitCode)
+	assert.True(t, mocks[0].Matches(mocks[0].Uses))
+}
+
+func TestStepFactoryActionMock(t *testing.T) {
+	sf := &stepFactoryImpl{}
+	m := &ActionMock{Uses: "aws-actions/*@*"}
+	assert.NoError(t, m.Compile())
+	rc := &RunContext{
+		Config: &Config{
+			ActionMocks: []*ActionMock{m},
+		},
+	}
+
+	s, err := sf.newStep(&model.Step{Uses: "aws-actions/configure-aws-credentials@v4"}, rc)
+	assert.NoError(t, err)
+	assert.IsType(t, &stepActionMock{}, s)
+
+	s, err = sf.newStep(&model.Step{Uses: "actions/setup-node@v4"}, rc)
+	assert.NoError(t, err)
+	assert.IsType(t, &stepActionRemote{}, s)
+}
+
+func TestStepActionMockMain(t *testing.T) {
+	table := []struct {
+		name     string
+		exitCode int
+	}{
+		{name: "success", exitCode: 0},
+		{name: "failure", exitCode: 1},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			cm := &containerMock{}
+			ctx := context.Background()
+
+			sam := &stepActionMock{
+				RunContext: &RunContext{
+					StepResults: map[string]*model.StepResult{},
+					Config:      &Config{},
+					Run: &model.Run{
+						JobID: "1",
+						Workflow: &model.Workflow{
+							Jobs: map[string]*model.Job{
+								"1": {},
+							},
+						},
+					},
+					JobContainer: cm,
+				},
+				Step: &model.Step{
+					ID:   "creds",
+					Uses: "aws-actions/configure-aws-credentials@v4",
+				},
+				Mock: &ActionMock{
+					Uses:     "aws-actions/*@*",
+					Outputs:  map[string]string{"account": "${{ github.job }}"},
+					Env:      map[string]string{"AWS_REGION": "eu-west-1"},
+					ExitCode: tt.exitCode,
+				},
+			}
+			sam.RunContext.ExprEval = sam.RunContext.NewExpressionEvaluator(ctx)
+
+			cm.On("Copy", "/var/run/act", mock.AnythingOfType("[]*container.FileEntry")).Return(func(_ context.Context) error {
+				return nil
+			})
+			cm.On("UpdateFromEnv", mock.AnythingOfType("string"), mock.AnythingOfType("*map[string]string")).Return(func(_ context.Context) error {
+				return nil
+			})
+			cm.On("GetContainerArchive", ctx, "/var/run/act/workflow/SUMMARY.md").Return(io.NopCloser(&bytes.Buffer{}), nil)
+			cm.On("GetContainerArchive", ctx, "/var/run/act/workflow/pathcmd.txt").Return(io.NopCloser(&bytes.Buffer{}), nil)
+
+			err := sam.main()(ctx)
+			if tt.exitCode == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, model.StepStatusSuccess, sam.RunContext.StepResults["creds"].Conclusion)
+			} else {
+				assert.ErrorContains(t, err, "exited with code 1")
+				assert.Equal(t, model.StepStatusFailure, sam.RunContext.StepResults["creds"].Conclusion)
+			}
+			assert.Equal(t, "1", sam.RunContext.StepResults["creds"].Outputs["account"])
+			assert.Equal(t, "eu-west-1", sam.RunContext.GlobalEnv["AWS_REGION"])
+		})
+	}
+}
@@ -72,10 +72,13 @@ func (sd *stepDocker) runUsesContainer() common.Executor {
 			entrypoint = []string{entry}
 		}
 
-		stepContainer := sd.newStepContainer(ctx, image, cmd, entrypoint)
+		stepContainer, err := sd.newStepContainer(ctx, image, cmd, entrypoint)
+		if err != nil {
+			return err
+		}
 
 		return common.NewPipelineExecutor(
-			stepContainer.Pull(rc.Config.ForcePull),
+			stepContainer.Pull(rc.forcePull(image)),
 			stepContainer.Remove().IfBool(!rc.Config.ReuseContainers),
 			stepContainer.Create(rc.Config.ContainerCapAdd, rc.Config.ContainerCapDrop),
 			stepContainer.Start(true),
@@ -89,7 +92,7 @@ var (
 	ContainerNewContainer = container.NewContainer
 )
 
-func (sd *stepDocker) newStepContainer(ctx context.Context, image string, cmd []string, entrypoint []string) container.Container {
+func (sd *stepDocker) newStepContainer(ctx context.Context, image string, cmd []string, entrypoint []string) (container.Container, error) {
 	rc := sd.RunContext
 	step := sd.Step
 
@@ -113,7 +116,7 @@ func (sd *stepDocker) newStepContainer(ctx context.Context, image string, cmd []
 	envList = append(envList, fmt.Sprintf("%s=%s", "RUNNER_TEMP", "/tmp"))
 
 	binds, mounts := rc.GetBindsAndMounts()
-	stepContainer := ContainerNewContainer(&container.NewContainerInput{
+	return rc.createStepContainer(ctx, step, &container.NewContainerInput{
 		Cmd:         cmd,
 		Entrypoint:  entrypoint,
 		WorkingDir:  rc.JobContainer.ToContainerPath(rc.Config.Workdir),
@@ -130,6 +133,5 @@ func (sd *stepDocker) newStepContainer(ctx context.Context, image string, cmd []
 		Privileged:  rc.Config.Privileged,
 		UsernsMode:  rc.Config.UsernsMode,
 		Platform:    rc.Config.ContainerArchitecture,
-	})
-	return stepContainer
+	}, nil)
 }
@@ -13,6 +13,15 @@ type stepFactory interface {
 type stepFactoryImpl struct{}
 
 func (sf *stepFactoryImpl) newStep(stepModel *model.Step, rc *RunContext) (step, error) {
+	// mocked actions are neither fetched nor run
+	if mock := rc.actionMock(stepModel); mock != nil {
+		return &stepActionMock{
+			Step:       stepModel,
+			RunContext: rc,
+			Mock:       mock,
+		}, nil
+	}
+
 	switch stepModel.Type() {
 	case model.StepTypeInvalid:
 		return nil, fmt.Errorf("Invalid run/uses syntax for job:%s step:%+v", rc.Run, stepModel)
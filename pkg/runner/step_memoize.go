@@ -0,0 +1,173 @@
+package runner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nektos/act/pkg/common"
+	"github.com/nektos/act/pkg/container"
+)
+
+// stepMemo is the recorded result of a successful run step, it is replayed
+// instead of running the step again as long as the inputs of the step are unchanged
+type stepMemo struct {
+	Outputs map[string]string `json:"outputs"`
+	Env     map[string]string `json:"env"`
+	Path    []string          `json:"path"`
+}
+
+// containerImageID returns the ID of the image of a docker job container
+var containerImageID = container.ImageID
+
+// stepMemoKey returns the memoization key of a run step or an empty string if
+// the step is not memoized. The key covers the backend and image of the job, the
+// matrix, the interpolated script, shell and working directory, the env of the step
+// and the files of x-act-inputs. The GITHUB_, RUNNER_ and ACTIONS_ variables describe
+// the run instead of the inputs of the step and are ignored.
+func stepMemoKey(ctx context.Context, step step, stage stepStage) (string, error) {
+	rc := step.getRunContext()
+	if rc.Config.MemoizeDir == "" || stage != stepStageMain || common.Dryrun(ctx) {
+		return "", nil
+	}
+	if _, ok := step.(*stepRun); !ok {
+		return "", nil
+	}
+	stepModel := step.getStepModel()
+
+	// the ID changes with the content of an image, its name may refer to another image later
+	backend, image := rc.jobBackend(ctx)
+	if backend == container.BackendDocker {
+		id, err := containerImageID(ctx, image)
+		if err != nil {
+			return "", err
+		}
+		if id != "" {
+			image = id
+		}
+	}
+
+	env := map[string]string{}
+	for k, v := range *step.getEnv() {
+		if strings.HasPrefix(k, "GITHUB_") || strings.HasPrefix(k, "RUNNER_") || strings.HasPrefix(k, "ACTIONS_") {
+			continue
+		}
+		env[k] = v
+	}
+	ee := rc.NewStepExpressionEvaluator(ctx, step)
+	with := map[string]string{}
+	for k, v := range stepModel.With {
+		with[k] = ee.Interpolate(ctx, v)
+	}
+
+	files := ""
+	if stepModel.ActInputs != nil && len(stepModel.ActInputs.Files) > 0 {
+		fsys, err := rc.hashFilesFS(ctx)
+		if err != nil {
+			return "", err
+		}
+		if files, err = hashFilesOf(fsys, rc.JobContainer.ToContainerPath(rc.Config.Workdir), stepModel.ActInputs.Files, false); err != nil {
+			return "", err
+		}
+	}
+
+	// json.Marshal sorts the keys of maps, so equal inputs always produce the same key
+	inputs, err := json.Marshal(map[string]interface{}{
+		"workflow":          rc.Run.Workflow.File,
+		"job":               rc.Run.JobID,
+		"step":              stepModel.ID,
+		"backend":           backend,
+		"image":             image,
+		"matrix":            rc.Matrix,
+		"run":               ee.Interpolate(ctx, stepModel.Run),
+		"shell":             ee.Interpolate(ctx, stepModel.Shell),
+		"working-directory": ee.Interpolate(ctx, stepModel.WorkingDirectory),
+		"env":               env,
+		"with":              with,
+		"files":             files,
+	})
+	if err != nil {
+		return "", err
+	}
+	digest := sha256.Sum256(inputs)
+	return hex.EncodeToString(digest[:]), nil
+}
+
+func (rc *RunContext) stepMemoPath(key string) string {
+	return filepath.Join(rc.Config.MemoizeDir, key+".json")
+}
+
+// readStepMemo returns the recorded result for the key or nil if there is none
+func (rc *RunContext) readStepMemo(key string) (*stepMemo, error) {
+	content, err := os.ReadFile(rc.stepMemoPath(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	memo := &stepMemo{}
+	if err := json.Unmarshal(content, memo); err != nil {
+		return nil, err
+	}
+	return memo, nil
+}
+
+func (rc *RunContext) writeStepMemo(key string, memo *stepMemo) error {
+	if err := os.MkdirAll(rc.Config.MemoizeDir, 0o700); err != nil {
+		return err
+	}
+	content, err := json.Marshal(memo)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(rc.stepMemoPath(key), content, 0o600)
+}
+
+// newStepMemo records the outputs of the current step together with the env
+// and path changes since envBefore and pathBefore
+func (rc *RunContext) newStepMemo(envBefore map[string]string, pathBefore []string) *stepMemo {
+	memo := &stepMemo{
+		Outputs: map[string]string{},
+		Env:     map[string]string{},
+		Path:    []string{},
+	}
+	if result, ok := rc.StepResults[rc.CurrentStep]; ok {
+		for k, v := range result.Outputs {
+			memo.Outputs[k] = v
+		}
+	}
+	for k, v := range rc.Env {
+		if before, ok := envBefore[k]; !ok || before != v {
+			memo.Env[k] = v
+		}
+	}
+	known := map[string]bool{}
+	for _, p := range pathBefore {
+		known[p] = true
+	}
+	for _, p := range rc.ExtraPath {
+		if !known[p] {
+			memo.Path = append(memo.Path, p)
+		}
+	}
+	return memo
+}
+
+// replay applies the recorded outputs, env and path of the step
+func (memo *stepMemo) replay(ctx context.Context, rc *RunContext) {
+	for k, v := range memo.Outputs {
+		rc.setOutput(ctx, map[string]string{"name": k}, v)
+	}
+	for k, v := range memo.Env {
+		rc.setEnv(ctx, map[string]string{"name": k}, v)
+	}
+	// addPath prepends, so the recorded order is restored by adding the entries in reverse
+	for i := len(memo.Path) - 1; i >= 0; i-- {
+		rc.addPath(ctx, memo.Path[i])
+	}
+}
@@ -0,0 +1,141 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nektos/act/pkg/model"
+)
+
+func newMemoizeTestStep(t *testing.T, memoizeDir string, env map[string]string) *stepRun {
+	return &stepRun{
+		RunContext: &RunContext{
+			StepResults: map[string]*model.StepResult{},
+			ExprEval:    &expressionEvaluator{},
+			Config: &Config{
+				MemoizeDir: memoizeDir,
+				Platforms:  map[string]string{"ubuntu-latest": "node:20"},
+			},
+			Env: map[string]string{},
+			Run: &model.Run{
+				JobID: "build",
+				Workflow: &model.Workflow{
+					File: "ci.yml",
+					Jobs: map[string]*model.Job{"build": createJob(t, "runs-on: ubuntu-latest", "")},
+				},
+			},
+		},
+		Step: &model.Step{
+			ID:  "deps",
+			Run: "make deps",
+		},
+		env: env,
+	}
+}
+
+func TestStepMemoKey(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	imageIDs := map[string]string{"node:20": "sha256:1"}
+	origContainerImageID := containerImageID
+	containerImageID = func(_ context.Context, image string) (string, error) {
+		return imageIDs[image], nil
+	}
+	defer func() {
+		containerImageID = origContainerImageID
+	}()
+
+	key, err := stepMemoKey(ctx, newMemoizeTestStep(t, "", map[string]string{}), stepStageMain)
+	assert.NoError(t, err)
+	assert.Empty(t, key)
+
+	key, err = stepMemoKey(ctx, newMemoizeTestStep(t, dir, map[string]string{"GO": "1.22", "GITHUB_SHA": "a"}), stepStageMain)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, key)
+
+	other, err := stepMemoKey(ctx, newMemoizeTestStep(t, dir, map[string]string{"GO": "1.22", "GITHUB_SHA": "b"}), stepStageMain)
+	assert.NoError(t, err)
+	assert.Equal(t, key, other, "GITHUB_ variables are not part of the key")
+
+	other, err = stepMemoKey(ctx, newMemoizeTestStep(t, dir, map[string]string{"GO": "1.23"}), stepStageMain)
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, other)
+
+	changed := newMemoizeTestStep(t, dir, map[string]string{"GO": "1.22"})
+	changed.Step.Run = "make deps test"
+	other, err = stepMemoKey(ctx, changed, stepStageMain)
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, other)
+
+	imageIDs["node:20"] = "sha256:2"
+	other, err = stepMemoKey(ctx, newMemoizeTestStep(t, dir, map[string]string{"GO": "1.22"}), stepStageMain)
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, other, "the image of the job changed")
+	imageIDs["node:20"] = "sha256:1"
+
+	changed = newMemoizeTestStep(t, dir, map[string]string{"GO": "1.22"})
+	changed.RunContext.Config.Platforms["ubuntu-latest"] = "-self-hosted"
+	other, err = stepMemoKey(ctx, changed, stepStageMain)
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, other, "the backend of the job changed")
+
+	changed = newMemoizeTestStep(t, dir, map[string]string{"GO": "1.22"})
+	changed.RunContext.Matrix = map[string]interface{}{"os": "linux"}
+	other, err = stepMemoKey(ctx, changed, stepStageMain)
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, other, "the matrix of the job changed")
+
+	other, err = stepMemoKey(ctx, newMemoizeTestStep(t, dir, map[string]string{"GO": "1.22"}), stepStagePost)
+	assert.NoError(t, err)
+	assert.Empty(t, other)
+}
+
+func TestStepMemo(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	sr := newMemoizeTestStep(t, dir, map[string]string{})
+	rc := sr.getRunContext()
+	rc.CurrentStep = "deps"
+	rc.Env["KEEP"] = "same"
+	rc.ExtraPath = []string{"/usr/local/bin"}
+	envBefore := map[string]string{"KEEP": "same"}
+	pathBefore := append([]string{}, rc.ExtraPath...)
+
+	rc.StepResults["deps"] = &model.StepResult{Outputs: map[string]string{"version": "1.2.3"}}
+	rc.Env["DEPS"] = "ok"
+	rc.addPath(ctx, "/opt/deps/sbin")
+	rc.addPath(ctx, "/opt/deps/bin")
+
+	memo := rc.newStepMemo(envBefore, pathBefore)
+	assert.Equal(t, &stepMemo{
+		Outputs: map[string]string{"version": "1.2.3"},
+		Env:     map[string]string{"DEPS": "ok"},
+		Path:    []string{"/opt/deps/bin", "/opt/deps/sbin"},
+	}, memo)
+	assert.NoError(t, rc.writeStepMemo("key", memo))
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(rc.stepMemoPath("key"))
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm(), "memos may contain secrets")
+	}
+
+	missing, err := rc.readStepMemo("other")
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+
+	replayed := newMemoizeTestStep(t, dir, map[string]string{}).getRunContext()
+	replayed.CurrentStep = "deps"
+	replayed.StepResults["deps"] = &model.StepResult{Outputs: map[string]string{}}
+	replayed.ExtraPath = []string{"/usr/local/bin"}
+	read, err := replayed.readStepMemo("key")
+	assert.NoError(t, err)
+	read.replay(ctx, replayed)
+	assert.Equal(t, map[string]string{"version": "1.2.3"}, replayed.StepResults["deps"].Outputs)
+	assert.Equal(t, "ok", replayed.Env["DEPS"])
+	assert.Equal(t, []string{"/opt/deps/bin", "/opt/deps/sbin", "/usr/local/bin"}, replayed.ExtraPath)
+}
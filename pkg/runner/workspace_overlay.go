@@ -0,0 +1,246 @@
+package runner
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/moby/moby/api/types/system"
+
+	"github.com/nektos/act/pkg/common"
+	"github.com/nektos/act/pkg/container"
+)
+
+// workspace modes of the job containers
+const (
+	WorkspaceModeCopy    = "copy"    // the workspace is a volume, local actions/checkout copies the working directory into it
+	WorkspaceModeBind    = "bind"    // the working directory is bound to the workspace
+	WorkspaceModeOverlay = "overlay" // the working directory is the read-only lower layer of an overlay, the job writes to a layer of its own
+)
+
+// WorkspaceModes are the valid values of Config.WorkspaceMode
+var WorkspaceModes = []string{WorkspaceModeCopy, WorkspaceModeBind, WorkspaceModeOverlay}
+
+// workspaceOverlay is the copy-on-write workspace of a job, the changes of the
+// job are written to the upper layer in dir and never to the working directory
+type workspaceOverlay struct {
+	dir string
+	// fuse mounts the overlay with fuse-overlayfs on the host and binds it to the
+	// workspace, a rootless docker daemon is not able to mount an overlay volume
+	fuse    bool
+	mounted bool
+}
+
+func (o *workspaceOverlay) upperDir() string {
+	return filepath.Join(o.dir, "upper")
+}
+
+func (o *workspaceOverlay) workDir() string {
+	return filepath.Join(o.dir, "work")
+}
+
+func (o *workspaceOverlay) mergedDir() string {
+	return filepath.Join(o.dir, "merged")
+}
+
+// options returns the mount options of the overlay with lowerdir as lower layer
+func (o *workspaceOverlay) options(lowerdir string) string {
+	return fmt.Sprintf("lowerdir=%s,upperdir=%s,workdir=%s", lowerdir, o.upperDir(), o.workDir())
+}
+
+// volumeOptions returns the driver options of a local volume mounting the overlay
+func (o *workspaceOverlay) volumeOptions(lowerdir string) map[string]string {
+	return map[string]string{
+		"type":   "overlay",
+		"device": "overlay",
+		"o":      o.options(lowerdir),
+	}
+}
+
+// overlayDir returns the directory of the upper layer of the workspace of the job
+func (rc *RunContext) overlayDir() string {
+	return filepath.Join(rc.ActionCacheDir(), "overlay", rc.jobContainerName())
+}
+
+// newWorkspaceOverlay returns the overlay of the workspace of the job or nil if
+// the job does not use --workspace-mode overlay
+func (rc *RunContext) newWorkspaceOverlay(ctx context.Context) (*workspaceOverlay, error) {
+	if rc.Config.WorkspaceMode != WorkspaceModeOverlay {
+		return nil, nil
+	}
+	if runtime.GOOS != "linux" {
+		return nil, errors.New("--workspace-mode overlay is only supported on linux")
+	}
+
+	overlay := &workspaceOverlay{dir: rc.overlayDir()}
+	if info, err := container.GetHostInfo(ctx); err != nil {
+		common.Logger(ctx).Debugf("Unable to detect a rootless docker daemon: %v", err)
+	} else {
+		// the layers are directories of this host, another host or VM would mount its own directories
+		if reason := remoteDaemonReason(os.Getenv("DOCKER_HOST"), info); reason != "" {
+			return nil, fmt.Errorf("--workspace-mode overlay requires a docker daemon on this host, %s, use --workspace-mode copy instead", reason)
+		}
+		for _, option := range info.SecurityOptions {
+			if strings.Contains(option, "name=rootless") {
+				overlay.fuse = true
+			}
+		}
+	}
+	if overlay.fuse {
+		if _, err := exec.LookPath("fuse-overlayfs"); err != nil {
+			return nil, fmt.Errorf("--workspace-mode overlay requires fuse-overlayfs with a rootless docker daemon: %w", err)
+		}
+	}
+	return overlay, nil
+}
+
+// remoteDaemonReason returns why the docker daemon does not run on this host or "" if it does,
+// like a daemon connected by ssh or by tcp on another host or the VM of Docker Desktop
+func remoteDaemonReason(dockerHost string, info system.Info) string {
+	if u, err := url.Parse(dockerHost); err == nil {
+		switch u.Scheme {
+		case "ssh":
+			return fmt.Sprintf("the docker daemon %s is connected by ssh", u.Redacted())
+		case "tcp", "http", "https":
+			if ip := net.ParseIP(u.Hostname()); u.Hostname() != "localhost" && (ip == nil || !ip.IsLoopback()) {
+				return fmt.Sprintf("the docker daemon %s is on another host", u.Redacted())
+			}
+		}
+	}
+	if strings.Contains(info.OperatingSystem, "Docker Desktop") {
+		return "the docker daemon runs in the VM of Docker Desktop"
+	}
+	return ""
+}
+
+// mountWorkspaceOverlay creates the layers and mounts the overlay before the job container
+// is created, either as the workspace volume or with fuse-overlayfs on the host. The upper
+// layer of a reused job container is kept.
+func (rc *RunContext) mountWorkspaceOverlay() common.Executor {
+	return func(ctx context.Context) error {
+		overlay := rc.overlay
+		if overlay == nil {
+			return nil
+		}
+		logger := common.Logger(ctx)
+		if common.Dryrun(ctx) {
+			logger.Debugf("Mounting the workspace overlay %s", overlay.dir)
+			return nil
+		}
+
+		if !rc.Config.ReuseContainers {
+			// the overlay of a previous run is still mounted if act was interrupted
+			if err := overlay.unmount(ctx); err != nil {
+				return err
+			}
+			if err := os.RemoveAll(overlay.dir); err != nil {
+				return err
+			}
+		}
+		for _, dir := range []string{overlay.upperDir(), overlay.workDir(), overlay.mergedDir()} {
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return err
+			}
+		}
+		overlay.mounted = true
+
+		if !overlay.fuse {
+			return container.NewDockerVolumeCreateExecutor(rc.jobContainerName(), overlay.volumeOptions(rc.Config.Workdir))(ctx)
+		}
+		if mounted, err := isMountPoint(overlay.mergedDir()); err != nil || mounted {
+			return err
+		}
+		logger.Debugf("fuse-overlayfs -o %s %s", overlay.options(rc.Config.Workdir), overlay.mergedDir())
+		if output, err := exec.CommandContext(ctx, "fuse-overlayfs", "-o", overlay.options(rc.Config.Workdir), overlay.mergedDir()).CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to mount the workspace overlay: %w: %s", err, output)
+		}
+		return nil
+	}
+}
+
+// cleanUpWorkspaceOverlay unmounts the overlay, moves the upper layer to
+// --export-workspace if set and removes the layers of the job
+func (rc *RunContext) cleanUpWorkspaceOverlay() common.Executor {
+	return func(ctx context.Context) error {
+		overlay := rc.overlay
+		if overlay == nil || !overlay.mounted {
+			return nil
+		}
+		overlay.mounted = false
+		logger := common.Logger(ctx)
+		if err := overlay.unmount(ctx); err != nil {
+			return err
+		}
+
+		if rc.Config.ExportWorkspaceDir != "" {
+			dest := rc.workspaceExportPath()
+			if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+				return err
+			}
+			if err := os.RemoveAll(dest); err != nil {
+				return err
+			}
+			if err := os.Rename(overlay.upperDir(), dest); err != nil {
+				return fmt.Errorf("failed to export the workspace of job '%s': %w", rc.String(), err)
+			}
+			logger.Infof("\U0001F4E4  Exported the changes of the workspace to %s", dest)
+		}
+		// files written by the root user of the container may not be removable by act
+		if err := os.RemoveAll(overlay.dir); err != nil {
+			logger.Warnf("Unable to remove the workspace overlay %s: %v", overlay.dir, err)
+		}
+		return nil
+	}
+}
+
+// workspaceExportPath returns the directory the workspace of the job is exported to
+func (rc *RunContext) workspaceExportPath() string {
+	return filepath.Join(rc.Config.ExportWorkspaceDir, safeFilename(rc.String()))
+}
+
+// unmount unmounts the fuse-overlayfs of the overlay if it is mounted, an overlay
+// volume is unmounted by docker when the volume is removed
+func (o *workspaceOverlay) unmount(ctx context.Context) error {
+	if !o.fuse {
+		return nil
+	}
+	if mounted, err := isMountPoint(o.mergedDir()); err != nil || !mounted {
+		return err
+	}
+	if output, err := exec.CommandContext(ctx, "fusermount", "-u", o.mergedDir()).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to unmount the workspace overlay: %w: %s", err, output)
+	}
+	return nil
+}
+
+// isMountPoint returns whether a file system is mounted at the path
+func isMountPoint(path string) (bool, error) {
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// the 5th field is the mount point, see proc(5)
+		fields := strings.Fields(scanner.Text())
+		if len(fields) > 4 && unescapeMountInfo(fields[4]) == path {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
+
+// unescapeMountInfo replaces the octal escapes of spaces, tabs, newlines and backslashes in mountinfo
+func unescapeMountInfo(s string) string {
+	return strings.NewReplacer(`\040`, " ", `\011`, "\t", `\012`, "\n", `\134`, `\`).Replace(s)
+}
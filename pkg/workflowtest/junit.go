@@ -0,0 +1,101 @@
+package workflowtest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// SuiteResults are the results of all tests of a suite
+type SuiteResults struct {
+	Suite   *Suite
+	Results []*Result
+}
+
+// WriteJUnit writes a JUnit compatible XML report of the results
+func WriteJUnit(w io.Writer, suites []SuiteResults) error {
+	report := junitTestSuites{}
+	for _, s := range suites {
+		suite := junitTestSuite{
+			Name:  s.Suite.Name,
+			Tests: len(s.Results),
+		}
+		var duration time.Duration
+		for _, result := range s.Results {
+			duration += result.Duration
+			testCase := junitTestCase{
+				Name:      result.Name,
+				ClassName: s.Suite.Name,
+				Time:      junitTime(result.Duration),
+			}
+			if result.Error != nil {
+				suite.Errors++
+				testCase.Error = &junitMessage{
+					Message: result.Error.Error(),
+					Text:    result.Error.Error(),
+				}
+			} else if len(result.Failures) > 0 {
+				suite.Failures++
+				testCase.Failure = &junitMessage{
+					Message: result.Failures[0],
+					Text:    strings.Join(result.Failures, "\n"),
+				}
+			}
+			if !result.Passed() {
+				lines := make([]string, 0, len(result.Entries))
+				for _, entry := range result.Entries {
+					lines = append(lines, entry.String())
+				}
+				testCase.SystemOut = strings.Join(lines, "\n")
+			}
+			suite.TestCases = append(suite.TestCases, testCase)
+		}
+		suite.Time = junitTime(duration)
+		report.Suites = append(report.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func junitTime(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
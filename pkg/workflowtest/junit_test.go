@@ -0,0 +1,37 @@
+package workflowtest
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteJUnit(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := WriteJUnit(buf, []SuiteResults{{
+		Suite: &Suite{Name: "release"},
+		Results: []*Result{
+			{Name: "builds", Duration: 1500 * time.Millisecond},
+			{Name: "deploys", Duration: 500 * time.Millisecond, Failures: []string{"job 'deploy' failed"}, Entries: []Entry{{JobID: "deploy", Message: "exit code 1"}}},
+			{Name: "broken", Error: errors.New("unable to plan")},
+		},
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="release" tests="3" failures="1" errors="1" time="2.000">
+    <testcase name="builds" classname="release" time="1.500"></testcase>
+    <testcase name="deploys" classname="release" time="0.500">
+      <failure message="job &#39;deploy&#39; failed">job &#39;deploy&#39; failed</failure>
+      <system-out>[deploy] exit code 1</system-out>
+    </testcase>
+    <testcase name="broken" classname="release" time="0.000">
+      <error message="unable to plan">unable to plan</error>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, buf.String())
+}
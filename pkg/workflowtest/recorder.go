@@ -0,0 +1,67 @@
+package workflowtest
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Entry is a log entry recorded during a test run
+type Entry struct {
+	JobID   string
+	Message string
+	Data    logrus.Fields
+}
+
+func (e Entry) String() string {
+	return fmt.Sprintf("[%s] %s", e.JobID, e.Message)
+}
+
+// Recorder records the log entries of all jobs, use it as the job logger
+// factory of a test run
+type Recorder struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// WithJobLogger returns a logger recording all entries of a job
+func (r *Recorder) WithJobLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	logger.SetLevel(logrus.GetLevel())
+	logger.SetFormatter(&recordingFormatter{recorder: r})
+	return logger
+}
+
+// Entries returns the recorded entries in the order they were logged
+func (r *Recorder) Entries() []Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Entry{}, r.entries...)
+}
+
+// recordingFormatter is wrapped by the masking formatter of the runner, so
+// that secrets are masked in the recorded messages
+type recordingFormatter struct {
+	recorder *Recorder
+}
+
+func (f *recordingFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	data := make(logrus.Fields, len(entry.Data))
+	for k, v := range entry.Data {
+		data[k] = v
+	}
+	jobID, _ := entry.Data["jobID"].(string)
+
+	f.recorder.mu.Lock()
+	defer f.recorder.mu.Unlock()
+	f.recorder.entries = append(f.recorder.entries, Entry{
+		JobID:   jobID,
+		Message: entry.Message,
+		Data:    data,
+	})
+	return nil, nil
+}
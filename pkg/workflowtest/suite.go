@@ -0,0 +1,147 @@
+// Package workflowtest runs declarative tests against workflows and verifies
+// the results, outputs, env and logs of their jobs
+package workflowtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/nektos/act/pkg/runner"
+)
+
+// Suite is a file containing workflow tests
+type Suite struct {
+	Name  string  `yaml:"name"`
+	Tests []*Test `yaml:"tests"`
+	File  string  `yaml:"-"`
+}
+
+// Test describes a single triggered run of the workflows and its expected results
+type Test struct {
+	Name        string                 `yaml:"name"`
+	Workflow    string                 `yaml:"workflow"`
+	Event       string                 `yaml:"event"`
+	Payload     map[string]interface{} `yaml:"payload"`
+	PayloadFile string                 `yaml:"payload-file"`
+	Inputs      map[string]string      `yaml:"inputs"`
+	Secrets     map[string]string      `yaml:"secrets"`
+	Vars        map[string]string      `yaml:"vars"`
+	Env         map[string]string      `yaml:"env"`
+	Mocks       []*runner.ActionMock   `yaml:"mocks"`
+	Expect      Expectations           `yaml:"expect"`
+}
+
+// Expectations are verified after the run of a test
+type Expectations struct {
+	Jobs map[string]*JobExpectation `yaml:"jobs"`
+	Logs []string                   `yaml:"logs"`
+}
+
+// JobExpectation is the expected result of a single job
+type JobExpectation struct {
+	// Result is one of success, failure or skipped
+	Result  string            `yaml:"result"`
+	Outputs map[string]string `yaml:"outputs"`
+	// Env contains the values the job exported through GITHUB_ENV
+	Env  map[string]string `yaml:"env"`
+	Logs []string          `yaml:"logs"`
+}
+
+// ReadSuite reads and validates a test suite file
+func ReadSuite(path string) (*Suite, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	suite := &Suite{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(suite); err != nil {
+		return nil, fmt.Errorf("unable to read test suite %s: %w", path, err)
+	}
+	suite.File = path
+	if suite.Name == "" {
+		suite.Name = filepath.Base(path)
+	}
+
+	if len(suite.Tests) == 0 {
+		return nil, fmt.Errorf("test suite %s contains no tests", path)
+	}
+	names := map[string]bool{}
+	for i, test := range suite.Tests {
+		if test.Name == "" {
+			test.Name = fmt.Sprintf("test-%d", i+1)
+		}
+		if names[test.Name] {
+			return nil, fmt.Errorf("test suite %s contains the test '%s' more than once", path, test.Name)
+		}
+		names[test.Name] = true
+		if test.Event == "" {
+			test.Event = "push"
+		}
+		if test.Payload != nil && test.PayloadFile != "" {
+			return nil, fmt.Errorf("test '%s' sets both payload and payload-file", test.Name)
+		}
+		for _, mock := range test.Mocks {
+			if mock.Uses == "" {
+				return nil, fmt.Errorf("test '%s' contains a mock without uses", test.Name)
+			}
+			if err := mock.Compile(); err != nil {
+				return nil, fmt.Errorf("test '%s': %w", test.Name, err)
+			}
+		}
+		for jobID, job := range test.Expect.Jobs {
+			if job == nil {
+				test.Expect.Jobs[jobID] = &JobExpectation{}
+				continue
+			}
+			switch job.Result {
+			case "", "success", "failure", "skipped":
+			default:
+				return nil, fmt.Errorf("test '%s' expects the unknown result '%s' for job '%s'", test.Name, job.Result, jobID)
+			}
+		}
+	}
+	return suite, nil
+}
+
+// EventPayload returns the event JSON of the test, the inputs of the test are
+// merged into the inputs of the payload. A relative payload-file is resolved
+// against dir.
+func (t *Test) EventPayload(dir string) ([]byte, error) {
+	payload := map[string]interface{}{}
+	if t.PayloadFile != "" {
+		path := t.PayloadFile
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(content, &payload); err != nil {
+			return nil, fmt.Errorf("unable to read payload-file %s: %w", path, err)
+		}
+	}
+	for k, v := range t.Payload {
+		payload[k] = v
+	}
+
+	if len(t.Inputs) > 0 {
+		inputs, _ := payload["inputs"].(map[string]interface{})
+		if inputs == nil {
+			inputs = map[string]interface{}{}
+		}
+		for k, v := range t.Inputs {
+			inputs[k] = v
+		}
+		payload["inputs"] = inputs
+	}
+	return json.Marshal(payload)
+}
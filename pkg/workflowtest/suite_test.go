@@ -0,0 +1,53 @@
+package workflowtest
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadSuite(t *testing.T) {
+	suite, err := ReadSuite("testdata/release.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, "release", suite.Name)
+	assert.Len(t, suite.Tests, 2)
+
+	test := suite.Tests[0]
+	assert.Equal(t, "push", test.Event)
+	assert.Equal(t, "success", test.Expect.Jobs["build"].Result)
+	assert.Equal(t, "1.2.3", test.Expect.Jobs["build"].Outputs["version"])
+	assert.Equal(t, []string{"deploying 1.2.3 to eu-west-1"}, test.Expect.Jobs["deploy"].Logs)
+	assert.Len(t, test.Mocks, 1)
+	assert.Equal(t, "eu-west-1", test.Mocks[0].Env["AWS_REGION"])
+	assert.True(t, test.Mocks[0].Matches("aws-actions/configure-aws-credentials@v4"), "the mocks of a suite are compiled")
+
+	assert.Equal(t, "test-2", suite.Tests[1].Name)
+	assert.Equal(t, "workflow_dispatch", suite.Tests[1].Event)
+	assert.Equal(t, &JobExpectation{}, suite.Tests[1].Expect.Jobs["build"])
+	assert.Equal(t, "skipped", suite.Tests[1].Expect.Jobs["deploy"].Result)
+
+	_, err = ReadSuite("testdata/invalid.yaml")
+	assert.ErrorContains(t, err, "unknown result 'passed'")
+}
+
+func TestEventPayload(t *testing.T) {
+	suite, err := ReadSuite("testdata/release.yaml")
+	assert.NoError(t, err)
+
+	payload, err := suite.Tests[0].EventPayload("testdata")
+	assert.NoError(t, err)
+	event := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(payload, &event))
+	assert.Equal(t, map[string]interface{}{
+		"ref": "refs/tags/v1.2.3",
+		"inputs": map[string]interface{}{
+			"other":  "x",
+			"deploy": "true",
+		},
+	}, event)
+
+	payload, err = suite.Tests[1].EventPayload("testdata")
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"ref": "refs/heads/main"}`, string(payload))
+}
@@ -0,0 +1,147 @@
+package workflowtest
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/nektos/act/pkg/model"
+)
+
+// Result is the outcome of a single test
+type Result struct {
+	Name     string
+	Duration time.Duration
+	// Failures contains the unmet expectations of the test
+	Failures []string
+	// Error is set if the test could not be run at all
+	Error   error
+	Entries []Entry
+}
+
+// Passed reports whether the test ran and met all expectations
+func (r *Result) Passed() bool {
+	return r.Error == nil && len(r.Failures) == 0
+}
+
+// Verify compares the jobs of the executed plan and the recorded log entries
+// with the expectations of the test and returns the unmet expectations
+func (t *Test) Verify(plan *model.Plan, entries []Entry, runErr error) []string {
+	failures := []string{}
+
+	jobs := map[string]*model.Job{}
+	jobFailed := false
+	for _, stage := range plan.Stages {
+		for _, run := range stage.Runs {
+			if _, ok := jobs[run.JobID]; ok {
+				continue
+			}
+			job := run.Job()
+			jobs[run.JobID] = job
+			if job.Result == "failure" {
+				jobFailed = true
+				if _, expected := t.Expect.Jobs[run.JobID]; !expected {
+					failures = append(failures, fmt.Sprintf("job '%s' failed", run.JobID))
+				}
+			}
+		}
+	}
+	if runErr != nil && !jobFailed {
+		failures = append(failures, fmt.Sprintf("run failed: %v", runErr))
+	}
+
+	jobIDs := make([]string, 0, len(t.Expect.Jobs))
+	for jobID := range t.Expect.Jobs {
+		jobIDs = append(jobIDs, jobID)
+	}
+	sort.Strings(jobIDs)
+
+	for _, jobID := range jobIDs {
+		expect := t.Expect.Jobs[jobID]
+		job, ok := jobs[jobID]
+		if !ok {
+			// the workflow of the job has not been triggered by the event
+			if expect.Result != "skipped" {
+				failures = append(failures, fmt.Sprintf("job '%s' is not part of the plan for event '%s'", jobID, t.Event))
+			}
+			continue
+		}
+
+		result := job.Result
+		if result == "" {
+			// jobs are not started if a job they need has failed
+			result = "skipped"
+		}
+		if expect.Result != "" && expect.Result != result {
+			failures = append(failures, fmt.Sprintf("job '%s': expected result '%s', got '%s'", jobID, expect.Result, result))
+		}
+
+		for _, name := range sortedKeys(expect.Outputs) {
+			if value := job.Outputs[name]; value != expect.Outputs[name] {
+				failures = append(failures, fmt.Sprintf("job '%s': expected output '%s' to be '%s', got '%s'", jobID, name, expect.Outputs[name], value))
+			}
+		}
+
+		env := exportedEnv(entries, jobID)
+		for _, name := range sortedKeys(expect.Env) {
+			if value, ok := env[name]; !ok {
+				failures = append(failures, fmt.Sprintf("job '%s': expected env '%s' to be exported", jobID, name))
+			} else if value != expect.Env[name] {
+				failures = append(failures, fmt.Sprintf("job '%s': expected env '%s' to be '%s', got '%s'", jobID, name, expect.Env[name], value))
+			}
+		}
+
+		for _, pattern := range expect.Logs {
+			if failure := matchLogs(entries, jobID, pattern); failure != "" {
+				failures = append(failures, fmt.Sprintf("job '%s': %s", jobID, failure))
+			}
+		}
+	}
+
+	for _, pattern := range t.Expect.Logs {
+		if failure := matchLogs(entries, "", pattern); failure != "" {
+			failures = append(failures, failure)
+		}
+	}
+
+	return failures
+}
+
+// exportedEnv returns the env values a job has set through GITHUB_ENV or the set-env command
+func exportedEnv(entries []Entry, jobID string) map[string]string {
+	env := map[string]string{}
+	for _, entry := range entries {
+		if entry.JobID != jobID || entry.Data["command"] != "set-env" {
+			continue
+		}
+		name, _ := entry.Data["name"].(string)
+		value, _ := entry.Data["arg"].(string)
+		env[name] = value
+	}
+	return env
+}
+
+// matchLogs checks that a log message of the job matches the pattern, an
+// empty jobID matches the messages of all jobs
+func matchLogs(entries []Entry, jobID string, pattern string) string {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Sprintf("invalid log pattern '%s': %v", pattern, err)
+	}
+	for _, entry := range entries {
+		if (jobID == "" || entry.JobID == jobID) && re.MatchString(entry.Message) {
+			return ""
+		}
+	}
+	return fmt.Sprintf("no log line matches '%s'", pattern)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,94 @@
+package workflowtest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nektos/act/pkg/model"
+)
+
+func newTestPlan(jobs map[string]*model.Job) *model.Plan {
+	workflow := &model.Workflow{Name: "release", Jobs: jobs}
+	stage := &model.Stage{}
+	for _, jobID := range []string{"build", "deploy"} {
+		if _, ok := jobs[jobID]; ok {
+			stage.Runs = append(stage.Runs, &model.Run{Workflow: workflow, JobID: jobID})
+		}
+	}
+	return &model.Plan{Stages: []*model.Stage{stage}}
+}
+
+func TestVerify(t *testing.T) {
+	recorder := &Recorder{}
+	logger := recorder.WithJobLogger()
+	build := logger.WithField("jobID", "build")
+	build.Info("building 1.2.3")
+	build.WithFields(logrus.Fields{"command": "set-env", "name": "CHANNEL", "arg": "stable"}).Info("::set-env:: CHANNEL=stable")
+	logger.WithField("jobID", "deploy").Info("deploying")
+
+	plan := newTestPlan(map[string]*model.Job{
+		"build":  {Result: "success", Outputs: map[string]string{"version": "1.2.3"}},
+		"deploy": {},
+	})
+
+	test := &Test{
+		Event: "push",
+		Expect: Expectations{
+			Jobs: map[string]*JobExpectation{
+				"build": {
+					Result:  "success",
+					Outputs: map[string]string{"version": "1.2.3"},
+					Env:     map[string]string{"CHANNEL": "stable"},
+					Logs:    []string{`building \d+\.\d+\.\d+`},
+				},
+				"deploy": {Result: "skipped"},
+				"lint":   {Result: "skipped"},
+			},
+			Logs: []string{"deploying"},
+		},
+	}
+	assert.Empty(t, test.Verify(plan, recorder.Entries(), nil))
+
+	test = &Test{
+		Event: "push",
+		Expect: Expectations{
+			Jobs: map[string]*JobExpectation{
+				"build": {
+					Result:  "failure",
+					Outputs: map[string]string{"version": "2.0.0"},
+					Env:     map[string]string{"CHANNEL": "beta", "TARGET": "prod"},
+					Logs:    []string{"deploying", "("},
+				},
+				"lint": {Result: "success"},
+			},
+			Logs: []string{"released"},
+		},
+	}
+	assert.Equal(t, []string{
+		"job 'build': expected result 'failure', got 'success'",
+		"job 'build': expected output 'version' to be '2.0.0', got '1.2.3'",
+		"job 'build': expected env 'CHANNEL' to be 'beta', got 'stable'",
+		"job 'build': expected env 'TARGET' to be exported",
+		"job 'build': no log line matches 'deploying'",
+		"job 'build': invalid log pattern '(': error parsing regexp: missing closing ): `(`",
+		"job 'lint' is not part of the plan for event 'push'",
+		"no log line matches 'released'",
+	}, test.Verify(plan, recorder.Entries(), nil))
+}
+
+func TestVerifyUnexpectedFailure(t *testing.T) {
+	test := &Test{Event: "push"}
+
+	plan := newTestPlan(map[string]*model.Job{
+		"build": {Result: "failure"},
+	})
+	assert.Equal(t, []string{"job 'build' failed"}, test.Verify(plan, nil, errors.New("Job 'build' failed")))
+
+	plan = newTestPlan(map[string]*model.Job{
+		"build": {Result: "success"},
+	})
+	assert.Equal(t, []string{"run failed: no space left"}, test.Verify(plan, nil, errors.New("no space left")))
+}